@@ -0,0 +1,8 @@
+data "visionone_container_cluster_group" "default" {
+  name = "Default"
+}
+
+resource "visionone_container_cluster" "example_cluster" {
+  name     = "example_cluster"
+  group_id = data.visionone_container_cluster_group.default.id
+}
@@ -0,0 +1,22 @@
+# All EKS clusters using the platform policy
+data "visionone_container_clusters" "eks" {
+  orchestrator = "Amazon EKS"
+  policy_id    = "LogOnlyPolicy-2hZs6I3K9Dq8sMh6UdjWg1R1fyB"
+}
+
+output "eks_cluster_names" {
+  value = data.visionone_container_clusters.eks.clusters[*].name
+}
+
+# Pods reported by the agent of the production clusters
+data "visionone_container_clusters" "prod" {
+  name_regex = "^prod-"
+}
+
+output "prod_pods" {
+  value = flatten([
+    for cluster in data.visionone_container_clusters.prod.clusters : [
+      for node in cluster.nodes : node.pods[*].name
+    ]
+  ])
+}
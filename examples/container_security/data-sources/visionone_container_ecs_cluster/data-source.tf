@@ -0,0 +1,7 @@
+data "visionone_container_ecs_cluster" "payments" {
+  cluster_arn = "arn:aws:ecs:us-east-1:123456789012:cluster/payments"
+}
+
+output "payments_policy_id" {
+  value = data.visionone_container_ecs_cluster.payments.policy_id
+}
@@ -0,0 +1,14 @@
+data "visionone_container_managed_rules" "execution" {
+  tag        = "T1059"
+  mitigation = "isolate"
+}
+
+resource "visionone_container_ruleset" "execution" {
+  name = "CommandExecution"
+  rules = [
+    for id in data.visionone_container_managed_rules.execution.ids : {
+      id         = id
+      mitigation = "isolate"
+    }
+  ]
+}
@@ -0,0 +1,9 @@
+# Look up the policy owned by the platform team by name
+data "visionone_container_policies" "platform" {
+  name = "platform-baseline"
+}
+
+resource "visionone_container_cluster" "app" {
+  name      = "app-cluster"
+  policy_id = one(data.visionone_container_policies.platform.ids)
+}
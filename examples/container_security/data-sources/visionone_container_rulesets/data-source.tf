@@ -0,0 +1,7 @@
+data "visionone_container_rulesets" "audit" {
+  name_regex = "^audit-"
+}
+
+output "audit_ruleset_ids" {
+  value = data.visionone_container_rulesets.audit.ids
+}
@@ -0,0 +1,10 @@
+data "visionone_customizable_tag" "production" {
+  key   = "environment"
+  value = "production"
+}
+
+resource "visionone_container_cluster" "example_cluster" {
+  name              = "example_cluster"
+  group_id          = data.visionone_container_cluster_group.default.id
+  customizable_tags = [{ id = data.visionone_customizable_tag.production.id }]
+}
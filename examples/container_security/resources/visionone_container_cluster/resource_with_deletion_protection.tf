@@ -0,0 +1,7 @@
+resource "visionone_container_cluster" "example_cluster" {
+  #...
+  deletion_protection = {
+    agent_window         = "15m"
+    wait_for_agents_gone = "10m"
+  }
+}
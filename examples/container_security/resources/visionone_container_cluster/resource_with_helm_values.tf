@@ -0,0 +1,14 @@
+resource "visionone_container_cluster" "example_cluster" {
+  #...
+  platform = "EKS"
+}
+
+resource "helm_release" "trendmicro" {
+  name             = "trendmicro"
+  chart            = "https://github.com/trendmicro/visionone-container-security-helm/archive/main.tar.gz"
+  namespace        = "trendmicro-system"
+  create_namespace = true
+  wait             = false
+
+  values = [visionone_container_cluster.example_cluster.helm_values_yaml]
+}
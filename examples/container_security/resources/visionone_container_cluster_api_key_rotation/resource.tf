@@ -0,0 +1,26 @@
+resource "visionone_container_cluster_api_key_rotation" "example_rotation" {
+  cluster_id   = visionone_container_cluster.example_cluster.id
+  rotate_after = "90d"
+
+  rotation_trigger = {
+    # Change to rotate the key outside of the schedule
+    reason = "initial"
+  }
+}
+
+resource "helm_release" "trendmicro" {
+  name             = "trendmicro"
+  chart            = "https://github.com/trendmicro/visionone-container-security-helm/archive/main.tar.gz"
+  namespace        = "trendmicro-system"
+  create_namespace = true
+  wait             = false
+
+  set_sensitive {
+    name  = "visionOne.bootstrapToken"
+    value = visionone_container_cluster_api_key_rotation.example_rotation.api_key
+  }
+  set {
+    name  = "visionOne.endpoint"
+    value = visionone_container_cluster.example_cluster.endpoint
+  }
+}
@@ -0,0 +1,9 @@
+resource "visionone_container_cluster_group" "payments" {
+  name        = "payments"
+  description = "Clusters running the payment services"
+}
+
+resource "visionone_container_cluster" "payments_prod" {
+  name     = "payments-prod"
+  group_id = visionone_container_cluster_group.payments.id
+}
@@ -0,0 +1,6 @@
+resource "visionone_container_ecs_cluster" "payments" {
+  cluster_arn                = "arn:aws:ecs:us-east-1:123456789012:cluster/payments"
+  policy_id                  = visionone_container_policy.example_policy.id
+  runtime_security_enabled   = true
+  vulnerability_scan_enabled = true
+}
@@ -0,0 +1,52 @@
+resource "visionone_container_policy" "example_typed_policy" {
+  name        = "TypedStatementsPolicy"
+  description = "A policy written with typed rule statements. The rule type is derived from the statement."
+  default = {
+    rules = [
+      {
+        action     = "block"
+        mitigation = "log"
+        pod_security_context = {
+          host_network = true
+          host_pid     = true
+        }
+      },
+      {
+        action = "log"
+        registry = {
+          operator = "notEquals"
+          value    = "registry.example.com"
+        }
+      },
+      {
+        action = "block"
+        vulnerabilities = {
+          max_severity = "high"
+        }
+      },
+      {
+        action = "block"
+        unscanned_image_malware = {
+          days = 30
+        }
+      },
+      {
+        action   = "log"
+        pod_exec = true
+      },
+      {
+        # Generic statement for rule types without a typed statement
+        action = "log"
+        type   = "unscannedImage"
+      }
+    ]
+    exceptions = [
+      {
+        image = {
+          operator = "startsWith"
+          value    = "registry.example.com/trusted/"
+        }
+      }
+    ]
+  }
+}
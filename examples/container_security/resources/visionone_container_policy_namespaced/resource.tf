@@ -0,0 +1,29 @@
+resource "visionone_container_policy_namespaced" "payments" {
+  policy_id  = visionone_container_policy.example_policy.id
+  name       = "payments"
+  namespaces = ["payments", "payments-batch"]
+
+  rules = [
+    {
+      action = "block"
+      vulnerabilities = {
+        max_severity = "high"
+      }
+    },
+    {
+      action = "block"
+      registry = {
+        operator = "notEquals"
+        value    = "registry.example.com"
+      }
+    }
+  ]
+  exceptions = [
+    {
+      image = {
+        operator = "startsWith"
+        value    = "registry.example.com/payments/debug-"
+      }
+    }
+  ]
+}
@@ -0,0 +1,23 @@
+resource "visionone_container_ruleset" "example_custom_ruleset" {
+  name        = "CustomRuleset"
+  description = "Managed rules together with rules authored for our workloads."
+
+  rules = [
+    {
+      id         = "TM-00000006"
+      mitigation = "log"
+    }
+  ]
+
+  custom_rules = [
+    {
+      name        = "Netcat started in container"
+      description = "Detects netcat, often used for reverse shells."
+      condition   = "spawned_process and container and proc.name in (nc, ncat, netcat)"
+      output      = "Netcat started in a container (user=%user.name command=%proc.cmdline container=%container.id)"
+      priority    = "warning"
+      tags        = ["T1059", "execution"]
+      mitigation  = "isolate"
+    }
+  ]
+}
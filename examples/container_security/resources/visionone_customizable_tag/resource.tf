@@ -0,0 +1,10 @@
+resource "visionone_customizable_tag" "production" {
+  key   = "environment"
+  value = "production"
+}
+
+resource "visionone_container_cluster" "payments_prod" {
+  name              = "payments-prod"
+  group_id          = visionone_container_cluster_group.payments.id
+  customizable_tags = [{ id = visionone_customizable_tag.production.id }]
+}
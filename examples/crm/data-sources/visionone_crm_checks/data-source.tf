@@ -0,0 +1,20 @@
+data "visionone_crm_account" "production" {
+  aws_account_id = "123456789012"
+}
+
+data "visionone_crm_checks" "blocking" {
+  account_ids = [data.visionone_crm_account.production.id]
+  statuses    = ["FAILURE"]
+  risk_levels = ["VERY_HIGH", "EXTREME"]
+  suppressed  = false
+}
+
+# Fail the plan of the production deploy while blocking checks are open
+resource "terraform_data" "deploy_gate" {
+  lifecycle {
+    precondition {
+      condition     = length(data.visionone_crm_checks.blocking.ids) == 0
+      error_message = "Open VERY_HIGH or EXTREME checks: ${join(", ", [for c in data.visionone_crm_checks.blocking.checks : "${c.rule_id} on ${c.resource_id}"])}"
+    }
+  }
+}
@@ -0,0 +1,8 @@
+data "visionone_crm_compliance_standards" "all" {}
+
+output "aws_compliance_standards" {
+  value = {
+    for standard in data.visionone_crm_compliance_standards.all.compliance_standards : standard.id => standard.name
+    if contains(standard.providers, "aws")
+  }
+}
@@ -0,0 +1,29 @@
+data "visionone_crm_rules" "cis_high" {
+  providers               = ["aws"]
+  compliance_standard_ids = ["CISAWSF-3_0"]
+  risk_levels             = ["HIGH", "VERY_HIGH", "EXTREME"]
+}
+
+# Enable every AWS rule mapped to CIS v3 at HIGH or above
+resource "visionone_crm_profile" "cis_high" {
+  name        = "cis-v3-high"
+  description = "AWS rules mapped to CIS v3 at HIGH or above"
+
+  dynamic "scan_rule" {
+    for_each = { for rule in data.visionone_crm_rules.cis_high.rules : rule.id => rule }
+    content {
+      id         = scan_rule.value.id
+      provider   = scan_rule.value.provider
+      enabled    = true
+      risk_level = scan_rule.value.risk_level
+    }
+  }
+}
+
+output "configurable_rules" {
+  value = {
+    for rule in data.visionone_crm_rules.cis_high.rules : rule.id => {
+      for setting in rule.extra_settings : setting.name => jsondecode(setting.default_value)
+    } if length(rule.extra_settings) > 0
+  }
+}
@@ -0,0 +1,20 @@
+# Unit test a custom rule against sample resources, for example with terraform test
+locals {
+  versioned_bucket   = jsonencode({ data = { BucketVersioning = { Status = "Enabled" } } })
+  unversioned_bucket = jsonencode({ data = { BucketVersioning = { Status = "Suspended" } } })
+}
+
+output "versioned_bucket_status" {
+  # "SUCCESS"
+  value = provider::visionone::crm_custom_rule_evaluate(visionone_crm_custom_rule.s3_versioning_check, local.versioned_bucket)
+}
+
+output "unversioned_bucket_status" {
+  # "FAILURE"
+  value = provider::visionone::crm_custom_rule_evaluate(visionone_crm_custom_rule.s3_versioning_check, local.unversioned_bucket)
+}
+
+# Rules kept as JSON in the API format can be evaluated too
+output "rule_file_status" {
+  value = provider::visionone::crm_custom_rule_evaluate(file("${path.module}/rules/s3-versioning.json"), local.versioned_bucket)
+}
@@ -0,0 +1,10 @@
+# Export a profile managed with scan_rule blocks, for example to share it or to import it elsewhere
+resource "local_file" "baseline_export" {
+  filename = "${path.module}/profiles/baseline.json"
+  content  = provider::visionone::crm_profile_export(visionone_crm_profile.with_rules)
+}
+
+# Convert a profile exported from Cloud One Conformity to the Vision One format
+output "converted_profile" {
+  value = provider::visionone::crm_profile_export(file("${path.module}/profiles/conformity-baseline.json"))
+}
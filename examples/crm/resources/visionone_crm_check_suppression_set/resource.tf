@@ -0,0 +1,15 @@
+# Example: Accepting the risk of every check on sandbox resources
+resource "visionone_crm_check_suppression_set" "sandbox" {
+  account_ids       = ["1a2b3c4d-5e6f-7a8b-9c0d-1e2f3a4b5c6d"] # Vision One Cloud Risk Management account UUIDs
+  tags              = ["environment::sandbox"]
+  resource_id_regex = "^sandbox-"
+  note              = "Sandbox resources - accepted risk register entry AR-42"
+
+  # Optional: Suppress until a specific date/time (ISO 8601 format with UTC timezone)
+  suppressed_until_date_time = "2026-12-31T23:59:59Z"
+}
+
+output "sandbox_suppressed_checks" {
+  description = "Number of checks suppressed for sandbox resources"
+  value       = length(visionone_crm_check_suppression_set.sandbox.check_ids)
+}
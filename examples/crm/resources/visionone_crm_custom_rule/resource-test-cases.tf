@@ -0,0 +1,50 @@
+# Custom rule with test cases, checked on every plan
+resource "visionone_crm_custom_rule" "s3_versioning_tested" {
+  name           = "s3-bucket-versioning-tested"
+  description    = "Ensure S3 buckets have versioning enabled"
+  risk_level     = "HIGH"
+  cloud_provider = "aws"
+  service        = "S3"
+  resource_type  = "s3-bucket"
+  enabled        = true
+  categories     = ["reliability"]
+
+  attribute {
+    name     = "bucketVersioning"
+    path     = "data.BucketVersioning"
+    required = true
+  }
+
+  event_rule {
+    description = "Check if bucket versioning status is enabled"
+
+    conditions {
+      operator = "all"
+
+      condition {
+        operator = "equal"
+        fact     = "bucketVersioning"
+        path     = "$.Status"
+        value    = jsonencode("Enabled")
+      }
+    }
+  }
+
+  test_case {
+    name            = "versioned bucket"
+    resource_json   = jsonencode({ data = { BucketVersioning = { Status = "Enabled" } } })
+    expected_status = "SUCCESS"
+  }
+
+  test_case {
+    name            = "suspended versioning"
+    resource_json   = jsonencode({ data = { BucketVersioning = { Status = "Suspended" } } })
+    expected_status = "FAILURE"
+  }
+
+  test_case {
+    name            = "versioning never configured"
+    resource_json   = file("${path.module}/fixtures/bucket-without-versioning.json")
+    expected_status = "FAILURE"
+  }
+}
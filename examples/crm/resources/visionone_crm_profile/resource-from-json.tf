@@ -0,0 +1,6 @@
+# Migrate a profile exported from the Vision One console or from Cloud One Conformity
+resource "visionone_crm_profile" "from_json" {
+  name         = "crm-profile-from-json"
+  description  = "Profile migrated from Conformity"
+  profile_json = file("${path.module}/profiles/conformity-baseline.json")
+}
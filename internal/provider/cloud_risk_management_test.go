@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+)
+
+func testCRMProfileConfig(riskLevel string) map[string]any {
+	return map[string]any{
+		"name":        "acc-profile",
+		"description": "managed by the test",
+		"scan_rule": []map[string]any{
+			{"id": "S3-001", "provider": "aws", "enabled": true, "risk_level": riskLevel},
+		},
+	}
+}
+
+func TestCRMProfileResourceLifecycle(t *testing.T) {
+	p, mock := newTestProvider(t)
+	profile := p.resource("visionone_crm_profile")
+
+	profile.apply(testCRMProfileConfig("MEDIUM"))
+	id := profile.stringAttribute("id")
+	if _, ok := mock.Get(mockserver.CRMProfilesPath, id); !ok {
+		t.Fatalf("profile %s was not created", id)
+	}
+
+	profile.apply(testCRMProfileConfig("HIGH"))
+	obj, _ := mock.Get(mockserver.CRMProfilesPath, id)
+	rules, _ := obj["scanRules"].([]any)
+	if len(rules) != 1 || rules[0].(map[string]any)["riskLevel"] != "HIGH" {
+		t.Errorf("the profile rules are %v after the update", obj["scanRules"])
+	}
+
+	// The description is only tracked when it is set explicitly
+	profile.checkImported(profile.importState(id), "description")
+
+	profile.destroy()
+	if _, ok := mock.Get(mockserver.CRMProfilesPath, id); ok {
+		t.Errorf("profile %s still exists", id)
+	}
+}
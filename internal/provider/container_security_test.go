@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+)
+
+func testClusterConfig(description string) map[string]any {
+	return map[string]any{
+		"name":                     "acc-cluster",
+		"description":              description,
+		"group_id":                 "00000000-0000-0000-0000-000000000001",
+		"runtime_security_enabled": true,
+	}
+}
+
+func TestContainerClusterResourceLifecycle(t *testing.T) {
+	p, mock := newTestProvider(t)
+	cluster := p.resource("visionone_container_cluster")
+
+	cluster.apply(testClusterConfig("created by the test"))
+	id := cluster.stringAttribute("id")
+	if _, ok := mock.Get(mockserver.KubernetesClustersPath, id); !ok {
+		t.Fatalf("cluster %s was not created", id)
+	}
+	if cluster.stringAttribute("api_key") == "" {
+		t.Error("the cluster has no api_key")
+	}
+
+	cluster.apply(testClusterConfig("updated by the test"))
+	if got := cluster.stringAttribute("id"); got != id {
+		t.Errorf("updating the cluster changed its id to %s", got)
+	}
+	if obj, _ := mock.Get(mockserver.KubernetesClustersPath, id); obj["description"] != "updated by the test" {
+		t.Errorf("the cluster description is %v after the update", obj["description"])
+	}
+
+	// The enrollment key is only returned on creation, and the mock cluster has no agent running runtime security
+	cluster.checkImported(cluster.importState(id), "api_key", "endpoint", "helm_values_yaml", "platform", "deletion_protection", "runtime_security_enabled")
+
+	cluster.destroy()
+	if _, ok := mock.Get(mockserver.KubernetesClustersPath, id); ok {
+		t.Errorf("cluster %s still exists", id)
+	}
+}
+
+func TestContainerClusterImportReadsFeatures(t *testing.T) {
+	p, mock := newTestProvider(t)
+	mock.Seed(mockserver.KubernetesClustersPath, mockserver.Object{
+		"id":                       "imported-cluster",
+		"name":                     "imported",
+		"orchestrator":             "Kubernetes",
+		"runtimeSecurityEnabled":   true,
+		"vulnerabilityScanEnabled": true,
+		"malwareScanEnabled":       false,
+	})
+
+	cluster := p.resource("visionone_container_cluster").importState("imported-cluster")
+	want := map[string]bool{
+		"runtime_security_enabled":   true,
+		"vulnerability_scan_enabled": true,
+		"malware_scan_enabled":       false,
+		"secret_scan_enabled":        false,
+		"inventory_collection":       true,
+	}
+	for name, enabled := range want {
+		var got bool
+		if err := cluster.attribute(name).As(&got); err != nil || got != enabled {
+			t.Errorf("imported cluster has %s = %v, want %v", name, cluster.attribute(name), enabled)
+		}
+	}
+}
+
+func testPolicyConfig(description string) map[string]any {
+	return map[string]any{
+		"name":        "acc-policy",
+		"description": description,
+		"default": map[string]any{
+			"rules": []any{
+				map[string]any{
+					"action":     "log",
+					"mitigation": "log",
+					"type":       "podSecurityContext",
+					"enabled":    true,
+					"statement": map[string]any{
+						"properties": []any{
+							map[string]any{"key": "runAsNonRoot", "value": "false"},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestContainerPolicyResourceLifecycle(t *testing.T) {
+	p, mock := newTestProvider(t)
+	policy := p.resource("visionone_container_policy")
+
+	policy.apply(testPolicyConfig("created by the test"))
+	id := policy.stringAttribute("id")
+	if _, ok := mock.Get(mockserver.PoliciesPath, id); !ok {
+		t.Fatalf("policy %s was not created", id)
+	}
+
+	policy.apply(testPolicyConfig("updated by the test"))
+	if obj, _ := mock.Get(mockserver.PoliciesPath, id); obj["description"] != "updated by the test" {
+		t.Errorf("the policy description is %v after the update", obj["description"])
+	}
+
+	policy.checkImported(policy.importState(id))
+
+	policy.destroy()
+	if _, ok := mock.Get(mockserver.PoliciesPath, id); ok {
+		t.Errorf("policy %s still exists", id)
+	}
+}
+
+func TestContainerPolicyResourceNamespaced(t *testing.T) {
+	p, mock := newTestProvider(t)
+	policy := p.resource("visionone_container_policy")
+	config := testPolicyConfig("created by the test")
+	namespaced := func(namespaces ...any) []any {
+		return []any{map[string]any{
+			"name":       "platform",
+			"namespaces": namespaces,
+			"rules":      config["default"].(map[string]any)["rules"],
+		}}
+	}
+
+	// Creating a policy with malware and secret scan disabled follows up with an update, which must keep the definitions
+	config["namespaced"] = namespaced("kube-system")
+	policy.apply(config)
+	obj, _ := mock.Get(mockserver.PoliciesPath, policy.stringAttribute("id"))
+	if list, _ := obj["namespaced"].([]any); len(list) != 1 {
+		t.Fatalf("the namespaced definitions are %v after the creation", obj["namespaced"])
+	}
+
+	config["namespaced"] = namespaced("kube-system", "monitoring")
+	policy.apply(config)
+	obj, _ = mock.Get(mockserver.PoliciesPath, policy.stringAttribute("id"))
+	if list, _ := obj["namespaced"].([]any); len(list) != 1 || len(list[0].(map[string]any)["namespaces"].([]any)) != 2 {
+		t.Errorf("the namespaced definitions are %v after the update", obj["namespaced"])
+	}
+
+	policy.destroy()
+}
+
+func TestContainerPolicyImportKeepsForeignNamespaced(t *testing.T) {
+	p, mock := newTestProvider(t)
+	rule := map[string]any{
+		"type": "podSecurityContext", "enabled": true, "action": "log", "mitigation": "log",
+		"statement": map[string]any{"properties": []any{map[string]any{"key": "runAsNonRoot", "value": "false"}}},
+	}
+	mock.Seed(mockserver.PoliciesPath, mockserver.Object{
+		"id":      "imported-policy",
+		"name":    "acc-policy",
+		"default": map[string]any{"rules": []any{rule}},
+		"namespaced": []any{
+			map[string]any{"name": "platform", "namespaces": []any{"kube-system"}, "rules": []any{rule}},
+			map[string]any{"name": "team-a", "namespaces": []any{"team-a"}, "rules": []any{rule}},
+		},
+		"xdrEnabled":  true,
+		"malwareScan": map[string]any{"mitigation": "log", "schedule": map[string]any{"enabled": false}},
+		"secretScan":  map[string]any{"mitigation": "log", "schedule": map[string]any{"enabled": false, "skipIfRuleNotChanged": false}},
+	})
+
+	policy := p.resource("visionone_container_policy").importState("imported-policy")
+	if namespaced := policy.attribute("namespaced"); !namespaced.IsNull() {
+		t.Fatalf("the imported policy adopted namespaced definitions: %v", namespaced)
+	}
+
+	// team-a belongs to a visionone_container_policy_namespaced resource
+	config := testPolicyConfig("")
+	delete(config, "description")
+	config["namespaced"] = []any{
+		map[string]any{"name": "platform", "namespaces": []any{"kube-system", "monitoring"}, "rules": []any{rule}},
+	}
+	policy.apply(config)
+
+	obj, _ := mock.Get(mockserver.PoliciesPath, "imported-policy")
+	namespaced, _ := obj["namespaced"].([]any)
+	names := []string{}
+	for _, item := range namespaced {
+		names = append(names, item.(map[string]any)["name"].(string))
+	}
+	if len(names) != 2 || names[0] != "platform" || names[1] != "team-a" {
+		t.Errorf("the namespaced definitions are %v after the update", names)
+	}
+}
+
+func TestContainerRulesetCustomRuleDefaultMitigation(t *testing.T) {
+	p, mock := newTestProvider(t)
+	ruleset := p.resource("visionone_container_ruleset")
+
+	// apply checks the plan is empty once the API returns the default mitigation
+	ruleset.apply(map[string]any{
+		"name":   "acc-ruleset",
+		"labels": []any{map[string]any{"key": "app", "value": "web"}},
+		"rules":  []any{map[string]any{"id": "TM-00000001", "mitigation": "log"}},
+		"custom_rules": []any{
+			map[string]any{
+				"name":      "netcat",
+				"condition": "spawned_process and container and proc.name = nc",
+				"output":    "netcat started in %container.id",
+				"priority":  "warning",
+			},
+		},
+	})
+	obj, _ := mock.Get(mockserver.RulesetsPath, ruleset.stringAttribute("id"))
+	rules, _ := obj["customRules"].([]any)
+	if len(rules) != 1 || rules[0].(map[string]any)["mitigation"] != "log" {
+		t.Errorf("the custom rules are %v", obj["customRules"])
+	}
+}
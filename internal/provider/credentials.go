@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// resolvedCredentials is the outcome of looking up the API key: either a static key or a source
+// that the client queries (and refreshes) on its own. RegFQDN is set when a shared profile carries one.
+type resolvedCredentials struct {
+	APIKey  string
+	Source  trendmicro.CredentialSource
+	RegFQDN string
+}
+
+// resolveCredentials picks the API key from, in order: api_key, api_key_file, api_key_command,
+// profile, the VISIONONE_API_KEY and VISIONONE_API_KEY_FILE environment variables, and finally the
+// profile named by VISIONONE_PROFILE (or "default") when the shared credentials file exists.
+func resolveCredentials(ctx context.Context, data *TrendMicroProviderModel) (*resolvedCredentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	switch {
+	case !data.ApiKey.IsNull():
+		return &resolvedCredentials{APIKey: data.ApiKey.ValueString()}, diags
+	case !data.ApiKeyFile.IsNull():
+		return sourceCredentials(trendmicro.NewFileCredentialSource(data.ApiKeyFile.ValueString()), path.Root(TF_KEY_API_KEY_FILE))
+	case !data.ApiKeyCommand.IsNull():
+		var command []string
+		diags.Append(data.ApiKeyCommand.ElementsAs(ctx, &command, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		return sourceCredentials(trendmicro.NewCommandCredentialSource(command), path.Root(TF_KEY_API_KEY_COMMAND))
+	case !data.Profile.IsNull():
+		return profileCredentials(data.Profile.ValueString(), true)
+	}
+
+	if apiKey := os.Getenv(ENV_VAR_NAME_API_KEY); apiKey != "" {
+		return &resolvedCredentials{APIKey: apiKey}, diags
+	}
+	if keyFile := os.Getenv(ENV_VAR_NAME_API_KEY_FILE); keyFile != "" {
+		return sourceCredentials(trendmicro.NewFileCredentialSource(keyFile), path.Root(TF_KEY_API_KEY_FILE))
+	}
+	if profile := os.Getenv(ENV_VAR_NAME_PROFILE); profile != "" {
+		return profileCredentials(profile, true)
+	}
+	return profileCredentials(trendmicro.DefaultCredentialsProfile, false)
+}
+
+func sourceCredentials(src trendmicro.CredentialSource, attr path.Path) (*resolvedCredentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	apiKey, err := src.Token()
+	if err != nil {
+		diags.AddAttributeError(attr, "Unable to Resolve Vision One API Key", err.Error())
+		return nil, diags
+	}
+
+	return &resolvedCredentials{APIKey: apiKey, Source: src}, diags
+}
+
+// profileCredentials loads a profile from the shared credentials file. When the profile was not
+// asked for explicitly a missing file or profile is not an error, there are simply no credentials.
+func profileCredentials(profile string, explicit bool) (*resolvedCredentials, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	file := os.Getenv(ENV_VAR_NAME_SHARED_CREDENTIALS_FILE)
+	if file == "" {
+		var err error
+		if file, err = trendmicro.DefaultSharedCredentialsFile(); err != nil {
+			if explicit {
+				diags.AddAttributeError(path.Root(TF_KEY_PROFILE), "Unable to Locate Shared Credentials File", err.Error())
+			}
+			return &resolvedCredentials{}, diags
+		}
+	}
+
+	if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) && !explicit {
+		return &resolvedCredentials{}, diags
+	}
+
+	p, err := trendmicro.LoadSharedProfile(file, profile)
+	if errors.Is(err, trendmicro.ErrProfileNotFound) && !explicit {
+		return &resolvedCredentials{}, diags
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root(TF_KEY_PROFILE), "Unable to Load Vision One Profile", err.Error())
+		return nil, diags
+	}
+
+	resolved, sourceDiags := sourceCredentials(trendmicro.NewProfileCredentialSource(file, profile), path.Root(TF_KEY_PROFILE))
+	diags.Append(sourceDiags...)
+	if diags.HasError() {
+		return nil, diags
+	}
+	resolved.RegFQDN = p.RegionalFQDN
+
+	return resolved, diags
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+)
+
+func TestProfileCredentialsWithoutDefaultProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(file, []byte("[eu-tenant]\napi_key = eu-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(ENV_VAR_NAME_SHARED_CREDENTIALS_FILE, file)
+
+	// Without a profile named the next credential source is used
+	creds, diags := profileCredentials(trendmicro.DefaultCredentialsProfile, false)
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if creds.APIKey != "" || creds.Source != nil {
+		t.Errorf("expected no credentials, got %+v", creds)
+	}
+
+	if _, diags := profileCredentials(trendmicro.DefaultCredentialsProfile, true); !diags.HasError() {
+		t.Error("expected an error for an explicit profile missing from the file")
+	}
+}
@@ -2,7 +2,10 @@ package provider
 
 import (
 	"context"
-	"os"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	gcpavtddatasources "terraform-provider-vision-one/internal/trendmicro/avtd/gcp/data-sources"
@@ -15,31 +18,66 @@ import (
 	gcpresources "terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/resources"
 	azureclmresources "terraform-provider-vision-one/internal/trendmicro/cloud_log_monitoring/azure/resources"
 	crmdatasources "terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/data-sources"
+	crmfunctions "terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/functions"
 	crmresources "terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/resources"
+	csdatasources "terraform-provider-vision-one/internal/trendmicro/container_security/data-sources"
 	"terraform-provider-vision-one/internal/trendmicro/container_security/resources"
 	gcpdspmdatasources "terraform-provider-vision-one/internal/trendmicro/data_security_posture_management/gcp/data-sources"
 	gcpdspmresources "terraform-provider-vision-one/internal/trendmicro/data_security_posture_management/gcp/resources"
+	"terraform-provider-vision-one/pkg/dto"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 const (
-	TF_KEY_API_KEY        = "api_key"
-	TF_KEY_REG_FQDN       = "regional_fqdn"
-	ENV_VAR_NAME_API_KEY  = "VISIONONE_API_KEY"
-	ENV_VAR_NAME_REG_FQDN = "VISIONONE_REGIONAL_FQDN"
+	TF_KEY_API_KEY         = "api_key"
+	TF_KEY_API_KEY_FILE    = "api_key_file"
+	TF_KEY_API_KEY_COMMAND = "api_key_command"
+	TF_KEY_PROFILE         = "profile"
+	TF_KEY_REG_FQDN        = "regional_fqdn"
+	TF_KEY_REGION          = "region"
+	TF_KEY_PROBE_REGIONS   = "probe_regions"
+	TF_KEY_MAX_RETRIES     = "max_retries"
+	TF_KEY_RETRY_MIN_WAIT  = "retry_min_backoff"
+	TF_KEY_RETRY_MAX_WAIT  = "retry_max_backoff"
+
+	TF_KEY_HTTP_PROXY           = "http_proxy"
+	TF_KEY_CA_CERT_FILE         = "ca_cert_file"
+	TF_KEY_CA_CERT_PEM          = "ca_cert_pem"
+	TF_KEY_CLIENT_CERT          = "client_cert"
+	TF_KEY_CLIENT_KEY           = "client_key"
+	TF_KEY_INSECURE_SKIP_VERIFY = "insecure_skip_verify"
+	TF_KEY_REQUEST_TIMEOUTS     = "request_timeouts"
+	TF_KEY_HTTP_LOG_FILE        = "http_log_file"
+	TF_KEY_REQUESTS_PER_SECOND  = "requests_per_second"
+	TF_KEY_BURST                = "burst"
+	TF_KEY_SERVICE_RATE_LIMITS  = "service_rate_limits"
+
+	ENV_VAR_NAME_API_KEY                 = "VISIONONE_API_KEY"
+	ENV_VAR_NAME_API_KEY_FILE            = "VISIONONE_API_KEY_FILE"
+	ENV_VAR_NAME_PROFILE                 = "VISIONONE_PROFILE"
+	ENV_VAR_NAME_SHARED_CREDENTIALS_FILE = "VISIONONE_SHARED_CREDENTIALS_FILE"
+	ENV_VAR_NAME_REG_FQDN                = "VISIONONE_REGIONAL_FQDN"
+	ENV_VAR_NAME_REGION                  = "VISIONONE_REGION"
+	ENV_VAR_NAME_HTTP_LOG                = "VISIONONE_HTTP_LOG"
 )
 
 const (
 	UnkonwnAPIKeyErrDetail  = "The provider cannot create the Trend Vision One API client as there is an unknown configuration value for the Vision One API Key. You could obtain a valid key from Vision One Console or API. Either target apply the source of the value first, set the value statically in the configuration, or use the " + ENV_VAR_NAME_API_KEY + " environment variable."
-	UnkonwnRegFQDNErrDetail = "The provider cannot create the Trend Vision One API client as there is an unknown configuration value for the Vision One Regional FQDN. Either target apply the source of the value first, set the value statically in the configuration, or use the " + ENV_VAR_NAME_REG_FQDN + " environment variable."
+	MissingAPIKeyErrDetail  = "The provider cannot create the Trend Vision One API client as no Vision One API Key was found. Set one of " + TF_KEY_API_KEY + ", " + TF_KEY_API_KEY_FILE + ", " + TF_KEY_API_KEY_COMMAND + " or " + TF_KEY_PROFILE + ", use the " + ENV_VAR_NAME_API_KEY + " or " + ENV_VAR_NAME_API_KEY_FILE + " environment variable, or add a default profile to ~/.visionone/credentials."
+	UnkonwnRegFQDNErrDetail = "The provider cannot create the Trend Vision One API client as there is an unknown configuration value for the Vision One Regional FQDN. Either target apply the source of the value first, set the value statically in the configuration, set " + TF_KEY_REGION + " to one of the supported regions, or use the " + ENV_VAR_NAME_REG_FQDN + " or " + ENV_VAR_NAME_REGION + " environment variable."
 )
 
 // Ensure TrendMicroProvider satisfies various provider interfaces.
@@ -58,8 +96,28 @@ type TrendMicroProvider struct {
 
 // TrendMicroProviderModel describes the provider data model.
 type TrendMicroProviderModel struct {
-	ApiKey  types.String `tfsdk:"api_key"`
-	RegFQDN types.String `tfsdk:"regional_fqdn"`
+	ApiKey          types.String `tfsdk:"api_key"`
+	ApiKeyFile      types.String `tfsdk:"api_key_file"`
+	ApiKeyCommand   types.List   `tfsdk:"api_key_command"`
+	Profile         types.String `tfsdk:"profile"`
+	RegFQDN         types.String `tfsdk:"regional_fqdn"`
+	Region          types.String `tfsdk:"region"`
+	ProbeRegions    types.Bool   `tfsdk:"probe_regions"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMinBackoff types.String `tfsdk:"retry_min_backoff"`
+	RetryMaxBackoff types.String `tfsdk:"retry_max_backoff"`
+
+	HTTPProxy          types.String            `tfsdk:"http_proxy"`
+	CACertFile         types.String            `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String            `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String            `tfsdk:"client_cert"`
+	ClientKey          types.String            `tfsdk:"client_key"`
+	InsecureSkipVerify types.Bool              `tfsdk:"insecure_skip_verify"`
+	RequestTimeouts    *requestTimeoutsModel   `tfsdk:"request_timeouts"`
+	HTTPLogFile        types.String            `tfsdk:"http_log_file"`
+	RequestsPerSecond  types.Float64           `tfsdk:"requests_per_second"`
+	Burst              types.Int64             `tfsdk:"burst"`
+	ServiceRateLimits  *serviceRateLimitsModel `tfsdk:"service_rate_limits"`
 }
 
 func (p *TrendMicroProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -71,13 +129,166 @@ func (p *TrendMicroProvider) Schema(ctx context.Context, req provider.SchemaRequ
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			TF_KEY_REG_FQDN: schema.StringAttribute{
-				MarkdownDescription: "Trend Vision One provides a server in each region where the service endpoint is hosted. You must specify the correct domain name for your region. Reference: https://automation.trendmicro.com/xdr/Guides/Regional-Domains",
+				MarkdownDescription: "Trend Vision One provides a server in each region where the service endpoint is hosted. You must specify the correct domain name for your region, as scheme and host only (for example `https://api.xdr.trendmicro.com`). Reference: https://automation.trendmicro.com/xdr/Guides/Regional-Domains",
+				Optional:            true,
+				Validators: []validator.String{
+					regionalFQDNValidator{},
+					stringvalidator.ConflictsWith(path.MatchRoot(TF_KEY_REGION)),
+				},
+			},
+			TF_KEY_REGION: schema.StringAttribute{
+				MarkdownDescription: "Shorthand for the region of your Vision One tenant, mapped to the documented regional domain. One of `" + strings.Join(trendmicro.Regions(), "`, `") + "`. Can also be set with the `" + ENV_VAR_NAME_REGION + "` environment variable. Conflicts with `" + TF_KEY_REG_FQDN + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(trendmicro.Regions()...),
+				},
+			},
+			TF_KEY_PROBE_REGIONS: schema.BoolAttribute{
+				MarkdownDescription: "When Vision One rejects the API key, send it to the healthcheck of every other region and report the region that accepts it. This discloses the key to all regional domains, so only enable it while looking for the region of a key. Defaults to `false`.",
 				Optional:            true,
 			},
 			TF_KEY_API_KEY: schema.StringAttribute{
 				MarkdownDescription: "API Key from Vision One Console",
 				Optional:            true,
 				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot(TF_KEY_API_KEY_FILE),
+						path.MatchRoot(TF_KEY_API_KEY_COMMAND),
+						path.MatchRoot(TF_KEY_PROFILE),
+					),
+				},
+			},
+			TF_KEY_API_KEY_FILE: schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the API key. The file is read again when Vision One rejects the key, so it can be rotated in place. Can also be set with the `" + ENV_VAR_NAME_API_KEY_FILE + "` environment variable.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot(TF_KEY_API_KEY_COMMAND),
+						path.MatchRoot(TF_KEY_PROFILE),
+					),
+				},
+			},
+			TF_KEY_API_KEY_COMMAND: schema.ListAttribute{
+				MarkdownDescription: "Credential helper command and its arguments, run without a shell. The command must print the API key to stdout, either as is or as a JSON object `{\"api_key\": \"...\", \"expires_at\": \"<RFC3339>\"}`. It is run again when the key expires or is rejected by Vision One.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ConflictsWith(path.MatchRoot(TF_KEY_PROFILE)),
+				},
+			},
+			TF_KEY_PROFILE: schema.StringAttribute{
+				MarkdownDescription: "Name of a profile in the shared credentials file (`~/.visionone/credentials`, or the path in `" + ENV_VAR_NAME_SHARED_CREDENTIALS_FILE + "`). A profile holds `api_key` and optionally `regional_fqdn`. Can also be set with the `" + ENV_VAR_NAME_PROFILE + "` environment variable; the `default` profile is used when no other credentials are given.",
+				Optional:            true,
+			},
+			TF_KEY_MAX_RETRIES: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Maximum number of retries when Vision One responds with `429 Too Many Requests` or a `5xx` error. Only idempotent requests (`GET`, `PUT`, `DELETE`) are retried. Set to `0` to disable retries. Defaults to `%d`.", trendmicro.DefaultMaxRetries),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			TF_KEY_RETRY_MIN_WAIT: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Delay before the first retry as a Go duration string (for example `500ms` or `2s`). The delay doubles on every attempt and is randomized to spread out concurrent requests. Defaults to `%s`.", trendmicro.DefaultRetryMinBackoff),
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			TF_KEY_RETRY_MAX_WAIT: schema.StringAttribute{
+				MarkdownDescription: fmt.Sprintf("Upper bound for the delay between retries as a Go duration string. A `Retry-After` header returned by Vision One is followed up to this bound. Defaults to `%s`.", trendmicro.DefaultRetryMaxBackoff),
+				Optional:            true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			TF_KEY_HTTP_PROXY: schema.StringAttribute{
+				MarkdownDescription: "URL of the proxy for all requests to Vision One, for example `http://proxy.example.com:3128`. When not set, the `HTTPS_PROXY`, `HTTP_PROXY` and `NO_PROXY` environment variables apply.",
+				Optional:            true,
+			},
+			TF_KEY_CA_CERT_FILE: schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded bundle of certificate authorities to trust in addition to the system ones, such as the CA of an inspecting proxy. Conflicts with `" + TF_KEY_CA_CERT_PEM + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot(TF_KEY_CA_CERT_PEM)),
+				},
+			},
+			TF_KEY_CA_CERT_PEM: schema.StringAttribute{
+				MarkdownDescription: "PEM encoded bundle of certificate authorities to trust in addition to the system ones.",
+				Optional:            true,
+			},
+			TF_KEY_CLIENT_CERT: schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate, or the path of a file containing it, presented when the proxy or endpoint requires mutual TLS. Requires `" + TF_KEY_CLIENT_KEY + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot(TF_KEY_CLIENT_KEY)),
+				},
+			},
+			TF_KEY_CLIENT_KEY: schema.StringAttribute{
+				MarkdownDescription: "PEM encoded private key of `" + TF_KEY_CLIENT_CERT + "`, or the path of a file containing it.",
+				Optional:            true,
+				Sensitive:           true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot(TF_KEY_CLIENT_CERT)),
+				},
+			},
+			TF_KEY_INSECURE_SKIP_VERIFY: schema.BoolAttribute{
+				MarkdownDescription: "Skip the verification of the server certificate. Only meant for troubleshooting; the provider warns when it is enabled. Defaults to `false`.",
+				Optional:            true,
+			},
+			TF_KEY_HTTP_LOG_FILE: schema.StringAttribute{
+				MarkdownDescription: "Path of a file to which every HTTP request to Vision One and its response are appended as JSON lines, with method, path, status, latency, trace ID and bodies. The API key is never written and secret fields such as cluster API keys, service account keys and the tokens of communication channels are masked. Meant for troubleshooting with Trend Micro support. Can also be set with the `" + ENV_VAR_NAME_HTTP_LOG + "` environment variable.",
+				Optional:            true,
+			},
+			TF_KEY_REQUESTS_PER_SECOND: schema.Float64Attribute{
+				MarkdownDescription: fmt.Sprintf("Sustained number of requests per second the provider sends to Vision One, shared by all resources and data sources of the provider. Requests above the limit wait their turn instead of being throttled by Vision One. Services listed in `%s` have their own budget. Set to `0` to disable the limit. Defaults to `%d`.", TF_KEY_SERVICE_RATE_LIMITS, trendmicro.DefaultRequestsPerSecond),
+				Optional:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			TF_KEY_BURST: schema.Int64Attribute{
+				MarkdownDescription: fmt.Sprintf("Number of requests that may be sent at once before `%s` applies. Defaults to `%d`.", TF_KEY_REQUESTS_PER_SECOND, trendmicro.DefaultBurst),
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			TF_KEY_SERVICE_RATE_LIMITS: schema.SingleNestedAttribute{
+				MarkdownDescription: fmt.Sprintf("Rate limits of single API families, each with its own budget next to the provider-wide one. Cloud Account Management defaults to `%d` requests per second with a burst of `%d`; the other services share the provider-wide limit.", trendmicro.DefaultCloudAccountManagementRequestsPerSecond, trendmicro.DefaultCloudAccountManagementBurst),
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"container_security":       rateLimitSchema("Container Security"),
+					"cloud_risk_management":    rateLimitSchema("Cloud Risk Management"),
+					"cloud_account_management": rateLimitSchema("Cloud Account Management"),
+				},
+			},
+			TF_KEY_REQUEST_TIMEOUTS: schema.SingleNestedAttribute{
+				MarkdownDescription: "Timeouts of a single HTTP request to Vision One, as Go duration strings. A timed out `GET`, `PUT` or `DELETE` request is retried like a `5xx` error.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"default": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout of requests not covered by a service below. `0s` disables the timeout. Defaults to `%s`.", trendmicro.DefaultRequestTimeout),
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"container_security": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout of Container Security requests. Defaults to `%s`.", trendmicro.DefaultContainerSecurityTimeout),
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"cloud_risk_management": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout of Cloud Risk Management requests. Defaults to `%s`.", trendmicro.DefaultCloudRiskManagementTimeout),
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+					"cloud_account_management": schema.StringAttribute{
+						MarkdownDescription: fmt.Sprintf("Timeout of Cloud Account Management requests, which can be slow while a cloud account is being connected. Defaults to `%s`.", trendmicro.DefaultCloudAccountManagementTimeout),
+						Optional:            true,
+						Validators:          []validator.String{durationValidator{}},
+					},
+				},
 			},
 		},
 	}
@@ -100,7 +311,14 @@ func (p *TrendMicroProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
-	if data.RegFQDN.IsUnknown() {
+	if data.ApiKeyFile.IsUnknown() || data.ApiKeyCommand.IsUnknown() || data.Profile.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown VisionOne Credentials",
+			UnkonwnAPIKeyErrDetail,
+		)
+	}
+
+	if data.RegFQDN.IsUnknown() || data.Region.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root(TF_KEY_REG_FQDN),
 			"Unknown VisionOne Regional FQDN",
@@ -108,30 +326,42 @@ func (p *TrendMicroProvider) Configure(ctx context.Context, req provider.Configu
 		)
 	}
 
+	if data.HTTPProxy.IsUnknown() || data.CACertFile.IsUnknown() || data.CACertPEM.IsUnknown() ||
+		data.ClientCert.IsUnknown() || data.ClientKey.IsUnknown() || data.InsecureSkipVerify.IsUnknown() ||
+		data.HTTPLogFile.IsUnknown() || data.RequestsPerSecond.IsUnknown() || data.Burst.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown HTTP Client Configuration",
+			"The provider cannot create the Trend Vision One API client as the proxy, TLS, logging or rate limit settings are not known yet. "+
+				"Either target apply the source of the values first or set them statically in the configuration.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	var apiKey string
-	var host string
-
-	if !data.ApiKey.IsNull() {
-		apiKey = data.ApiKey.ValueString()
-	} else {
-		apiKey = os.Getenv(ENV_VAR_NAME_API_KEY)
+	creds, diags := resolveCredentials(ctx, &data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	if !data.RegFQDN.IsNull() {
-		host = data.RegFQDN.ValueString()
-	} else {
-		host = os.Getenv(ENV_VAR_NAME_REG_FQDN)
+	apiKey := creds.APIKey
+
+	host, hostAttr := resolveHost(&data, creds)
+	if host != "" {
+		normalized, err := trendmicro.NormalizeRegionalFQDN(host)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(hostAttr, "Invalid Vision One Regional FQDN", err.Error())
+			return
+		}
+		host = normalized
 	}
 
 	if apiKey == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root(TF_KEY_API_KEY),
 			"Missing Vision One API Key",
-			UnkonwnAPIKeyErrDetail,
+			MissingAPIKeyErrDetail,
 		)
 	}
 
@@ -155,7 +385,64 @@ func (p *TrendMicroProvider) Configure(ctx context.Context, req provider.Configu
 
 	tflog.Debug(ctx, "Creating Trend Vision One API client")
 
-	client, err := trendmicro.NewClient(&host, &apiKey, p.version)
+	retry := trendmicro.DefaultRetryConfig()
+	if !data.MaxRetries.IsNull() && !data.MaxRetries.IsUnknown() {
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	if !data.RetryMinBackoff.IsNull() && !data.RetryMinBackoff.IsUnknown() {
+		retry.MinBackoff, _ = time.ParseDuration(data.RetryMinBackoff.ValueString())
+	}
+	if !data.RetryMaxBackoff.IsNull() && !data.RetryMaxBackoff.IsUnknown() {
+		retry.MaxBackoff, _ = time.ParseDuration(data.RetryMaxBackoff.ValueString())
+	}
+	if retry.MaxBackoff < retry.MinBackoff {
+		resp.Diagnostics.AddAttributeError(
+			path.Root(TF_KEY_RETRY_MAX_WAIT),
+			"Invalid Retry Backoff",
+			fmt.Sprintf("%s (%s) must not be lower than %s (%s).", TF_KEY_RETRY_MAX_WAIT, retry.MaxBackoff, TF_KEY_RETRY_MIN_WAIT, retry.MinBackoff),
+		)
+		return
+	}
+
+	transport, diags := resolveTransport(&data)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	opts := []trendmicro.ClientOption{
+		trendmicro.WithRetry(retry),
+		trendmicro.WithTransport(transport),
+		trendmicro.WithTimeouts(resolveTimeouts(data.RequestTimeouts)),
+		trendmicro.WithRateLimits(resolveRateLimits(&data)),
+	}
+	if logFile := resolveHTTPLogFile(&data); logFile != "" {
+		auditLog, err := trendmicro.OpenAuditLog(logFile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(TF_KEY_HTTP_LOG_FILE), "Unable to Open HTTP Log", err.Error())
+			return
+		}
+		tflog.Info(ctx, "Logging Vision One HTTP requests", map[string]any{TF_KEY_HTTP_LOG_FILE: logFile})
+		opts = append(opts, trendmicro.WithAuditLog(auditLog))
+	}
+	if creds.Source != nil {
+		opts = append(opts, trendmicro.WithCredentials(creds.Source))
+	}
+
+	client, err := trendmicro.NewClient(&host, &apiKey, p.version, opts...)
+	if err != nil && errors.Is(err, dto.Unauthorized) && data.ProbeRegions.ValueBool() {
+		tflog.Debug(ctx, err.Error())
+		if region := trendmicro.ProbeRegion(apiKey, p.version, host, transport); region != "" {
+			resp.Diagnostics.AddAttributeError(
+				hostAttr,
+				"Vision One API Key Belongs to Another Region",
+				fmt.Sprintf("The API key was rejected by %s but accepted by the %q region (%s). "+
+					"Set %s = %q or point %s at that region's domain.",
+					host, region, trendmicro.RegionalFQDNs[region], TF_KEY_REGION, region, TF_KEY_REG_FQDN),
+			)
+			return
+		}
+	}
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -178,6 +465,11 @@ func (p *TrendMicroProvider) Resources(ctx context.Context) []func() resource.Re
 		resources.NewClusterResource,
 		resources.NewRulesetResource,
 		resources.NewPolicyResource,
+		resources.NewNamespacedPolicyResource,
+		resources.NewClusterAPIKeyRotationResource,
+		resources.NewClusterGroupResource,
+		resources.NewCustomizableTagResource,
+		resources.NewEcsClusterResource,
 		azureresources.NewAppRegistration,
 		azureresources.NewServicePrincipal,
 		azureresources.NewFederatedIdentity,
@@ -191,6 +483,7 @@ func (p *TrendMicroProvider) Resources(ctx context.Context) []func() resource.Re
 		crmresources.NewProfileResource,
 		crmresources.NewGroupResource,
 		crmresources.NewCheckSuppressionResource,
+		crmresources.NewCheckSuppressionSetResource,
 		crmresources.NewCustomRuleResource,
 		crmresources.NewCommunicationConfigurationResource,
 		crmresources.NewAccountScanSettingResource,
@@ -223,12 +516,23 @@ func (p *TrendMicroProvider) DataSources(ctx context.Context) []func() datasourc
 		gcpavtddatasources.NewLegacyStateRegionsDataSource,
 		crmdatasources.NewCRMAccountDataSource,
 		crmdatasources.NewApplyProfileDataSource,
+		crmdatasources.NewChecksDataSource,
+		crmdatasources.NewRulesDataSource,
+		crmdatasources.NewComplianceStandardsDataSource,
+		csdatasources.NewClustersDataSource,
+		csdatasources.NewPoliciesDataSource,
+		csdatasources.NewRulesetsDataSource,
+		csdatasources.NewManagedRulesDataSource,
+		csdatasources.NewClusterGroupDataSource,
+		csdatasources.NewCustomizableTagDataSource,
+		csdatasources.NewEcsClusterDataSource,
 	}
 }
 
 func (p *TrendMicroProvider) Functions(ctx context.Context) []func() function.Function {
 	return []func() function.Function{
-		// NewExampleFunction,
+		crmfunctions.NewCustomRuleEvaluateFunction,
+		crmfunctions.NewProfileExportFunction,
 	}
 }
 
@@ -0,0 +1,398 @@
+package provider
+
+import (
+	"context"
+	"maps"
+	"math/big"
+	"slices"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testAccProtoV6ProviderFactories serves the provider in-process, in the form resource.Test expects.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"visionone": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testProvider drives the provider through the protocol Terraform uses, against the fake Vision One
+// backend. It covers Configure, planning, apply, refresh and import end to end without a Terraform CLI.
+type testProvider struct {
+	t       *testing.T
+	server  tfprotov6.ProviderServer
+	schemas *tfprotov6.GetProviderSchemaResponse
+}
+
+// newTestProvider starts the fake backend, points the provider at it through the environment and
+// configures the provider.
+func newTestProvider(t *testing.T) (*testProvider, *mockserver.Server) {
+	t.Helper()
+	p, mock := newUnconfiguredTestProvider(t)
+	checkDiagnostics(t, "configure the provider", p.configure(nil))
+	return p, mock
+}
+
+// newUnconfiguredTestProvider is newTestProvider for tests that configure the provider themselves.
+func newUnconfiguredTestProvider(t *testing.T) (*testProvider, *mockserver.Server) {
+	t.Helper()
+	mock := mockserver.NewTestServer(t)
+	mock.SetProviderEnv(t)
+
+	server, err := testAccProtoV6ProviderFactories["visionone"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemas, err := server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDiagnostics(t, "get the schema", schemas.Diagnostics)
+
+	return &testProvider{t: t, server: server, schemas: schemas}, mock
+}
+
+// configure configures the provider and returns its diagnostics.
+func (p *testProvider) configure(config map[string]any) []*tfprotov6.Diagnostic {
+	p.t.Helper()
+	resp, err := p.server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.9.0",
+		Config:           p.dynamicValue(p.schemas.Provider.ValueType(), testObjectValue(p.schemas.Provider.Block, config)),
+	})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return resp.Diagnostics
+}
+
+// testResource is one resource managed through a testProvider, with its current state.
+type testResource struct {
+	p        *testProvider
+	typeName string
+	schema   *tfprotov6.Schema
+	state    tftypes.Value
+	private  []byte
+}
+
+func (p *testProvider) resource(typeName string) *testResource {
+	schema, ok := p.schemas.ResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("the provider has no %s resource", typeName)
+	}
+	return &testResource{p: p, typeName: typeName, schema: schema, state: tftypes.NewValue(schema.ValueType(), nil)}
+}
+
+// apply creates or updates the resource in place with config, like terraform apply. It then refreshes the
+// resource and checks the next plan is empty.
+func (r *testResource) apply(config map[string]any) {
+	r.p.t.Helper()
+	configValue := testObjectValue(r.schema.Block, config)
+	validate, err := r.p.server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{
+		TypeName: r.typeName,
+		Config:   r.p.dynamicValue(r.schema.ValueType(), configValue),
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "validate "+r.typeName, validate.Diagnostics)
+
+	plan := r.plan(configValue)
+	if !r.state.IsNull() && len(plan.RequiresReplace) > 0 {
+		r.p.t.Fatalf("updating %s requires replacing it: %v", r.typeName, plan.RequiresReplace)
+	}
+	resp, err := r.p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.p.dynamicValue(r.schema.ValueType(), r.state),
+		PlannedState:   plan.PlannedState,
+		Config:         r.p.dynamicValue(r.schema.ValueType(), configValue),
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "apply "+r.typeName, resp.Diagnostics)
+	r.state = r.p.unmarshal(r.schema.ValueType(), resp.NewState)
+	r.private = resp.Private
+
+	r.refresh()
+	if plan := r.plan(configValue); !r.p.unmarshal(r.schema.ValueType(), plan.PlannedState).Equal(r.state) {
+		diffs, _ := r.state.Diff(r.p.unmarshal(r.schema.ValueType(), plan.PlannedState))
+		r.p.t.Fatalf("the plan after applying %s is not empty: %v", r.typeName, diffs)
+	}
+}
+
+// destroy deletes the resource, like terraform destroy.
+func (r *testResource) destroy() {
+	r.p.t.Helper()
+	null := tftypes.NewValue(r.schema.ValueType(), nil)
+	plan := r.plan(null)
+	resp, err := r.p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.p.dynamicValue(r.schema.ValueType(), r.state),
+		PlannedState:   plan.PlannedState,
+		Config:         r.p.dynamicValue(r.schema.ValueType(), null),
+		PlannedPrivate: plan.PlannedPrivate,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "destroy "+r.typeName, resp.Diagnostics)
+	r.state = null
+}
+
+// importState imports the resource by ID into a new testResource and refreshes it, like terraform import.
+func (r *testResource) importState(id string) *testResource {
+	r.p.t.Helper()
+	resp, err := r.p.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{
+		TypeName: r.typeName,
+		ID:       id,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "import "+r.typeName, resp.Diagnostics)
+	if len(resp.ImportedResources) != 1 {
+		r.p.t.Fatalf("importing %s returned %d resources", r.typeName, len(resp.ImportedResources))
+	}
+	imported := r.p.resource(r.typeName)
+	imported.state = r.p.unmarshal(r.schema.ValueType(), resp.ImportedResources[0].State)
+	imported.private = resp.ImportedResources[0].Private
+	imported.refresh()
+	return imported
+}
+
+// checkImported checks the imported resource has the attributes of r, except for ignore.
+func (r *testResource) checkImported(imported *testResource, ignore ...string) {
+	r.p.t.Helper()
+	var want, got map[string]tftypes.Value
+	if err := r.state.As(&want); err != nil {
+		r.p.t.Fatal(err)
+	}
+	if err := imported.state.As(&got); err != nil {
+		r.p.t.Fatal(err)
+	}
+	for name, value := range want {
+		if !slices.Contains(ignore, name) && !value.Equal(got[name]) {
+			r.p.t.Errorf("imported %s has %s = %v, want %v", r.typeName, name, got[name], value)
+		}
+	}
+}
+
+// attribute returns a top-level attribute of the state.
+func (r *testResource) attribute(name string) tftypes.Value {
+	var attributes map[string]tftypes.Value
+	if err := r.state.As(&attributes); err != nil {
+		r.p.t.Fatal(err)
+	}
+	return attributes[name]
+}
+
+// stringAttribute returns a top-level string attribute of the state.
+func (r *testResource) stringAttribute(name string) string {
+	var s string
+	if err := r.attribute(name).As(&s); err != nil {
+		r.p.t.Fatal(err)
+	}
+	return s
+}
+
+func (r *testResource) refresh() {
+	r.p.t.Helper()
+	resp, err := r.p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.p.dynamicValue(r.schema.ValueType(), r.state),
+		Private:      r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "read "+r.typeName, resp.Diagnostics)
+	r.state = r.p.unmarshal(r.schema.ValueType(), resp.NewState)
+	r.private = resp.Private
+}
+
+func (r *testResource) plan(config tftypes.Value) *tfprotov6.PlanResourceChangeResponse {
+	r.p.t.Helper()
+	resp, err := r.p.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.p.dynamicValue(r.schema.ValueType(), r.state),
+		ProposedNewState: r.p.dynamicValue(r.schema.ValueType(), proposedNewBlock(r.schema.Block, r.state, config)),
+		Config:           r.p.dynamicValue(r.schema.ValueType(), config),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	checkDiagnostics(r.p.t, "plan "+r.typeName, resp.Diagnostics)
+	return resp
+}
+
+func (p *testProvider) dynamicValue(typ tftypes.Type, value tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	dv, err := tfprotov6.NewDynamicValue(typ, value)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return &dv
+}
+
+func (p *testProvider) unmarshal(typ tftypes.Type, dv *tfprotov6.DynamicValue) tftypes.Value {
+	p.t.Helper()
+	value, err := dv.Unmarshal(typ)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return value
+}
+
+func checkDiagnostics(t *testing.T, action string, diags []*tfprotov6.Diagnostic) {
+	t.Helper()
+	for _, diag := range diags {
+		if diag.Severity == tfprotov6.DiagnosticSeverityError {
+			t.Fatalf("could not %s: %s: %s", action, diag.Summary, diag.Detail)
+		}
+	}
+}
+
+// testObjectValue converts a configuration written as nested maps and slices to a value of the block.
+// Blocks that are not configured are empty, as in Terraform.
+func testObjectValue(block *tfprotov6.SchemaBlock, config map[string]any) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for _, attribute := range block.Attributes {
+		values[attribute.Name] = testValue(attribute.ValueType(), config[attribute.Name])
+	}
+	for _, nested := range block.BlockTypes {
+		raw, ok := config[nested.TypeName]
+		switch nested.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			var elements []tftypes.Value
+			if ok {
+				for _, element := range raw.([]map[string]any) {
+					elements = append(elements, testObjectValue(nested.Block, element))
+				}
+			}
+			values[nested.TypeName] = tftypes.NewValue(nested.ValueType(), elements)
+		default:
+			if ok {
+				values[nested.TypeName] = testObjectValue(nested.Block, raw.(map[string]any))
+			} else {
+				values[nested.TypeName] = tftypes.NewValue(nested.ValueType(), nil)
+			}
+		}
+	}
+	return tftypes.NewValue(block.ValueType(), values)
+}
+
+func testValue(typ tftypes.Type, raw any) tftypes.Value {
+	if raw == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	switch typ := typ.(type) {
+	case tftypes.Object:
+		values := map[string]tftypes.Value{}
+		for name, attributeType := range typ.AttributeTypes {
+			values[name] = testValue(attributeType, raw.(map[string]any)[name])
+		}
+		return tftypes.NewValue(typ, values)
+	case tftypes.List:
+		return tftypes.NewValue(typ, testElements(typ.ElementType, raw.([]any)))
+	case tftypes.Set:
+		return tftypes.NewValue(typ, testElements(typ.ElementType, raw.([]any)))
+	}
+	if n, ok := raw.(int); ok {
+		return tftypes.NewValue(typ, big.NewFloat(float64(n)))
+	}
+	return tftypes.NewValue(typ, raw)
+}
+
+func testElements(typ tftypes.Type, raw []any) []tftypes.Value {
+	elements := make([]tftypes.Value, len(raw))
+	for i, element := range raw {
+		elements[i] = testValue(typ, element)
+	}
+	return elements
+}
+
+// proposedNewBlock merges the prior state into the configuration the way Terraform does before planning:
+// computed attributes that are not configured keep their prior value.
+func proposedNewBlock(block *tfprotov6.SchemaBlock, prior, config tftypes.Value) tftypes.Value {
+	if config.IsNull() || prior.IsNull() || !prior.IsKnown() {
+		return config
+	}
+	var priorValues, configValues map[string]tftypes.Value
+	if err := prior.As(&priorValues); err != nil {
+		return config
+	}
+	if err := config.As(&configValues); err != nil {
+		return config
+	}
+	// As shares its map with the value, copy it so config is left untouched
+	values := maps.Clone(configValues)
+	for _, attribute := range block.Attributes {
+		values[attribute.Name] = proposedNewAttribute(attribute.Computed, attribute.NestedType, priorValues[attribute.Name], values[attribute.Name])
+	}
+	for _, nested := range block.BlockTypes {
+		if nested.Nesting == tfprotov6.SchemaNestedBlockNestingModeSingle {
+			values[nested.TypeName] = proposedNewBlock(nested.Block, priorValues[nested.TypeName], values[nested.TypeName])
+		}
+	}
+	return tftypes.NewValue(config.Type(), values)
+}
+
+// proposedNewAttribute merges nested attributes like proposedNewBlock. List elements are matched by index;
+// set elements cannot be matched and are kept as configured.
+func proposedNewAttribute(computed bool, nested *tfprotov6.SchemaObject, prior, config tftypes.Value) tftypes.Value {
+	if config.IsNull() {
+		if computed {
+			return prior
+		}
+		return config
+	}
+	if nested == nil || prior.IsNull() || !prior.IsKnown() {
+		return config
+	}
+	switch nested.Nesting {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		return proposedNewObject(nested, prior, config)
+	case tfprotov6.SchemaObjectNestingModeList:
+		var priorElements, configElements []tftypes.Value
+		if prior.As(&priorElements) != nil || config.As(&configElements) != nil || len(priorElements) != len(configElements) {
+			return config
+		}
+		elements := make([]tftypes.Value, len(configElements))
+		for i := range configElements {
+			elements[i] = proposedNewObject(nested, priorElements[i], configElements[i])
+		}
+		return tftypes.NewValue(config.Type(), elements)
+	}
+	return config
+}
+
+func proposedNewObject(nested *tfprotov6.SchemaObject, prior, config tftypes.Value) tftypes.Value {
+	var priorValues, configValues map[string]tftypes.Value
+	if prior.As(&priorValues) != nil || config.As(&configValues) != nil {
+		return config
+	}
+	values := maps.Clone(configValues)
+	for _, attribute := range nested.Attributes {
+		values[attribute.Name] = proposedNewAttribute(attribute.Computed, attribute.NestedType, priorValues[attribute.Name], values[attribute.Name])
+	}
+	return tftypes.NewValue(config.Type(), values)
+}
+
+func TestProviderConfiguresAgainstMockServer(t *testing.T) {
+	_, mock := newTestProvider(t)
+
+	for _, request := range mock.Requests() {
+		if request.Method == "GET" && request.Path == "/v3.0/healthcheck/connectivity" {
+			if got := request.Header.Get("Authorization"); got != "Bearer "+mock.APIKey() {
+				t.Errorf("the healthcheck was sent with Authorization %q", got)
+			}
+			return
+		}
+	}
+	t.Error("configuring the provider did not check the connectivity")
+}
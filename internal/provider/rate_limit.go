@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"math"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// serviceRateLimitsModel describes the service_rate_limits block of the provider.
+type serviceRateLimitsModel struct {
+	ContainerSecurity      *rateLimitModel `tfsdk:"container_security"`
+	CloudRiskManagement    *rateLimitModel `tfsdk:"cloud_risk_management"`
+	CloudAccountManagement *rateLimitModel `tfsdk:"cloud_account_management"`
+}
+
+type rateLimitModel struct {
+	RequestsPerSecond types.Float64 `tfsdk:"requests_per_second"`
+	Burst             types.Int64   `tfsdk:"burst"`
+}
+
+func rateLimitSchema(service string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: fmt.Sprintf("Rate limit of %s requests.", service),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			TF_KEY_REQUESTS_PER_SECOND: schema.Float64Attribute{
+				MarkdownDescription: fmt.Sprintf("Sustained number of %s requests per second. Set to `0` to send them without a limit of their own, sharing the provider-wide one.", service),
+				Required:            true,
+				Validators: []validator.Float64{
+					float64validator.AtLeast(0),
+				},
+			},
+			TF_KEY_BURST: schema.Int64Attribute{
+				MarkdownDescription: "Number of requests that may be sent at once. Defaults to `requests_per_second` rounded up.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+// resolveRateLimits applies requests_per_second, burst and service_rate_limits on top of the
+// default rate limits.
+func resolveRateLimits(data *TrendMicroProviderModel) trendmicro.RateLimits {
+	limits := trendmicro.DefaultRateLimits()
+	if !data.RequestsPerSecond.IsNull() {
+		limits.Default.RequestsPerSecond = data.RequestsPerSecond.ValueFloat64()
+	}
+	if !data.Burst.IsNull() {
+		limits.Default.Burst = int(data.Burst.ValueInt64())
+	}
+
+	if data.ServiceRateLimits == nil {
+		return limits
+	}
+	for _, setting := range []struct {
+		model  *rateLimitModel
+		target *trendmicro.RateLimit
+	}{
+		{data.ServiceRateLimits.ContainerSecurity, &limits.ContainerSecurity},
+		{data.ServiceRateLimits.CloudRiskManagement, &limits.CloudRiskManagement},
+		{data.ServiceRateLimits.CloudAccountManagement, &limits.CloudAccountManagement},
+	} {
+		if setting.model == nil {
+			continue
+		}
+		rps := setting.model.RequestsPerSecond.ValueFloat64()
+		burst := int(setting.model.Burst.ValueInt64())
+		if setting.model.Burst.IsNull() {
+			burst = int(math.Ceil(rps))
+		}
+		*setting.target = trendmicro.RateLimit{RequestsPerSecond: rps, Burst: burst}
+	}
+	return limits
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"context"
+	"os"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// resolveHost picks the API endpoint from, in order: regional_fqdn, region, the
+// VISIONONE_REGIONAL_FQDN and VISIONONE_REGION environment variables, and the regional_fqdn of
+// the shared profile. It also returns the attribute to blame when the endpoint turns out wrong.
+func resolveHost(data *TrendMicroProviderModel, creds *resolvedCredentials) (string, path.Path) {
+	switch {
+	case !data.RegFQDN.IsNull():
+		return data.RegFQDN.ValueString(), path.Root(TF_KEY_REG_FQDN)
+	case !data.Region.IsNull():
+		return trendmicro.RegionalFQDNs[data.Region.ValueString()], path.Root(TF_KEY_REGION)
+	}
+
+	if host := os.Getenv(ENV_VAR_NAME_REG_FQDN); host != "" {
+		return host, path.Root(TF_KEY_REG_FQDN)
+	}
+	if region := os.Getenv(ENV_VAR_NAME_REGION); region != "" {
+		// An unknown region leaves the host empty, which is reported as a missing endpoint.
+		return trendmicro.RegionalFQDNs[region], path.Root(TF_KEY_REGION)
+	}
+	return creds.RegFQDN, path.Root(TF_KEY_PROFILE)
+}
+
+var _ validator.String = &regionalFQDNValidator{}
+
+type regionalFQDNValidator struct{}
+
+func (v regionalFQDNValidator) Description(ctx context.Context) string {
+	return "value must be an http(s) URL made of scheme and host only"
+}
+
+func (v regionalFQDNValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be an http(s) URL made of scheme and host only, such as `https://api.xdr.trendmicro.com`"
+}
+
+func (v regionalFQDNValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := trendmicro.NormalizeRegionalFQDN(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Vision One Regional FQDN",
+			err.Error(),
+		)
+	}
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+)
+
+// rejectingProxy answers every request with 401 and records the hosts tunnelled through it.
+type rejectingProxy struct {
+	mu      sync.Mutex
+	tunnels []string
+}
+
+func (p *rejectingProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		p.mu.Lock()
+		p.tunnels = append(p.tunnels, r.Host)
+		p.mu.Unlock()
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusUnauthorized)
+}
+
+func TestConfigureProbesRegionsOnlyWhenEnabled(t *testing.T) {
+	for _, probe := range []bool{false, true} {
+		proxy := &rejectingProxy{}
+		server := httptest.NewServer(proxy)
+		t.Cleanup(server.Close)
+
+		p, _ := newUnconfiguredTestProvider(t)
+		config := map[string]any{TF_KEY_HTTP_PROXY: server.URL}
+		if probe {
+			config[TF_KEY_PROBE_REGIONS] = true
+		}
+		if diags := p.configure(config); len(diags) == 0 {
+			t.Fatal("configuring the provider with a rejected API key succeeded")
+		}
+
+		proxy.mu.Lock()
+		tunnels := proxy.tunnels
+		proxy.mu.Unlock()
+		if !probe && len(tunnels) != 0 {
+			t.Errorf("the rejected API key was sent to %v without probe_regions", tunnels)
+		}
+		// The fake backend is not a regional domain, so every region is probed
+		if probe && (len(tunnels) != len(trendmicro.RegionalFQDNs) || !slices.Contains(tunnels, "api.eu.xdr.trendmicro.com:443")) {
+			t.Errorf("probe_regions checked %v instead of every region", tunnels)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// requestTimeoutsModel describes the request_timeouts block of the provider.
+type requestTimeoutsModel struct {
+	Default                types.String `tfsdk:"default"`
+	ContainerSecurity      types.String `tfsdk:"container_security"`
+	CloudRiskManagement    types.String `tfsdk:"cloud_risk_management"`
+	CloudAccountManagement types.String `tfsdk:"cloud_account_management"`
+}
+
+// resolveTransport builds the HTTP transport shared by every client from the proxy, CA and client
+// certificate settings. Certificates are read from disk here so that a wrong path is reported
+// against the attribute that holds it.
+func resolveTransport(data *TrendMicroProviderModel) (*http.Transport, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	cfg := trendmicro.TransportConfig{
+		ProxyURL:           data.HTTPProxy.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}
+
+	switch {
+	case !data.CACertFile.IsNull():
+		pem, err := os.ReadFile(data.CACertFile.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root(TF_KEY_CA_CERT_FILE), "Unable to Read CA Certificate", err.Error())
+		}
+		cfg.CACertPEM = pem
+	case !data.CACertPEM.IsNull():
+		cfg.CACertPEM = []byte(data.CACertPEM.ValueString())
+	}
+
+	var err error
+	if cfg.ClientCertPEM, err = readPEMOrFile(data.ClientCert); err != nil {
+		diags.AddAttributeError(path.Root(TF_KEY_CLIENT_CERT), "Unable to Read Client Certificate", err.Error())
+	}
+	if cfg.ClientKeyPEM, err = readPEMOrFile(data.ClientKey); err != nil {
+		diags.AddAttributeError(path.Root(TF_KEY_CLIENT_KEY), "Unable to Read Client Key", err.Error())
+	}
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if cfg.InsecureSkipVerify {
+		diags.AddAttributeWarning(
+			path.Root(TF_KEY_INSECURE_SKIP_VERIFY),
+			"TLS Certificate Verification Disabled",
+			"The provider does not verify the certificate of Vision One or of the proxy, so the API key can be intercepted. "+
+				"Prefer trusting the proxy's certificate authority with "+TF_KEY_CA_CERT_FILE+" or "+TF_KEY_CA_CERT_PEM+".",
+		)
+	}
+
+	transport, err := trendmicro.NewTransport(cfg)
+	if err != nil {
+		diags.AddError("Invalid HTTP Transport Configuration", err.Error())
+		return nil, diags
+	}
+	return transport, diags
+}
+
+// resolveHTTPLogFile returns the path of the HTTP audit log from http_log_file or the
+// VISIONONE_HTTP_LOG environment variable, or "" when auditing is off.
+func resolveHTTPLogFile(data *TrendMicroProviderModel) string {
+	if !data.HTTPLogFile.IsNull() {
+		return data.HTTPLogFile.ValueString()
+	}
+	return os.Getenv(ENV_VAR_NAME_HTTP_LOG)
+}
+
+// readPEMOrFile returns the PEM content of value, which holds either the PEM itself or the path
+// of a file containing it.
+func readPEMOrFile(value types.String) ([]byte, error) {
+	if value.IsNull() || value.ValueString() == "" {
+		return nil, nil
+	}
+	if s := value.ValueString(); strings.Contains(s, "-----BEGIN") {
+		return []byte(s), nil
+	}
+	pem, err := os.ReadFile(value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("the value is neither PEM encoded nor a readable file: %w", err)
+	}
+	return pem, nil
+}
+
+// resolveTimeouts applies the request_timeouts block on top of the default timeouts. The durations
+// were checked by the schema validators.
+func resolveTimeouts(model *requestTimeoutsModel) trendmicro.Timeouts {
+	timeouts := trendmicro.DefaultTimeouts()
+	if model == nil {
+		return timeouts
+	}
+
+	for _, setting := range []struct {
+		value  types.String
+		target *time.Duration
+	}{
+		{model.Default, &timeouts.Default},
+		{model.ContainerSecurity, &timeouts.ContainerSecurity},
+		{model.CloudRiskManagement, &timeouts.CloudRiskManagement},
+		{model.CloudAccountManagement, &timeouts.CloudAccountManagement},
+	} {
+		if setting.value.IsNull() || setting.value.IsUnknown() {
+			continue
+		}
+		*setting.target, _ = time.ParseDuration(setting.value.ValueString())
+	}
+	return timeouts
+}
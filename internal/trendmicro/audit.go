@@ -0,0 +1,174 @@
+package trendmicro
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RedactedValue replaces the value of secret fields in the HTTP audit log.
+const RedactedValue = "***REDACTED***"
+
+// auditSecretFields are the JSON fields, compared case-insensitively, whose values never reach the
+// audit log: cluster API keys, GCP service account keys and the credentials of CRM communication
+// channels (webhook, Jira, ServiceNow, PagerDuty).
+var auditSecretFields = map[string]bool{
+	"apikey":            true,
+	"serviceaccountkey": true,
+	"privatekey":        true,
+	"securitytoken":     true,
+	"apitoken":          true,
+	"servicekey":        true,
+	"password":          true,
+}
+
+// AuditLog writes one JSON line per HTTP exchange with Vision One. The Authorization header is
+// never written and secret body fields are replaced by RedactedValue.
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLog returns an audit log writing to w.
+func NewAuditLog(w io.Writer) *AuditLog {
+	return &AuditLog{w: w}
+}
+
+// OpenAuditLog returns an audit log appending to the file at path, which is created readable by
+// the current user only.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open HTTP log %s: %w", path, err)
+	}
+	return NewAuditLog(f), nil
+}
+
+// WithAuditLog records every request of the client, including retries, in log.
+func WithAuditLog(log *AuditLog) ClientOption {
+	return func(c *Client) {
+		c.AuditLog = log
+	}
+}
+
+type auditEntry struct {
+	Time         string          `json:"time"`
+	Method       string          `json:"method"`
+	Path         string          `json:"path"`
+	Query        string          `json:"query,omitempty"`
+	Status       int             `json:"status,omitempty"`
+	LatencyMs    int64           `json:"latency_ms"`
+	TraceID      string          `json:"trace_id,omitempty"`
+	RequestBody  json.RawMessage `json:"request_body,omitempty"`
+	ResponseBody json.RawMessage `json:"response_body,omitempty"`
+	Error        string          `json:"error,omitempty"`
+}
+
+func (l *AuditLog) write(entry *auditEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.w.Write(append(line, '\n'))
+}
+
+// auditTransport is an http.RoundTripper recording each exchange in an AuditLog.
+type auditTransport struct {
+	next http.RoundTripper
+	log  *AuditLog
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	entry := &auditEntry{
+		Time:   time.Now().UTC().Format(time.RFC3339Nano),
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.RawQuery,
+	}
+
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = redactBody(body)
+	}
+
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	start := time.Now()
+	res, err := next.RoundTrip(req)
+	entry.LatencyMs = time.Since(start).Milliseconds()
+
+	if err != nil {
+		entry.Error = err.Error()
+		t.log.write(entry)
+		return res, err
+	}
+
+	entry.Status = res.StatusCode
+	entry.TraceID = res.Header.Get("x-trace-id")
+	body, readErr := io.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		entry.Error = readErr.Error()
+	}
+	entry.ResponseBody = redactBody(body)
+	t.log.write(entry)
+
+	if readErr != nil {
+		return nil, readErr
+	}
+	return res, nil
+}
+
+// redactBody returns body as JSON with secret fields masked. Bodies that are not JSON are
+// summarized by their size, since they cannot be checked for secrets.
+func redactBody(body []byte) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var value any
+	if err := json.Unmarshal(body, &value); err != nil {
+		summary, _ := json.Marshal(fmt.Sprintf("<%d bytes of non-JSON content>", len(body)))
+		return summary
+	}
+	redacted, err := json.Marshal(redactValue(value))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if auditSecretFields[strings.ToLower(key)] {
+				if field != nil && field != "" {
+					v[key] = RedactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
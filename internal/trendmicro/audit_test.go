@@ -0,0 +1,77 @@
+package trendmicro
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAuditLogRedactsSecrets(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-trace-id", "trace-1")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":"c1","apiKey":"cluster-secret","endpointUrl":"https://example"}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	c := &Client{
+		HostURL:     server.URL,
+		BearerToken: "provider-key",
+		HTTPClient:  &http.Client{Transport: &auditTransport{log: NewAuditLog(&buf)}},
+	}
+	reqBody := `{"enabled":true,"channelConfiguration":{"url":"https://hook","securityToken":"hook-secret","headers":[{"name":"x","password":"p"}]}}`
+	req, _ := http.NewRequest("POST", server.URL+"/v3.0/containerSecurity/kubernetesClusters?top=1", strings.NewReader(reqBody))
+
+	body, err := c.DoRequest(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(body), "cluster-secret") {
+		t.Errorf("the caller must still receive the unredacted response, got %s", body)
+	}
+
+	line := buf.String()
+	for _, secret := range []string{"cluster-secret", "hook-secret", `"p"`, "provider-key"} {
+		if strings.Contains(line, secret) {
+			t.Errorf("audit log leaks %s: %s", secret, line)
+		}
+	}
+
+	var entry auditEntry
+	if err := json.Unmarshal([]byte(line), &entry); err != nil {
+		t.Fatalf("audit log is not a JSON line: %v", err)
+	}
+	if entry.Method != "POST" || entry.Path != "/v3.0/containerSecurity/kubernetesClusters" || entry.Query != "top=1" ||
+		entry.Status != http.StatusCreated || entry.TraceID != "trace-1" {
+		t.Errorf("unexpected audit entry %+v", entry)
+	}
+	if !strings.Contains(string(entry.RequestBody), `"url":"https://hook"`) || !strings.Contains(string(entry.ResponseBody), `"id":"c1"`) {
+		t.Errorf("audit entry lost non-secret fields: %s", line)
+	}
+}
+
+func TestAuditLogSummarizesNonJSONBodies(t *testing.T) {
+	var buf bytes.Buffer
+	transport := &auditTransport{
+		log: NewAuditLog(&buf),
+		next: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader("token=secret"))}, nil
+		}),
+	}
+	req, _ := http.NewRequest("GET", "https://example/v3.0/healthcheck", http.NoBody)
+	if _, err := transport.RoundTrip(req); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "secret") || !strings.Contains(buf.String(), "12 bytes of non-JSON content") {
+		t.Errorf("unexpected audit entry for a non-JSON body: %s", buf.String())
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
@@ -1,17 +1,13 @@
 package trendmicro
 
 import (
-	"bytes"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"path"
 	"time"
-
-	"terraform-provider-vision-one/pkg/dto"
 )
 
 // HostURL - Default Hashicups URL
@@ -23,6 +19,29 @@ type Client struct {
 	BearerToken     string
 	TMUserAgent     string
 	ProviderVersion string
+	Retry           RetryConfig
+	// Timeouts are the request timeouts of the client and of each service, see ForService.
+	Timeouts Timeouts
+	// RateLimits shape the requests of the client and of each service, see ForService.
+	RateLimits RateLimits
+	// Credentials, when set, supplies the bearer token in place of BearerToken and is asked for a
+	// fresh key when Vision One answers 401.
+	Credentials CredentialSource
+	// AuditLog, when set, records every HTTP exchange with secrets redacted.
+	AuditLog *AuditLog
+
+	limiters *rateLimiters
+	limiter  *RateLimiter
+}
+
+// ClientOption customizes a Client built by NewClient before it validates connectivity.
+type ClientOption func(*Client)
+
+// WithRetry sets the retry policy applied to 429 and 5xx responses.
+func WithRetry(rc RetryConfig) ClientOption {
+	return func(c *Client) {
+		c.Retry = rc
+	}
 }
 
 // AuthResponse -
@@ -40,13 +59,32 @@ const (
 )
 
 // NewClient -
-func NewClient(host, token *string, version string) (*Client, error) {
+func NewClient(host, token *string, version string, opts ...ClientOption) (*Client, error) {
 	c := Client{
-		HTTPClient:      &http.Client{Timeout: 10 * time.Second},
+		HTTPClient:      &http.Client{},
 		HostURL:         *host,
 		BearerToken:     *token,
 		TMUserAgent:     TMUserAgent,
 		ProviderVersion: version,
+		Retry:           DefaultRetryConfig(),
+		Timeouts:        DefaultTimeouts(),
+		RateLimits:      DefaultRateLimits(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	c.HTTPClient.Timeout = c.Timeouts.Default
+	c.limiters = newRateLimiters(c.RateLimits)
+	c.limiter = c.limiters.fallback
+	if c.AuditLog != nil {
+		c.HTTPClient.Transport = &auditTransport{next: c.HTTPClient.Transport, log: c.AuditLog}
+	}
+	if c.Credentials != nil {
+		token, err := c.Credentials.Token()
+		if err != nil {
+			return nil, err
+		}
+		c.BearerToken = token
 	}
 
 	_, err := c.Auth()
@@ -58,11 +96,7 @@ func NewClient(host, token *string, version string) (*Client, error) {
 }
 
 func (c *Client) DoRequest(req *http.Request) (body []byte, err error) {
-	req.Header.Set("Authorization", "Bearer "+c.BearerToken)
-	req.Header.Set("User-Agent", UserAgentHeader+"/"+c.ProviderVersion)
-	req.Header.Set("x-tm-user-agent", c.TMUserAgent+"/"+c.ProviderVersion)
-
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.sendAuthorized(req)
 	if err != nil {
 		return nil, err
 	}
@@ -76,24 +110,13 @@ func (c *Client) DoRequest(req *http.Request) (body []byte, err error) {
 	switch res.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent, http.StatusMultiStatus:
 		return body, nil
-	case http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, StatusVisionOneInnerError:
-		var out bytes.Buffer
-		err = json.Indent(&out, body, "", "  ")
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("\n%w \nTrace id: %s", errors.New(out.String()), res.Header.Get("x-trace-id"))
 	default:
-		return nil, fmt.Errorf("%w trace id: %s", dto.ErrorInternal, res.Header.Get("x-trace-id"))
+		return nil, NewAPIError(res.StatusCode, res.Header, body)
 	}
 }
 
 func (c *Client) DoRequestWithFullResponse(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Bearer "+c.BearerToken)
-	req.Header.Set("User-Agent", UserAgentHeader+"/"+c.ProviderVersion)
-	req.Header.Set("x-tm-user-agent", c.TMUserAgent+"/"+c.ProviderVersion)
-
-	res, err := c.HTTPClient.Do(req)
+	res, err := c.sendAuthorized(req)
 	if err != nil {
 		return nil, err
 	}
@@ -101,44 +124,81 @@ func (c *Client) DoRequestWithFullResponse(req *http.Request) (*http.Response, e
 	switch res.StatusCode {
 	case http.StatusOK, http.StatusCreated, http.StatusNoContent, http.StatusMultiStatus:
 		return res, nil
-	case http.StatusNotFound, http.StatusBadRequest, http.StatusUnauthorized, http.StatusForbidden, StatusVisionOneInnerError:
+	default:
 		defer res.Body.Close()
 		var body []byte
 		body, err = io.ReadAll(res.Body)
 		if err != nil {
 			return nil, err
 		}
-
-		var out bytes.Buffer
-		err = json.Indent(&out, body, "", "  ")
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("\n%w \nTrace id: %s", errors.New(out.String()), res.Header.Get("x-trace-id"))
-	default:
-		return nil, fmt.Errorf("%w \nTrace id: %s", dto.ErrorInternal, res.Header.Get("x-trace-id"))
+		return nil, NewAPIError(res.StatusCode, res.Header, body)
 	}
 }
 
 // DoRequestRaw sends the request with the provider's bearer token and returns the raw
 // response, letting the caller branch on status code instead of getting one collapsed error.
+// NewAPIError turns a failed response into the same error type DoRequest returns.
 // The caller owns closing the body.
 func (c *Client) DoRequestRaw(req *http.Request) (*http.Response, error) {
-	req.Header.Set("Authorization", "Bearer "+c.BearerToken)
-	req.Header.Set("User-Agent", UserAgentHeader+"/"+c.ProviderVersion)
-	req.Header.Set("x-tm-user-agent", c.TMUserAgent+"/"+c.ProviderVersion)
-
-	return c.HTTPClient.Do(req)
+	return c.sendAuthorized(req)
 }
 
 // DoRequestRawWithToken is DoRequestRaw with a caller-supplied bearer token in place of the
 // client's provider-level BearerToken, for endpoints scoped to a narrower credential.
 func (c *Client) DoRequestRawWithToken(req *http.Request, token string) (*http.Response, error) {
+	c.setHeaders(req, token)
+
+	return c.send(req)
+}
+
+func (c *Client) setHeaders(req *http.Request, token string) {
 	req.Header.Set("Authorization", "Bearer "+token)
 	req.Header.Set("User-Agent", UserAgentHeader+"/"+c.ProviderVersion)
 	req.Header.Set("x-tm-user-agent", c.TMUserAgent+"/"+c.ProviderVersion)
+}
+
+func (c *Client) bearerToken() (string, error) {
+	if c.Credentials == nil {
+		return c.BearerToken, nil
+	}
+	return c.Credentials.Token()
+}
+
+// sendAuthorized sends req with the provider-level API key. When the key comes from a refreshable
+// credential source and Vision One rejects it, the source is asked for a fresh key and the request
+// is sent once more.
+func (c *Client) sendAuthorized(req *http.Request) (*http.Response, error) {
+	token, err := c.bearerToken()
+	if err != nil {
+		return nil, err
+	}
+	c.setHeaders(req, token)
+
+	res, err := c.send(req)
+	if err != nil || res.StatusCode != http.StatusUnauthorized || c.Credentials == nil {
+		return res, err
+	}
+
+	c.Credentials.Invalidate()
+	fresh, tokenErr := c.Credentials.Token()
+	if tokenErr != nil || fresh == token {
+		return res, nil
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		if req.GetBody == nil {
+			return res, nil
+		}
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return res, nil
+		}
+		req.Body = body
+	}
+	_, _ = io.Copy(io.Discard, res.Body)
+	res.Body.Close()
 
-	return c.HTTPClient.Do(req)
+	c.setHeaders(req, fresh)
+	return c.send(req)
 }
 
 // Auth - Authenticate the client with the Trend Micro Vision One API Secret Token and validate connectivity
@@ -170,9 +230,15 @@ func (c *Client) Auth() (*AuthResponse, error) {
 	return nil, nil
 }
 
+// WithTimeout returns a copy of the client with another request timeout, keeping its transport.
 func (c *Client) WithTimeout(d time.Duration) *Client {
 	clientCopy := *c
-	clientCopy.HTTPClient = &http.Client{Timeout: d}
+	httpClient := http.Client{}
+	if c.HTTPClient != nil {
+		httpClient = *c.HTTPClient
+	}
+	httpClient.Timeout = d
+	clientCopy.HTTPClient = &httpClient
 	return &clientCopy
 }
 
@@ -4,13 +4,14 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -91,7 +92,6 @@ type CloudAccountResponse struct {
 // CreateCloudAccount submits a POST request to register a new AWS account in CAM.
 // organizationID is injected as a tmv1-organizationID header when non-empty.
 func (c *CamClient) CreateCloudAccount(ctx context.Context, organizationID string, data *CreateCloudAccountRequest) (string, error) {
-	cam.JitterSleep(cam.AWSJitterConfig)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return "", err
@@ -147,7 +147,6 @@ func (c *CamClient) CreateCloudAccount(ctx context.Context, organizationID strin
 }
 
 func (c *CamClient) ReadCloudAccount(cloudAccountID string, excludeCloudAssets bool) (*CloudAccountResponse, error) {
-	cam.JitterSleep(cam.AWSJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/awsAccounts/%s", c.Client.HostURL, cloudAccountID)
 	if excludeCloudAssets {
 		url += "?excludeCloudAssets=true"
@@ -178,7 +177,6 @@ func (c *CamClient) ReadCloudAccount(cloudAccountID string, excludeCloudAssets b
 }
 
 func (c *CamClient) UpdateCloudAccounts(cloudAccountID, organizationID string, data *ModifyCloudAccountRequest) error {
-	cam.JitterSleep(cam.AWSJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/awsAccounts/%s", c.Client.HostURL, cloudAccountID)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -205,7 +203,6 @@ func (c *CamClient) UpdateCloudAccounts(cloudAccountID, organizationID string, d
 }
 
 func (c *CamClient) DeleteCloudAccounts(cloudAccountID string) error {
-	cam.JitterSleep(cam.AWSJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/awsAccounts/%s", c.Client.HostURL, cloudAccountID)
 
 	req, err := http.NewRequest("DELETE", url, http.NoBody)
@@ -215,7 +212,7 @@ func (c *CamClient) DeleteCloudAccounts(cloudAccountID string) error {
 
 	resp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") {
+		if errors.Is(err, dto.ErrorNotFound) {
 			return nil
 		}
 		return err
@@ -0,0 +1,57 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+func TestCloudAccountLifecycle(t *testing.T) {
+	server := mockserver.NewTestServer(t)
+	client := &CamClient{Client: &trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	}}
+
+	id, err := client.CreateCloudAccount(context.Background(), "o-example", &CreateCloudAccountRequest{
+		RoleArn: "arn:aws:iam::123456789012:role/VisionOne",
+		Name:    "prod",
+	})
+	if err != nil {
+		t.Fatalf("CreateCloudAccount: %v", err)
+	}
+
+	requests := server.Requests()
+	if got := requests[len(requests)-1].Header.Get("tmv1-organizationID"); got != "o-example" {
+		t.Errorf("expected the organization header on create, got %q", got)
+	}
+
+	name := "prod-renamed"
+	if err := client.UpdateCloudAccounts(id, "", &ModifyCloudAccountRequest{Name: &name}); err != nil {
+		t.Fatalf("UpdateCloudAccounts: %v", err)
+	}
+
+	account, err := client.ReadCloudAccount(id, true)
+	if err != nil {
+		t.Fatalf("ReadCloudAccount: %v", err)
+	}
+	if account.CloudAccountID != id || account.Name != name || account.State == "" {
+		t.Errorf("unexpected account %+v", account)
+	}
+
+	if err := client.DeleteCloudAccounts(id); err != nil {
+		t.Fatalf("DeleteCloudAccounts: %v", err)
+	}
+	if err := client.DeleteCloudAccounts(id); err != nil {
+		t.Errorf("deleting a missing account should succeed, got %v", err)
+	}
+	if _, err := client.ReadCloudAccount(id, true); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
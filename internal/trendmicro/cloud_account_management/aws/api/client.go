@@ -2,8 +2,17 @@ package api
 
 import (
 	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type CamClient struct {
 	Client *trendmicro.Client
 }
+
+// WithCredentials returns a client for the tenant named by override, or c when it is unset.
+func (c *CamClient) WithCredentials(override *dto.CredentialsOverrideModel) *CamClient {
+	if override == nil {
+		return c
+	}
+	return &CamClient{Client: c.Client.WithCredentialsOverride(override.ApiKey.ValueString(), override.RegFQDN.ValueString())}
+}
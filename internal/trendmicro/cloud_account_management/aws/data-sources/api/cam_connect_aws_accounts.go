@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
+	"terraform-provider-vision-one/internal/trendmicro"
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type CAMCloudAccountsResponse struct {
@@ -65,7 +68,7 @@ func filterByState(accounts []CAMCloudAccount, state string) []CAMCloudAccount {
 	return filtered
 }
 
-func (c *CamClient) ListCloudAccounts(cloudAccountIDs []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
+func (c *CamClient) ListCloudAccounts(ctx context.Context, cloudAccountIDs []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
 	if len(cloudAccountIDs) > 0 {
 		var allCloudAccounts []CAMCloudAccount
 		for _, cloudaccountID := range cloudAccountIDs {
@@ -80,28 +83,12 @@ func (c *CamClient) ListCloudAccounts(cloudAccountIDs []string, top int64, state
 		return &CAMCloudAccountsResponse{CloudAccounts: filterByState(allCloudAccounts, state)}, nil
 	}
 
-	url := fmt.Sprintf("%s/beta/cam/awsAccounts?%s", c.Client.HostURL, "top="+fmt.Sprintf("%d", top))
-
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Client.DoRequestWithFullResponse(req)
+	items, err := trendmicro.ListAll[CAMCloudAccount](ctx, c.Client, fmt.Sprintf("%s/beta/cam/awsAccounts", c.Client.HostURL), cam.CloudAccountListOptions(top))
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var cloudAccountsResponse CAMCloudAccountsResponse
-	if err := json.Unmarshal(body, &cloudAccountsResponse); err != nil {
-		return nil, err
-	}
+	cloudAccountsResponse := CAMCloudAccountsResponse{TotalCount: len(items), Count: len(items), CloudAccounts: items}
 	cloudAccountsResponse.CloudAccounts = filterByState(cloudAccountsResponse.CloudAccounts, state)
 
 	return &cloudAccountsResponse, nil
@@ -117,7 +104,7 @@ func (c *CamClient) DescribeCloudAccount(cloudAccountID string) (*CAMCloudAccoun
 
 	resp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
-		if strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if errors.Is(err, dto.ErrorNotFound) {
 			return nil, nil
 		}
 		return nil, err
@@ -2,6 +2,7 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"regexp"
@@ -11,6 +12,8 @@ import (
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/aws/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/aws/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/google/uuid"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
@@ -74,6 +77,8 @@ type CAMConnectorResourceModel struct {
 	ServerWorkloadProtectionRegions types.List   `tfsdk:"server_workload_protection_regions"`
 	CamDeployedRegion               types.String `tfsdk:"cam_deployed_region"`
 	PreventDestroy                  types.Bool   `tfsdk:"prevent_destroy"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 func (r *CAMConnectorResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -84,6 +89,7 @@ func (r *CAMConnectorResource) Schema(ctx context.Context, req resource.SchemaRe
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an AWS connector for Trend Micro Vision One CAM",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"cloud_account_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "AWS account ID (12-digit). Immutable — changing this forces a new resource.",
@@ -284,7 +290,7 @@ func (r *CAMConnectorResource) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[CAM Connector] CAM Connector resource configured successfully")
 }
@@ -405,6 +411,8 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	features, featureDiags := extractAWSFeatures(ctx, plan.Features)
 	resp.Diagnostics.Append(featureDiags...)
 	if resp.Diagnostics.HasError() {
@@ -427,8 +435,8 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	existing, readErr := r.client.ReadCloudAccount(cloudAccountID, true)
-	if readErr != nil && !strings.Contains(readErr.Error(), "NotFound") {
+	existing, readErr := client.ReadCloudAccount(cloudAccountID, true)
+	if readErr != nil && !errors.Is(readErr, dto.ErrorNotFound) {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Create] Error Checking Existing Account",
 			fmt.Sprintf("Failed to check for existing account: %s", readErr),
@@ -461,7 +469,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		setBoolPtr(&postBody.IsCremEnabled, plan.IsCremEnabled)
 		setBoolPtr(&postBody.IsTFProviderDeployed, plan.IsTFProviderDeployed)
 		setBoolPtr(&postBody.IsAwsOrgMgmtAccount, plan.IsAwsOrgMgmtAccount)
-		if _, err := r.client.CreateCloudAccount(ctx, plan.OrganizationID.ValueString(), postBody); err != nil {
+		if _, err := client.CreateCloudAccount(ctx, plan.OrganizationID.ValueString(), postBody); err != nil {
 			resp.Diagnostics.AddError(
 				"[CAM Connector][Create] Error Adding AWS Account",
 				fmt.Sprintf("[CAM Connector][Create] Failed to add AWS account: %s", err),
@@ -477,7 +485,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		orgMgmtSet := !plan.IsAwsOrgMgmtAccount.IsNull() && !plan.IsAwsOrgMgmtAccount.IsUnknown() && plan.IsAwsOrgMgmtAccount.ValueBool()
 		if orgIDSet || orgMgmtSet {
 			tflog.Info(ctx, fmt.Sprintf("[CAM Connector][Create] Account %s already registered; org fields require re-registration (DELETE + POST)", cloudAccountID))
-			if err := r.client.DeleteCloudAccounts(cloudAccountID); err != nil {
+			if err := client.DeleteCloudAccounts(cloudAccountID); err != nil {
 				resp.Diagnostics.AddError(
 					"[CAM Connector][Create] Error Re-registering AWS Account",
 					fmt.Sprintf("[CAM Connector][Create] Failed to delete existing account for re-registration: %s", err),
@@ -504,7 +512,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 			setBoolPtr(&postBody.IsCremEnabled, plan.IsCremEnabled)
 			setBoolPtr(&postBody.IsTFProviderDeployed, plan.IsTFProviderDeployed)
 			setBoolPtr(&postBody.IsAwsOrgMgmtAccount, plan.IsAwsOrgMgmtAccount)
-			if _, err := r.client.CreateCloudAccount(ctx, plan.OrganizationID.ValueString(), postBody); err != nil {
+			if _, err := client.CreateCloudAccount(ctx, plan.OrganizationID.ValueString(), postBody); err != nil {
 				resp.Diagnostics.AddError(
 					"[CAM Connector][Create] Error Re-registering AWS Account",
 					fmt.Sprintf("[CAM Connector][Create] Failed to re-register account after deletion: %s", err),
@@ -533,7 +541,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 			setBoolPtr(&updateBody.IsCremEnabled, plan.IsCremEnabled)
 			setBoolPtr(&updateBody.IsTFProviderDeployed, plan.IsTFProviderDeployed)
 			setBoolPtr(&updateBody.IsAwsOrgMgmtAccount, plan.IsAwsOrgMgmtAccount)
-			if err := r.client.UpdateCloudAccounts(cloudAccountID, plan.OrganizationID.ValueString(), updateBody); err != nil {
+			if err := client.UpdateCloudAccounts(cloudAccountID, plan.OrganizationID.ValueString(), updateBody); err != nil {
 				resp.Diagnostics.AddError(
 					"[CAM Connector][Create] Error Updating Existing AWS Account",
 					fmt.Sprintf("[CAM Connector][Create] Failed to update existing account: %s", err),
@@ -543,7 +551,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		}
 	}
 
-	res, err := r.client.ReadCloudAccount(cloudAccountID, true)
+	res, err := client.ReadCloudAccount(cloudAccountID, true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Create] Error Reading AWS Account",
@@ -577,10 +585,12 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	res, err := r.client.ReadCloudAccount(state.CloudAccountID.ValueString(), true)
+	client := r.client.WithCredentials(state.Credentials)
+
+	res, err := client.ReadCloudAccount(state.CloudAccountID.ValueString(), true)
 	if err != nil {
 		// 401/403/500 are NOT deletion — surface the error
-		if strings.Contains(err.Error(), "NotFound") {
+		if errors.Is(err, dto.ErrorNotFound) {
 			tflog.Info(ctx, "[CAM Connector][Read] Account not found, removing from state")
 			resp.State.RemoveResource(ctx)
 			return
@@ -614,6 +624,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	client := r.client.WithCredentials(plan.Credentials)
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -681,7 +693,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 
 	cloudAccountID := state.CloudAccountID.ValueString()
 
-	err := r.client.UpdateCloudAccounts(cloudAccountID, plan.OrganizationID.ValueString(), body)
+	err := client.UpdateCloudAccounts(cloudAccountID, plan.OrganizationID.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Update] Error Updating AWS Account",
@@ -690,7 +702,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	res, err := r.client.ReadCloudAccount(cloudAccountID, true)
+	res, err := client.ReadCloudAccount(cloudAccountID, true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Update] Error Describing AWS Account",
@@ -721,6 +733,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		state.CustomTags = plan.CustomTags
 	}
 
+	state.Credentials = plan.Credentials
+
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 }
@@ -734,12 +748,14 @@ func (r *CAMConnectorResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	if state.PreventDestroy.IsNull() || state.PreventDestroy.IsUnknown() || state.PreventDestroy.ValueBool() {
 		tflog.Info(ctx, fmt.Sprintf("[CAM Connector][Delete] prevent_destroy=true (or unset), skipping CAM DELETE for account %s", state.CloudAccountID.ValueString()))
 		return
 	}
 
-	err := r.client.DeleteCloudAccounts(state.CloudAccountID.ValueString())
+	err := client.DeleteCloudAccounts(state.CloudAccountID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Delete] Error Removing Subscription",
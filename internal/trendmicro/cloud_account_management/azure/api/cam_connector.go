@@ -3,13 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type CreateSubscriptionRequest struct {
@@ -79,7 +80,6 @@ type Feature struct {
 }
 
 func (c *CamClient) CreateSubscription(data *CreateSubscriptionRequest) error {
-	cam.JitterSleep(cam.AzureJitterConfig)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -90,7 +90,7 @@ func (c *CamClient) CreateSubscription(data *CreateSubscriptionRequest) error {
 	// Attempt to read the subscription to determine if it already exists
 	describeResp, err := c.ReadSubscription(data.SubscriptionID, true)
 	if err != nil {
-		if !strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if !errors.Is(err, dto.ErrorNotFound) {
 			return fmt.Errorf("failed to verify subscription existence: %w", err)
 		}
 		fmt.Printf("Subscription not found, proceeding to create new subscription: %s\n", data.SubscriptionID)
@@ -161,7 +161,6 @@ func (c *CamClient) CreateSubscription(data *CreateSubscriptionRequest) error {
 }
 
 func (c *CamClient) ReadSubscription(subscriptionID string, excludeCloudAssets bool) (*SubscriptionResponse, error) {
-	cam.JitterSleep(cam.AzureJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/azureSubscriptions/%s", c.Client.HostURL, subscriptionID)
 	if excludeCloudAssets {
 		url += "?excludeCloudAssets=true"
@@ -193,7 +192,6 @@ func (c *CamClient) ReadSubscription(subscriptionID string, excludeCloudAssets b
 }
 
 func (c *CamClient) UpdateSubscription(subscriptionID string, data *ModifySubscriptionRequest) error {
-	cam.JitterSleep(cam.AzureJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/azureSubscriptions/%s", c.Client.HostURL, subscriptionID)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -216,7 +214,6 @@ func (c *CamClient) UpdateSubscription(subscriptionID string, data *ModifySubscr
 }
 
 func (c *CamClient) DeleteSubscription(subscriptionID string) error {
-	cam.JitterSleep(cam.AzureJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/azureSubscriptions/%s", c.Client.HostURL, subscriptionID)
 
 	req, err := http.NewRequest("DELETE", url, http.NoBody)
@@ -1,11 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 
+	"terraform-provider-vision-one/internal/trendmicro"
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
 
 	"github.com/google/uuid"
@@ -48,7 +50,7 @@ type FeatureDetail struct {
 	TemplateVersion types.String   `json:"template_version"`
 }
 
-func (c *CamClient) ListAzureSubscriptions(subscriptionIds []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
+func (c *CamClient) ListAzureSubscriptions(ctx context.Context, subscriptionIds []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
 	if len(subscriptionIds) > 0 {
 		var allCloudAccounts []CAMCloudAccount
 		for _, subscriptionId := range subscriptionIds {
@@ -74,29 +76,12 @@ func (c *CamClient) ListAzureSubscriptions(subscriptionIds []string, top int64,
 		return &CAMCloudAccountsResponse{CloudAccounts: allCloudAccounts}, nil
 	}
 
-	url := fmt.Sprintf("%s/beta/cam/azureSubscriptions?%s", c.Client.HostURL, "top="+fmt.Sprintf("%d", top))
-
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Client.DoRequestWithFullResponse(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	items, err := trendmicro.ListAll[CAMCloudAccount](ctx, c.Client, fmt.Sprintf("%s/beta/cam/azureSubscriptions", c.Client.HostURL), cam.CloudAccountListOptions(top))
 	if err != nil {
 		return nil, err
 	}
 
-	var cloudAccountsResponse CAMCloudAccountsResponse
-	err = json.Unmarshal(body, &cloudAccountsResponse)
-	if err != nil {
-		return nil, err
-	}
+	cloudAccountsResponse := CAMCloudAccountsResponse{TotalCount: len(items), Count: len(items), CloudAccounts: items}
 	// filter by state if provided
 	if state != "" {
 		var filteredCloudAccounts []CAMCloudAccount
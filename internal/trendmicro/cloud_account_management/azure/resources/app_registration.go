@@ -317,7 +317,7 @@ func (r *appRegistration) Configure(ctx context.Context, req resource.ConfigureR
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[App Registration] App Registration resource configured successfully")
 }
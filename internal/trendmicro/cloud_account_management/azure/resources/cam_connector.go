@@ -9,6 +9,8 @@ import (
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/azure/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/azure/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -69,6 +71,8 @@ type CAMConnectorResourceModel struct {
 	FeaturesConfigFilePath    types.String                `tfsdk:"features_config_file_path"`
 	PreventDestroy            types.Bool                  `tfsdk:"prevent_destroy"`
 	AutoDiscoveryEnabled      types.Bool                  `tfsdk:"auto_discovery_enabled"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type ManagementGroupDetailsModel struct {
@@ -85,6 +89,7 @@ func (r *CAMConnectorResource) Schema(ctx context.Context, req resource.SchemaRe
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages an Azure connector for Trend Micro Vision One CAM",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"application_id": schema.StringAttribute{
 				Required:            true,
 				MarkdownDescription: "Azure application ID which is used to connect to the Azure subscription",
@@ -259,7 +264,7 @@ func (r *CAMConnectorResource) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[CAM Connector] CAM Connector resource configured successfully")
 }
@@ -308,6 +313,8 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	var connectedServices []cam.ConnectedSecurityService
 	if !plan.ConnectedSecurityServices.IsNull() {
 		var securityServiceModels []SecurityServiceModel
@@ -365,7 +372,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		AutoDiscoveryEnabled:      plan.AutoDiscoveryEnabled.ValueBool(),
 	}
 
-	createErr := r.client.CreateSubscription(body)
+	createErr := client.CreateSubscription(body)
 	if createErr != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Create] Error Adding Subscription",
@@ -373,7 +380,7 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 		)
 		return
 	}
-	res, err := r.client.ReadSubscription(plan.SubscriptionID.ValueString(), true)
+	res, err := client.ReadSubscription(plan.SubscriptionID.ValueString(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Create] Error Describing Subscription",
@@ -443,6 +450,8 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	var connectedServices []cam.ConnectedSecurityService
 	if !state.ConnectedSecurityServices.IsNull() {
 		var securityServiceModels []SecurityServiceModel
@@ -467,7 +476,7 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 		}
 	}
 
-	res, err := r.client.ReadSubscription(state.SubscriptionID.ValueString(), true)
+	res, err := client.ReadSubscription(state.SubscriptionID.ValueString(), true)
 	if err != nil {
 		tflog.Warn(ctx, "[CAM Connector][Read] Failed to describe subscription, will attempt to create it", map[string]any{
 			"error": err.Error(),
@@ -503,7 +512,7 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 			AutoDiscoveryEnabled:      state.AutoDiscoveryEnabled.ValueBool(),
 		}
 
-		err = r.client.CreateSubscription(body)
+		err = client.CreateSubscription(body)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"[CAM Connector][Read] Error Adding Subscription",
@@ -548,12 +557,10 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 			AutoDiscoveryEnabled:      state.AutoDiscoveryEnabled.ValueBool(),
 		}
 
-		err = r.client.UpdateSubscription(res.SubscriptionID, body)
+		err = client.UpdateSubscription(res.SubscriptionID, body)
 		if err != nil {
-			// Check if the error indicates the application ID is not found in the tenant
-			if strings.Contains(err.Error(), "application ID provided within your request was not found") ||
-				strings.Contains(err.Error(), "assume-identity-failed") ||
-				strings.Contains(err.Error(), "BadRequest") {
+			// Vision One cannot assume the identity of an application ID that no longer exists in the tenant
+			if trendmicro.HasErrorCode(err, "assume-identity-failed") {
 				tflog.Info(ctx, fmt.Sprintf("[CAM Connector][Read] Application ID %s no longer exists in tenant, removing from state", state.ApplicationID.ValueString()))
 				resp.State.RemoveResource(ctx)
 				return
@@ -566,7 +573,7 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 		}
 	}
 
-	res, err = r.client.ReadSubscription(state.SubscriptionID.ValueString(), true)
+	res, err = client.ReadSubscription(state.SubscriptionID.ValueString(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Read] Error Describing Subscription",
@@ -633,6 +640,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	client := r.client.WithCredentials(plan.Credentials)
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -710,7 +719,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 	// backend's current name instead of plan/state's, so Update() never overwrites a name
 	// that was changed out-of-band (e.g. via the console) back to Terraform's stale value.
 	targetName := plan.Name.ValueString()
-	if current, err := r.client.ReadSubscription(subscriptionID, true); err == nil && current.Name != "" {
+	if current, err := client.ReadSubscription(subscriptionID, true); err == nil && current.Name != "" {
 		targetName = current.Name
 	}
 
@@ -731,7 +740,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		AutoDiscoveryEnabled:      plan.AutoDiscoveryEnabled.ValueBool(),
 	}
 
-	err := r.client.UpdateSubscription(subscriptionID, body)
+	err := client.UpdateSubscription(subscriptionID, body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Update] Error Updating Subscription",
@@ -740,7 +749,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	res, err := r.client.ReadSubscription(plan.SubscriptionID.ValueString(), true)
+	res, err := client.ReadSubscription(plan.SubscriptionID.ValueString(), true)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Update] Error Describing Subscription",
@@ -805,6 +814,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		state.AutoDiscoveryEnabled = plan.AutoDiscoveryEnabled
 	}
 
+	state.Credentials = plan.Credentials
+
 	resp.State.Set(ctx, &state)
 }
 
@@ -817,12 +828,14 @@ func (r *CAMConnectorResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	if state.PreventDestroy.IsNull() || state.PreventDestroy.IsUnknown() || state.PreventDestroy.ValueBool() {
 		tflog.Info(ctx, fmt.Sprintf("[CAM Connector][Delete] prevent_destroy=true (or unset), skipping CAM DELETE for subscription %s", state.SubscriptionID.ValueString()))
 		return
 	}
 
-	err := r.client.DeleteSubscription(state.SubscriptionID.ValueString())
+	err := client.DeleteSubscription(state.SubscriptionID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"[CAM Connector][Delete] Error Removing Subscription",
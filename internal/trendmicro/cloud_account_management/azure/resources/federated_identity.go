@@ -14,7 +14,6 @@ import (
 	"github.com/microsoftgraph/msgraph-sdk-go/models"
 
 	"terraform-provider-vision-one/internal/trendmicro"
-	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/azure/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/azure/resources/config"
 )
@@ -377,7 +376,7 @@ func (r *federatedIdentity) Configure(ctx context.Context, req resource.Configur
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[Federated Identity] Federated Identity resource configured successfully")
 }
@@ -3,13 +3,14 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 	"time"
 
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type OrganizationDetails struct {
@@ -118,7 +119,6 @@ type ProjectResponse struct {
 }
 
 func (c *CamClient) CreateProject(data *CreateProjectRequest) error {
-	cam.JitterSleep(cam.GCPJitterConfig)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return err
@@ -129,7 +129,7 @@ func (c *CamClient) CreateProject(data *CreateProjectRequest) error {
 	// Attempt to read the project to determine if it already exists
 	describeResp, err := c.ReadProject(data.ProjectNumber)
 	if err != nil {
-		if !strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if !errors.Is(err, dto.ErrorNotFound) {
 			return fmt.Errorf("failed to verify project existence: %w", err)
 		}
 		fmt.Printf("Project not found, proceeding to create new project: %s\n", data.ProjectNumber)
@@ -201,7 +201,6 @@ func (c *CamClient) CreateProject(data *CreateProjectRequest) error {
 }
 
 func (c *CamClient) ReadProject(projectNumber string) (*ProjectResponse, error) {
-	cam.JitterSleep(cam.GCPJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/gcpProjects/%s?excludeCloudAssets=true", c.Client.HostURL, projectNumber)
 
 	req, err := http.NewRequest("GET", url, http.NoBody)
@@ -230,7 +229,6 @@ func (c *CamClient) ReadProject(projectNumber string) (*ProjectResponse, error)
 }
 
 func (c *CamClient) UpdateProject(projectNumber string, data *ModifyProjectRequest) error {
-	cam.JitterSleep(cam.GCPJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/gcpProjects/%s", c.Client.HostURL, projectNumber)
 	jsonData, err := json.Marshal(data)
 	if err != nil {
@@ -253,7 +251,6 @@ func (c *CamClient) UpdateProject(projectNumber string, data *ModifyProjectReque
 }
 
 func (c *CamClient) DeleteProject(projectNumber string) error {
-	cam.JitterSleep(cam.GCPJitterConfig)
 	url := fmt.Sprintf("%s/beta/cam/gcpProjects/%s", c.Client.HostURL, projectNumber)
 
 	req, err := http.NewRequest("DELETE", url, http.NoBody)
@@ -263,7 +260,7 @@ func (c *CamClient) DeleteProject(projectNumber string) error {
 
 	resp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
-		if strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if errors.Is(err, dto.ErrorNotFound) {
 			return nil
 		}
 		return err
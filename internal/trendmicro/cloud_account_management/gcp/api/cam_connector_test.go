@@ -1,13 +1,15 @@
 package api
 
 import (
+	"errors"
 	"io"
 	"net/http"
 	"strings"
 	"testing"
 
 	"terraform-provider-vision-one/internal/trendmicro"
-	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type roundTripFunc func(*http.Request) (*http.Response, error)
@@ -17,10 +19,6 @@ func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
 }
 
 func TestDeleteProjectTreatsNotFoundAsSuccess(t *testing.T) {
-	originalJitter := cam.GCPJitterConfig
-	cam.GCPJitterConfig = cam.JitterConfig{}
-	t.Cleanup(func() { cam.GCPJitterConfig = originalJitter })
-
 	client := newTestCAMClient(func(req *http.Request) (*http.Response, error) {
 		return jsonResponse(http.StatusNotFound, `{"error":{"code":"NotFound","message":"not found"}}`), nil
 	})
@@ -30,6 +28,38 @@ func TestDeleteProjectTreatsNotFoundAsSuccess(t *testing.T) {
 	}
 }
 
+func TestCreateProjectModifiesExistingProject(t *testing.T) {
+	server := mockserver.NewTestServer(t)
+	server.Seed(mockserver.CAMGCPProjectsPath, mockserver.Object{"id": "123", "projectNumber": "123", "state": "managed"})
+	client := &CamClient{Client: &trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	}}
+
+	if err := client.CreateProject(&CreateProjectRequest{ProjectNumber: "123", Description: "adopted"}); err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+	if got := len(server.List(mockserver.CAMGCPProjectsPath)); got != 1 {
+		t.Fatalf("existing project should be modified, not added; have %d projects", got)
+	}
+
+	project, err := client.ReadProject("123")
+	if err != nil {
+		t.Fatalf("ReadProject: %v", err)
+	}
+	if project.Description != "adopted" {
+		t.Errorf("unexpected project %+v", project)
+	}
+
+	if err := client.DeleteProject("123"); err != nil {
+		t.Fatalf("DeleteProject: %v", err)
+	}
+	if _, err := client.ReadProject("123"); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
+
 func newTestCAMClient(roundTrip roundTripFunc) *CamClient {
 	return &CamClient{Client: &trendmicro.Client{
 		HostURL:    "https://unit.test",
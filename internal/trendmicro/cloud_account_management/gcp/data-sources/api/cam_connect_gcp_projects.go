@@ -1,13 +1,16 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
-	"strings"
 
+	"terraform-provider-vision-one/internal/trendmicro"
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -70,7 +73,7 @@ type FeatureDetail struct {
 	TemplateVersion types.String   `json:"template_version"`
 }
 
-func (c *CamClient) ListGCPProjects(projectIds []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
+func (c *CamClient) ListGCPProjects(ctx context.Context, projectIds []string, top int64, state string) (*CAMCloudAccountsResponse, error) {
 	if len(projectIds) > 0 {
 		var allCloudAccounts []CAMCloudAccount
 		for _, projectId := range projectIds {
@@ -95,29 +98,12 @@ func (c *CamClient) ListGCPProjects(projectIds []string, top int64, state string
 		return &CAMCloudAccountsResponse{CloudAccounts: allCloudAccounts}, nil
 	}
 
-	url := fmt.Sprintf("%s/beta/cam/gcpProjects?%s", c.Client.HostURL, "top="+fmt.Sprintf("%d", top))
-
-	req, err := http.NewRequest("GET", url, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	resp, err := c.Client.DoRequestWithFullResponse(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
+	items, err := trendmicro.ListAll[CAMCloudAccount](ctx, c.Client, fmt.Sprintf("%s/beta/cam/gcpProjects", c.Client.HostURL), cam.CloudAccountListOptions(top))
 	if err != nil {
 		return nil, err
 	}
 
-	var cloudAccountsResponse CAMCloudAccountsResponse
-	err = json.Unmarshal(body, &cloudAccountsResponse)
-	if err != nil {
-		return nil, err
-	}
+	cloudAccountsResponse := CAMCloudAccountsResponse{TotalCount: len(items), Count: len(items), CloudAccounts: items}
 	// filter by state if provided
 	if state != "" {
 		var filteredCloudAccounts []CAMCloudAccount
@@ -145,7 +131,7 @@ func (c *CamClient) DescribeGCPProject(projectId string) (*CAMCloudAccount, erro
 
 	resp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
-		if strings.Contains(err.Error(), "NotFound") || strings.Contains(err.Error(), "Could not find") {
+		if errors.Is(err, dto.ErrorNotFound) {
 			return nil, nil
 		}
 		return nil, err
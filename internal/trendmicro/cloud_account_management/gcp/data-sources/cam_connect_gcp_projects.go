@@ -119,7 +119,7 @@ func (d *CAMCloudAccountsDataSource) Schema(_ context.Context, _ datasource.Sche
 			},
 			"top": schema.Int64Attribute{
 				Optional:            true,
-				MarkdownDescription: "Maximum number of cloud accounts to return. Valid values: 25, 50, 100, 500, 1000, 5000.",
+				MarkdownDescription: "Maximum number of cloud accounts to return, also used as the page size. Valid values: 25, 50, 100, 500, 1000, 5000. When unset, all cloud accounts are returned.",
 			},
 		},
 	}
@@ -338,12 +338,8 @@ func (d *CAMCloudAccountsDataSource) Read(ctx context.Context, req datasource.Re
 
 	projectIDs := cam.ConvertTypesStringSliceToStringSlice(data.ProjectIds)
 
-	var top int64
-	if data.Top.ValueInt64() > 0 {
-		top = data.Top.ValueInt64()
-	} else {
-		top = 100
-	}
+	// An unset top reads every page, see cam.CloudAccountListOptions.
+	top := data.Top.ValueInt64()
 
 	var state string
 	if !data.State.IsNull() && !data.State.IsUnknown() {
@@ -352,7 +348,7 @@ func (d *CAMCloudAccountsDataSource) Read(ctx context.Context, req datasource.Re
 		state = ""
 	}
 
-	response, err := d.client.ListGCPProjects(projectIDs, top, state)
+	response, err := d.client.ListGCPProjects(ctx, projectIDs, top, state)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Failed to Read CAM Cloud Accounts",
@@ -401,7 +397,7 @@ func (d *CAMCloudAccountsDataSource) Configure(ctx context.Context, req datasour
 	}
 
 	d.client = &api.CamClient{
-		Client: client,
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[CAM Cloud Accounts] CAM Cloud Accounts GCP data source configured successfully")
 }
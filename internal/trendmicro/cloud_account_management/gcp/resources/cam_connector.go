@@ -12,6 +12,8 @@ import (
 	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -80,6 +82,8 @@ type CAMConnectorResourceModel struct {
 	ServiceAccountEmail types.String `tfsdk:"service_account_email"`
 	State               types.String `tfsdk:"state"`
 	UpdatedDateTime     types.String `tfsdk:"updated_date_time"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 // OrganizationDetailsModel represents the GCP organization details in Terraform state.
@@ -104,6 +108,7 @@ func (r *CAMConnectorResource) Schema(ctx context.Context, req resource.SchemaRe
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Manages a GCP connector for Trend Micro Vision One CAM",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"cam_deployed_region": schema.StringAttribute{
 				Optional:            true,
 				Computed:            true,
@@ -317,7 +322,7 @@ func (r *CAMConnectorResource) Configure(ctx context.Context, req resource.Confi
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[CAM Connector GCP] CAM Connector resource configured successfully")
 }
@@ -463,7 +468,8 @@ func (r *CAMConnectorResource) Create(ctx context.Context, req resource.CreateRe
 	unlock := lockGCPCAMProjectMutation(plan.ProjectNumber.ValueString())
 	defer unlock()
 
-	res, err := createProjectAndWaitConnected(ctx, r.client, body, plan.ProjectNumber.ValueString(),
+	client := r.client.WithCredentials(plan.Credentials)
+	res, err := createProjectAndWaitConnected(ctx, client, body, plan.ProjectNumber.ValueString(),
 		gcpProjectConnectedWaitTimeout, gcpProjectConnectedWaitInterval)
 	if err != nil {
 		if addGCPNetworkRetryDiagnostic(&resp.Diagnostics, "Create", err) {
@@ -504,9 +510,11 @@ func (r *CAMConnectorResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	res, err := r.client.ReadProject(state.ProjectNumber.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	res, err := client.ReadProject(state.ProjectNumber.ValueString())
 	if err != nil {
-		if strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if errors.Is(err, dto.ErrorNotFound) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
@@ -541,6 +549,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 
 	diags := req.Plan.Get(ctx, &plan)
 	resp.Diagnostics.Append(diags...)
+
+	client := r.client.WithCredentials(plan.Credentials)
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -663,7 +673,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 	unlock := lockGCPCAMProjectMutation(projectNumber)
 	defer unlock()
 
-	err = r.client.UpdateProject(projectNumber, body)
+	err = client.UpdateProject(projectNumber, body)
 	if err != nil {
 		if addGCPNetworkRetryDiagnostic(&resp.Diagnostics, "Update", err) {
 			return
@@ -675,7 +685,7 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	res, err := r.client.ReadProject(plan.ProjectNumber.ValueString())
+	res, err := client.ReadProject(plan.ProjectNumber.ValueString())
 	if err != nil {
 		if addGCPNetworkRetryDiagnostic(&resp.Diagnostics, "Update", err) {
 			return
@@ -703,6 +713,8 @@ func (r *CAMConnectorResource) Update(ctx context.Context, req resource.UpdateRe
 		state.AutoDetectionOrganizationID = plan.AutoDetectionOrganizationID
 	}
 
+	state.Credentials = plan.Credentials
+
 	resp.State.Set(ctx, &state)
 }
 
@@ -715,10 +727,12 @@ func (r *CAMConnectorResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	unlock := lockGCPCAMProjectMutation(state.ProjectNumber.ValueString())
 	defer unlock()
 
-	err := r.client.DeleteProject(state.ProjectNumber.ValueString())
+	err := client.DeleteProject(state.ProjectNumber.ValueString())
 	if err != nil {
 		if addGCPNetworkRetryDiagnostic(&resp.Diagnostics, "Delete", err) {
 			return
@@ -100,7 +100,7 @@ func (r *EnableAPIServices) Configure(_ context.Context, req resource.ConfigureR
 		return
 	}
 
-	r.client.Client = client
+	r.client.Client = client.ForService(trendmicro.ServiceCloudAccountManagement)
 }
 
 func (r *EnableAPIServices) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
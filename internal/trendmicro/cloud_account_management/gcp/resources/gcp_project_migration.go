@@ -2,6 +2,7 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -10,7 +11,7 @@ import (
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/resources/config"
 
-	cam "terraform-provider-vision-one/internal/trendmicro/cloud_account_management"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -134,7 +135,7 @@ func (r *GCPProjectMigrationResource) Configure(_ context.Context, req resource.
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 }
 
@@ -171,7 +172,7 @@ func (r *GCPProjectMigrationResource) Create(ctx context.Context, req resource.C
 	// UpdateProject returns a clear "code": "NotFound" error if the project is not registered.
 	existing, readExistingErr := r.client.ReadProject(projectNumber)
 	if readExistingErr != nil {
-		if strings.Contains(readExistingErr.Error(), `"code": "NotFound"`) {
+		if errors.Is(readExistingErr, dto.ErrorNotFound) {
 			resp.Diagnostics.AddError(
 				"[GCP Project Migration] Project Not Found",
 				fmt.Sprintf("project %s not found in CAM database; ensure the project was registered via the legacy Terraform Package Solution", projectNumber),
@@ -200,7 +201,7 @@ func (r *GCPProjectMigrationResource) Create(ctx context.Context, req resource.C
 	err = r.client.UpdateProject(projectNumber, updateReq)
 	if err != nil {
 		errMsg := fmt.Sprintf("failed to update project with new service account: %s", err)
-		if strings.Contains(err.Error(), `"code": "NotFound"`) {
+		if errors.Is(err, dto.ErrorNotFound) {
 			errMsg = fmt.Sprintf("project %s not found in CAM database; ensure the project was registered via the legacy Terraform Package Solution", projectNumber)
 		}
 		plan.MigratedAt = types.StringValue("")
@@ -130,7 +130,7 @@ func (r *GCPTagKeyResource) Configure(_ context.Context, req resource.ConfigureR
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 }
 
@@ -130,7 +130,7 @@ func (r *GCPTagValueResource) Configure(_ context.Context, req resource.Configur
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 }
 
@@ -666,7 +666,7 @@ func (r *IAMCustomRole) Configure(ctx context.Context, req resource.ConfigureReq
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 	tflog.Debug(ctx, "[GCP Role Definition] resource configured successfully")
 }
@@ -255,7 +255,7 @@ func (r *ServiceAccountIntegration) Configure(ctx context.Context, req resource.
 	}
 
 	r.client = &api.CamClient{
-		Client: client.WithTimeout(cam.CAMAPITimeout),
+		Client: client.ForService(trendmicro.ServiceCloudAccountManagement),
 	}
 }
 
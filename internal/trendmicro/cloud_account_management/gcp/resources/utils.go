@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_account_management/gcp/resources/config"
 
@@ -261,7 +262,7 @@ func createProjectAndWaitConnected(
 	projectNumber string,
 	connectTimeout, pollInterval time.Duration,
 ) (*api.ProjectResponse, error) {
-	if createErr := client.CreateProject(body); createErr != nil && !strings.Contains(createErr.Error(), "account-exist") {
+	if createErr := client.CreateProject(body); createErr != nil && !trendmicro.HasErrorCode(createErr, "account-exist") {
 		return nil, createErr
 	}
 
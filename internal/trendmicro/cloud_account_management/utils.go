@@ -3,25 +3,20 @@ package cloud_account_management
 import (
 	"context"
 	"crypto/rand"
-	"fmt"
-	"math/big"
 	"sort"
-	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// Applies a randomized delay based on the given JitterConfig
-// to prevent high concurrency API throttling.
-func JitterSleep(cfg JitterConfig) {
-	jitterRange := cfg.MaxDelayMs - cfg.MinDelayMs
-	if jitterRange <= 0 {
-		jitterRange = 1
-	}
-	n, _ := big.NewInt(0).SetString(fmt.Sprintf("%d", jitterRange), 10)
-	jitter, _ := rand.Int(rand.Reader, n)
-	delay := time.Duration(cfg.MinDelayMs+int(jitter.Int64())) * time.Millisecond
-	time.Sleep(delay)
+// CloudAccountListOptions pages a cloud account list. A positive top is both the page size and the
+// maximum number of accounts returned, otherwise every page is read.
+func CloudAccountListOptions(top int64) trendmicro.ListOptions {
+	if top <= 0 {
+		return trendmicro.ListOptions{Top: DefaultCloudAccountPageSize}
+	}
+	return trendmicro.ListOptions{Top: int(top), Limit: int(top)}
 }
 
 // GenerateRandomString generates a random string of specified length
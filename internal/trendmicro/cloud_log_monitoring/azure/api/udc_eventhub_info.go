@@ -3,7 +3,6 @@ package api
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"io"
 	"net/http"
 	neturl "net/url"
@@ -88,13 +87,7 @@ func (c *ClmClient) do(req *http.Request, deviceToken string) error {
 		return nil
 	}
 
-	traceID := res.Header.Get("x-trace-id")
-	var out bytes.Buffer
-	if jsonErr := json.Indent(&out, body, "", "  "); jsonErr != nil {
-		return fmt.Errorf("unexpected status %d: %s \nTrace id: %s", res.StatusCode, body, traceID)
-	}
-
-	return fmt.Errorf("unexpected status %d: \n%s \nTrace id: %s", res.StatusCode, out.String(), traceID)
+	return trendmicro.NewAPIError(res.StatusCode, res.Header, body)
 }
 
 // UpsertUdcEventHubInfo registers the Event Hub stack, or overwrites the existing record's topology
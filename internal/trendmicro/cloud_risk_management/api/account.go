@@ -1,10 +1,13 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
+	"terraform-provider-vision-one/internal/trendmicro"
+
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 )
 
@@ -16,12 +19,9 @@ type CloudProviderFilter struct {
 	AlibabaAccountID    string
 }
 
-func (c *CrmClient) ListAccounts(cloudProviderFilter *CloudProviderFilter) (*cloud_risk_management_dto.ListAccountsResponse, error) {
+// ListAccounts returns every CRM account matching the filter, following nextLink across pages.
+func (c *CrmClient) ListAccounts(ctx context.Context, cloudProviderFilter *CloudProviderFilter) (*cloud_risk_management_dto.ListAccountsResponse, error) {
 	apiURL := fmt.Sprintf("%s/v3.0/cloudRiskManagement/accounts", c.Client.HostURL)
-	req, err := http.NewRequest("GET", apiURL, http.NoBody)
-	if err != nil {
-		return nil, err
-	}
 
 	// Build Filter
 	var filterStr string
@@ -39,20 +39,15 @@ func (c *CrmClient) ListAccounts(cloudProviderFilter *CloudProviderFilter) (*clo
 	default:
 		return nil, fmt.Errorf("exactly one cloud provider filter must be provided (AwsAccountID, AzureSubscriptionID, GcpProjectID, OciCompartmentID, or AlibabaAccountID)")
 	}
-	req.Header.Set("TMV1-Filter", filterStr)
-
-	resp, err := c.Client.DoRequest(req)
-	if err != nil {
-		return nil, err
-	}
 
-	accounts := cloud_risk_management_dto.ListAccountsResponse{}
-	err = json.Unmarshal(resp, &accounts)
+	items, err := trendmicro.ListAll[cloud_risk_management_dto.AccountResource](ctx, c.Client, apiURL, trendmicro.ListOptions{
+		Header: http.Header{"TMV1-Filter": {filterStr}},
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return &accounts, nil
+	return &cloud_risk_management_dto.ListAccountsResponse{Items: items}, nil
 }
 
 func (c *CrmClient) GetAccountById(accountID string) (*cloud_risk_management_dto.Account, error) {
@@ -2,11 +2,15 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 )
 
@@ -32,33 +36,23 @@ func (e *PartialFailureError) Error() string {
 }
 
 // GetAccountRuleSettings retrieves all rule settings for a CRM account, handling pagination.
-func (c *CrmClient) GetAccountRuleSettings(accountID string) ([]cloud_risk_management_dto.AccountRuleSetting, error) {
+func (c *CrmClient) GetAccountRuleSettings(ctx context.Context, accountID string) ([]cloud_risk_management_dto.AccountRuleSetting, error) {
 	var allRuleSettings []cloud_risk_management_dto.AccountRuleSetting
-	requestURL := fmt.Sprintf("%s%s?top=100", c.HostURL, fmt.Sprintf(accountRuleSettingsGetPath, accountID))
+	requestURL := fmt.Sprintf("%s%s", c.HostURL, fmt.Sprintf(accountRuleSettingsGetPath, accountID))
 
-	for requestURL != "" {
-		httpReq, err := http.NewRequest("GET", requestURL, http.NoBody)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create HTTP request: %w", err)
-		}
-		httpReq.Header.Set("TMV1-Filter", "isCustomized eq 'true'")
-
-		body, err := c.DoRequest(httpReq)
+	pages := trendmicro.Pages[cloud_risk_management_dto.AccountRuleSetting](ctx, c.Client, requestURL, trendmicro.ListOptions{
+		Top:    100,
+		Header: http.Header{"TMV1-Filter": {"isCustomized eq 'true'"}},
+	})
+	for page, err := range pages {
 		if err != nil {
 			// 404 means no customized rules found for this account — return empty slice.
-			if IsNotFoundError(err) {
+			if errors.Is(err, dto.ErrorNotFound) {
 				return allRuleSettings, nil
 			}
 			return nil, fmt.Errorf("failed to get account scan rule settings: %w", err)
 		}
-
-		var response cloud_risk_management_dto.AccountRuleSettingsResponse
-		if err := json.Unmarshal(body, &response); err != nil {
-			return nil, fmt.Errorf("failed to unmarshal account scan rule settings response: %w", err)
-		}
-
-		allRuleSettings = append(allRuleSettings, response.Items...)
-		requestURL = response.NextLink
+		allRuleSettings = append(allRuleSettings, page.Items...)
 	}
 
 	return allRuleSettings, nil
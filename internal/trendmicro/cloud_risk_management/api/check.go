@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+// CheckFilter selects checks. Values within a field are alternatives, fields must all match. Empty
+// fields do not filter.
+type CheckFilter struct {
+	AccountIDs []string
+	RuleIDs    []string
+	Services   []string
+	Regions    []string
+	RiskLevels []string
+	Statuses   []string
+	// Tags matches checks carrying any of the tags.
+	Tags []string
+	// ResourceID matches the resource of the check.
+	ResourceID *regexp.Regexp
+	Suppressed *bool
+	// Limit stops listing once this many checks matched. Zero lists every check.
+	Limit int
+}
+
+// tmv1Filter returns the TMV1-Filter expression for the fields the API filters on.
+func (f *CheckFilter) tmv1Filter() string {
+	var clauses []string
+	for _, field := range []struct {
+		name   string
+		values []string
+	}{
+		{"accountId", f.AccountIDs},
+		{"ruleId", f.RuleIDs},
+		{"service", f.Services},
+		{"region", f.Regions},
+		{"riskLevel", f.RiskLevels},
+		{"status", f.Statuses},
+	} {
+		if len(field.values) == 0 {
+			continue
+		}
+		alternatives := make([]string, len(field.values))
+		for i, value := range field.values {
+			alternatives[i] = fmt.Sprintf("%s eq '%s'", field.name, strings.ReplaceAll(value, "'", "''"))
+		}
+		if len(alternatives) == 1 {
+			clauses = append(clauses, alternatives[0])
+		} else {
+			clauses = append(clauses, "("+strings.Join(alternatives, " or ")+")")
+		}
+	}
+	if f.Suppressed != nil {
+		clauses = append(clauses, fmt.Sprintf("suppressed eq '%t'", *f.Suppressed))
+	}
+	return strings.Join(clauses, " and ")
+}
+
+// matches applies the fields the API cannot filter on.
+func (f *CheckFilter) matches(check *cloud_risk_management_dto.CheckResource) bool {
+	if f.ResourceID != nil && !f.ResourceID.MatchString(check.ResourceID) {
+		return false
+	}
+	if len(f.Tags) > 0 && !slices.ContainsFunc(check.Tags, func(tag string) bool { return slices.Contains(f.Tags, tag) }) {
+		return false
+	}
+	return true
+}
+
+// ListChecks returns the checks matching the filter, following nextLink across pages.
+func (c *CrmClient) ListChecks(ctx context.Context, filter *CheckFilter) ([]cloud_risk_management_dto.CheckResource, error) {
+	apiURL := fmt.Sprintf("%s/beta/cloudPosture/checks", c.Client.HostURL)
+
+	opts := trendmicro.ListOptions{Top: 100}
+	if expression := filter.tmv1Filter(); expression != "" {
+		opts.Header = http.Header{"TMV1-Filter": {expression}}
+	}
+
+	checks := []cloud_risk_management_dto.CheckResource{}
+	for page, err := range trendmicro.Pages[cloud_risk_management_dto.CheckResource](ctx, c.Client, apiURL, opts) {
+		if err != nil {
+			return nil, err
+		}
+		for i := range page.Items {
+			if !filter.matches(&page.Items[i]) {
+				continue
+			}
+			checks = append(checks, page.Items[i])
+			if filter.Limit > 0 && len(checks) >= filter.Limit {
+				return checks, nil
+			}
+		}
+	}
+
+	return checks, nil
+}
@@ -1,39 +1,27 @@
 package api
 
 import (
-	"net/http"
-	"strings"
-	"time"
-
 	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
 )
 
 type CrmClient struct {
 	*trendmicro.Client
 }
 
-// NewCrmClient creates a new CRM client
-func NewCrmClient(host, token, version string) *CrmClient {
+// NewCrmClient creates a new CRM client sharing the provider client's host, credentials, transport and retry policy
+func NewCrmClient(client *trendmicro.Client) *CrmClient {
+	crmClient := client.ForService(trendmicro.ServiceCloudRiskManagement)
+	crmClient.TMUserAgent = "TMCRMTerraform"
 	return &CrmClient{
-		Client: &trendmicro.Client{
-			HTTPClient:      &http.Client{Timeout: 60 * time.Second},
-			HostURL:         host,
-			BearerToken:     token,
-			TMUserAgent:     "TMCRMTerraform",
-			ProviderVersion: version,
-		},
+		Client: crmClient,
 	}
 }
 
-// IsNotFoundError checks if an error is a 404 NotFound error from the API
-// The CRM API returns 404 errors with a JSON structure containing "NotFound" code
-// This helper detects such errors and returns the dto.ErrorNotFound sentinel
-func IsNotFoundError(err error) bool {
-	if err == nil {
-		return false
+// WithCredentials returns a client for the tenant named by override, or c when it is unset.
+func (c *CrmClient) WithCredentials(override *dto.CredentialsOverrideModel) *CrmClient {
+	if override == nil {
+		return c
 	}
-	errMsg := err.Error()
-	// Check if the error message contains the NotFound code from the API response
-	return strings.Contains(errMsg, "\"code\": \"NotFound\"") ||
-		strings.Contains(errMsg, "\"code\":\"NotFound\"")
+	return &CrmClient{Client: c.Client.WithCredentialsOverride(override.ApiKey.ValueString(), override.RegFQDN.ValueString())}
 }
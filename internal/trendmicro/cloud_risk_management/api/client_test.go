@@ -0,0 +1,241 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"slices"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+func newTestCrmClient(t *testing.T) (*CrmClient, *mockserver.Server) {
+	server := mockserver.NewTestServer(t)
+	return NewCrmClient(&trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	}), server
+}
+
+func TestGroupLifecycle(t *testing.T) {
+	client, _ := newTestCrmClient(t)
+
+	created, err := client.CreateGroup(&cloud_risk_management_dto.CreateGroupRequest{Name: "finance", Tags: []string{"bu:finance"}})
+	if err != nil {
+		t.Fatalf("CreateGroup: %v", err)
+	}
+
+	if err := client.UpdateGroup(created.ID, &cloud_risk_management_dto.UpdateGroupRequest{Name: "finance-eu"}); err != nil {
+		t.Fatalf("UpdateGroup: %v", err)
+	}
+
+	group, err := client.GetGroup(created.ID)
+	if err != nil {
+		t.Fatalf("GetGroup: %v", err)
+	}
+	if group.Name != "finance-eu" || len(group.Tags) != 1 {
+		t.Errorf("unexpected group %+v", group)
+	}
+
+	if err := client.DeleteGroup(created.ID); err != nil {
+		t.Fatalf("DeleteGroup: %v", err)
+	}
+	if _, err := client.GetGroup(created.ID); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("expected NotFound after delete, got %v", err)
+	}
+}
+
+func TestCreateCommunicationConfigurationMultiStatus(t *testing.T) {
+	client, server := newTestCrmClient(t)
+
+	created, err := client.CreateCommunicationConfiguration(&cloud_risk_management_dto.CreateCommunicationConfigurationRequest{
+		Enabled:              true,
+		ChannelType:          "email",
+		ChannelConfiguration: map[string]any{"userIds": []string{"u1"}},
+	})
+	if err != nil {
+		t.Fatalf("CreateCommunicationConfiguration: %v", err)
+	}
+	if _, ok := server.Get(mockserver.CRMCommunicationConfigurationsPath, created.ID); !ok {
+		t.Errorf("configuration %q not stored", created.ID)
+	}
+}
+
+func TestAccountScanRules(t *testing.T) {
+	client, server := newTestCrmClient(t)
+	server.Seed(mockserver.CRMAccountsPath, mockserver.Object{"id": "acc-1", "awsAccountId": "123456789012"})
+
+	accounts, err := client.ListAccounts(context.Background(), &CloudProviderFilter{AwsAccountID: "123456789012"})
+	if err != nil {
+		t.Fatalf("ListAccounts: %v", err)
+	}
+	if len(accounts.Items) != 1 {
+		t.Fatalf("expected the seeded account, got %+v", accounts.Items)
+	}
+
+	rules := []cloud_risk_management_dto.AccountRuleSettingUpdate{
+		{ScanRule: cloud_risk_management_dto.ScanRule{ID: "S3-001", Provider: "aws", Enabled: false, RiskLevel: "LOW"}, Note: "test"},
+		{ScanRule: cloud_risk_management_dto.ScanRule{ID: "EC2-002", Provider: "aws", Enabled: true, RiskLevel: "HIGH"}, Note: "test"},
+	}
+	if err := client.UpdateAccountRuleSettings("acc-1", rules); err != nil {
+		t.Fatalf("UpdateAccountRuleSettings: %v", err)
+	}
+
+	got, err := client.GetAccountRuleSettings(context.Background(), "acc-1")
+	if err != nil {
+		t.Fatalf("GetAccountRuleSettings: %v", err)
+	}
+	if len(got) != 2 || !got[0].IsCustomized {
+		t.Fatalf("unexpected rule settings %+v", got)
+	}
+
+	if err := client.DeleteAccountRuleSettings("acc-1", []string{"S3-001", "missing"}); err != nil {
+		t.Fatalf("DeleteAccountRuleSettings should ignore rules that are not customized: %v", err)
+	}
+	got, err = client.GetAccountRuleSettings(context.Background(), "acc-1")
+	if err != nil {
+		t.Fatalf("GetAccountRuleSettings: %v", err)
+	}
+	if len(got) != 1 || got[0].ID != "EC2-002" {
+		t.Errorf("unexpected rule settings after reset %+v", got)
+	}
+
+	if got, err := client.GetAccountRuleSettings(context.Background(), "unknown"); err != nil || len(got) != 0 {
+		t.Errorf("unknown account should have no rule settings, got %+v, %v", got, err)
+	}
+}
+
+func TestAccountScanSetting(t *testing.T) {
+	client, server := newTestCrmClient(t)
+	server.Seed(mockserver.CRMAccountsPath, mockserver.Object{"id": "acc-1"})
+
+	if err := client.UpdateAccountScanSetting("acc-1", &cloud_risk_management_dto.AccountScanSetting{Enabled: true, Interval: 12}); err != nil {
+		t.Fatalf("UpdateAccountScanSetting: %v", err)
+	}
+	setting, err := client.GetAccountScanSetting("acc-1")
+	if err != nil {
+		t.Fatalf("GetAccountScanSetting: %v", err)
+	}
+	if !setting.Enabled || setting.Interval != 12 {
+		t.Errorf("unexpected scan setting %+v", setting)
+	}
+}
+
+func TestCheckSuppression(t *testing.T) {
+	client, server := newTestCrmClient(t)
+	checkID := "ccc:acc-1:S3-001:S3:global:bucket"
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": checkID, "accountId": "acc-1", "ruleId": "S3-001"})
+
+	if err := client.UpdateCheck(checkID, &cloud_risk_management_dto.UpdateCheckRequest{Suppressed: true, Note: "accepted risk"}); err != nil {
+		t.Fatalf("UpdateCheck: %v", err)
+	}
+	check, err := client.GetCheck(checkID)
+	if err != nil {
+		t.Fatalf("GetCheck: %v", err)
+	}
+	if !check.Suppressed || check.Note != "accepted risk" {
+		t.Errorf("unexpected check %+v", check)
+	}
+}
+
+func TestListChecks(t *testing.T) {
+	client, server := newTestCrmClient(t)
+	for _, check := range []mockserver.Object{
+		{"id": "c1", "accountId": "acc-1", "ruleId": "S3-001", "resource": "prod-logs", "status": "FAILURE", "riskLevel": "VERY_HIGH", "suppressed": false, "tags": []any{"env::prod"}},
+		{"id": "c2", "accountId": "acc-1", "ruleId": "S3-002", "resource": "prod-data", "status": "FAILURE", "riskLevel": "EXTREME", "suppressed": false},
+		{"id": "c3", "accountId": "acc-1", "ruleId": "EC2-001", "resource": "dev-box", "status": "FAILURE", "riskLevel": "VERY_HIGH", "suppressed": true, "tags": []any{"env::dev"}},
+		{"id": "c4", "accountId": "acc-1", "ruleId": "EC2-002", "resource": "prod-web", "status": "SUCCESS", "riskLevel": "VERY_HIGH", "suppressed": false},
+		{"id": "c5", "accountId": "acc-2", "ruleId": "S3-001", "resource": "prod-o'brien", "status": "FAILURE", "riskLevel": "VERY_HIGH", "suppressed": false},
+	} {
+		server.Seed(mockserver.CRMChecksPath, check)
+	}
+	suppressed := false
+
+	tests := []struct {
+		name   string
+		filter CheckFilter
+		want   []string
+	}{
+		{"all", CheckFilter{}, []string{"c1", "c2", "c3", "c4", "c5"}},
+		{"failed very high", CheckFilter{AccountIDs: []string{"acc-1"}, Statuses: []string{"FAILURE"}, RiskLevels: []string{"VERY_HIGH"}}, []string{"c1", "c3"}},
+		{"alternatives", CheckFilter{RiskLevels: []string{"VERY_HIGH", "EXTREME"}, Statuses: []string{"FAILURE"}, Suppressed: &suppressed}, []string{"c1", "c2", "c5"}},
+		{"resource regex", CheckFilter{ResourceID: regexp.MustCompile(`^prod-o'`)}, []string{"c5"}},
+		{"tags", CheckFilter{Tags: []string{"env::dev", "env::test"}}, []string{"c3"}},
+		{"limit", CheckFilter{AccountIDs: []string{"acc-1"}, Limit: 2}, []string{"c1", "c2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checks, err := client.ListChecks(context.Background(), &tt.filter)
+			if err != nil {
+				t.Fatalf("ListChecks: %v", err)
+			}
+			var ids []string
+			for _, check := range checks {
+				ids = append(ids, check.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("got %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	quoted := CheckFilter{AccountIDs: []string{"acc-1", "o'neil"}, Suppressed: &suppressed}
+	if got, want := quoted.tmv1Filter(), "(accountId eq 'acc-1' or accountId eq 'o''neil') and suppressed eq 'false'"; got != want {
+		t.Errorf("tmv1Filter() = %q, want %q", got, want)
+	}
+}
+
+func TestListRules(t *testing.T) {
+	client, server := newTestCrmClient(t)
+	cis := mockserver.Object{"id": "CISAWSF-3_0"}
+	for _, rule := range []mockserver.Object{
+		{"id": "S3-001", "provider": "aws", "service": "S3", "riskLevel": "HIGH", "categories": []any{"security"}, "complianceStandards": []any{cis}},
+		{"id": "S3-002", "provider": "aws", "service": "S3", "riskLevel": "LOW", "categories": []any{"security"}, "complianceStandards": []any{cis}},
+		{"id": "EC2-001", "provider": "aws", "service": "EC2", "riskLevel": "VERY_HIGH", "categories": []any{"cost-optimisation"}, "complianceStandards": []any{mockserver.Object{"id": "NIST4"}}},
+		{"id": "EC2-002", "provider": "aws", "service": "EC2", "riskLevel": "EXTREME", "complianceStandards": []any{cis}, "deprecated": true},
+		{"id": "StorageAccounts-001", "provider": "azure", "service": "StorageAccounts", "riskLevel": "HIGH", "complianceStandards": []any{cis}},
+	} {
+		server.Seed(mockserver.CRMRulesPath, rule)
+	}
+
+	tests := []struct {
+		name   string
+		filter RuleFilter
+		want   []string
+	}{
+		{"all", RuleFilter{}, []string{"S3-001", "S3-002", "EC2-001", "StorageAccounts-001"}},
+		{"deprecated", RuleFilter{Services: []string{"EC2"}, IncludeDeprecated: true}, []string{"EC2-001", "EC2-002"}},
+		{"standard and risk levels", RuleFilter{Providers: []string{"aws"}, ComplianceStandardIDs: []string{"CISAWSF-3_0"}, RiskLevels: []string{"HIGH", "VERY_HIGH", "EXTREME"}}, []string{"S3-001"}},
+		{"category", RuleFilter{Categories: []string{"cost-optimisation"}}, []string{"EC2-001"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := client.ListRules(context.Background(), &tt.filter)
+			if err != nil {
+				t.Fatalf("ListRules: %v", err)
+			}
+			var ids []string
+			for _, rule := range rules {
+				ids = append(ids, rule.ID)
+			}
+			if !slices.Equal(ids, tt.want) {
+				t.Errorf("got %v, want %v", ids, tt.want)
+			}
+		})
+	}
+
+	server.Seed(mockserver.CRMComplianceStandardsPath, mockserver.Object{"id": "NIST4", "name": "NIST 800-53 Rev 4", "providers": []any{"aws", "azure"}})
+	standards, err := client.ListComplianceStandards(context.Background())
+	if err != nil {
+		t.Fatalf("ListComplianceStandards: %v", err)
+	}
+	if len(standards) != 1 || standards[0].ID != "NIST4" || len(standards[0].Providers) != 2 {
+		t.Errorf("unexpected compliance standards %+v", standards)
+	}
+}
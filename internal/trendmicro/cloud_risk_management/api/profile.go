@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"path"
 
+	"terraform-provider-vision-one/internal/trendmicro"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 )
 
@@ -68,30 +69,33 @@ func (c *CrmClient) CreateProfile(req cloud_risk_management_dto.CreateProfileReq
 	return profile, nil
 }
 
-// GetProfile retrieves a profile by ID
+// GetProfile retrieves a profile by ID together with its ETag
 func (c *CrmClient) GetProfile(profileID string) (*cloud_risk_management_dto.Profile, error) {
 	httpReq, err := http.NewRequest("GET", fmt.Sprintf("%s%s/%s", c.HostURL, profilesPath, profileID), http.NoBody)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
 	}
 
-	body, err := c.DoRequest(httpReq)
+	resp, err := c.DoRequestWithFullResponse(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get profile: %w", err)
 	}
+	defer resp.Body.Close()
 
 	var profile cloud_risk_management_dto.Profile
-	if err := json.Unmarshal(body, &profile); err != nil {
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal profile response: %w", err)
 	}
 
 	profile.ID = profileID
+	profile.ETag = resp.Header.Get("ETag")
 
 	return &profile, nil
 }
 
-// UpdateProfile updates an existing profile
-func (c *CrmClient) UpdateProfile(profileID string, req cloud_risk_management_dto.UpdateProfileRequest) error {
+// UpdateProfile updates an existing profile. A non-empty etag makes the update fail with
+// dto.ErrorPreconditionFailed when the profile changed since it was read.
+func (c *CrmClient) UpdateProfile(profileID, etag string, req cloud_risk_management_dto.UpdateProfileRequest) error {
 	body, err := json.Marshal(req)
 	if err != nil {
 		return fmt.Errorf("failed to marshal update profile request: %w", err)
@@ -103,6 +107,7 @@ func (c *CrmClient) UpdateProfile(profileID string, req cloud_risk_management_dt
 	}
 	httpReq.Header.Set("TMV1-Patch-Array-Mode", "update")
 	httpReq.Header.Set("Content-Type", "application/json")
+	trendmicro.SetIfMatch(httpReq, etag)
 
 	_, err = c.DoRequest(httpReq)
 	if err != nil {
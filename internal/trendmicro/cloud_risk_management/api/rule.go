@@ -0,0 +1,69 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+// RuleFilter selects rules of the catalog. Values within a field are alternatives, fields must all match.
+// Empty fields do not filter.
+type RuleFilter struct {
+	Providers  []string
+	Services   []string
+	RiskLevels []string
+	// Categories matches rules in any of the categories.
+	Categories []string
+	// ComplianceStandardIDs matches rules mapped to any of the standards.
+	ComplianceStandardIDs []string
+	IncludeDeprecated     bool
+}
+
+func (f *RuleFilter) matches(rule *cloud_risk_management_dto.Rule) bool {
+	matchesAny := func(values []string, value string) bool {
+		return len(values) == 0 || slices.Contains(values, value)
+	}
+	if !matchesAny(f.Providers, rule.Provider) || !matchesAny(f.Services, rule.Service) || !matchesAny(f.RiskLevels, rule.RiskLevel) {
+		return false
+	}
+	if rule.Deprecated && !f.IncludeDeprecated {
+		return false
+	}
+	if len(f.Categories) > 0 && !slices.ContainsFunc(rule.Categories, func(category string) bool { return slices.Contains(f.Categories, category) }) {
+		return false
+	}
+	if len(f.ComplianceStandardIDs) > 0 && !slices.ContainsFunc(rule.ComplianceStandards, func(standard cloud_risk_management_dto.ComplianceStandard) bool {
+		return slices.Contains(f.ComplianceStandardIDs, standard.ID)
+	}) {
+		return false
+	}
+	return true
+}
+
+// ListRules returns the rules of the catalog matching the filter.
+func (c *CrmClient) ListRules(ctx context.Context, filter *RuleFilter) ([]cloud_risk_management_dto.Rule, error) {
+	apiURL := fmt.Sprintf("%s/beta/cloudPosture/rules", c.Client.HostURL)
+
+	all, err := trendmicro.ListAll[cloud_risk_management_dto.Rule](ctx, c.Client, apiURL, trendmicro.ListOptions{Top: 200})
+	if err != nil {
+		return nil, err
+	}
+
+	rules := []cloud_risk_management_dto.Rule{}
+	for i := range all {
+		if filter.matches(&all[i]) {
+			rules = append(rules, all[i])
+		}
+	}
+	return rules, nil
+}
+
+// ListComplianceStandards returns the compliance standards rules can be mapped to.
+func (c *CrmClient) ListComplianceStandards(ctx context.Context) ([]cloud_risk_management_dto.ComplianceStandardDetail, error) {
+	apiURL := fmt.Sprintf("%s/beta/cloudPosture/complianceStandards", c.Client.HostURL)
+	return trendmicro.ListAll[cloud_risk_management_dto.ComplianceStandardDetail](ctx, c.Client, apiURL, trendmicro.ListOptions{})
+}
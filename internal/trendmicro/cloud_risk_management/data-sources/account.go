@@ -58,7 +58,7 @@ func (d *CRMAccountDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	d.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	d.client = api.NewCrmClient(client)
 }
 
 func (d *CRMAccountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
@@ -140,7 +140,7 @@ func (d *CRMAccountDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	baseDelay := 2 * time.Second
 
 	for attempt := 0; attempt <= maxRetries; attempt++ {
-		response, err = d.client.ListAccounts(&filter)
+		response, err = d.client.ListAccounts(ctx, &filter)
 		if err != nil {
 			tflog.Error(ctx, "Failed to list CRM accounts", map[string]any{
 				"error": err.Error(),
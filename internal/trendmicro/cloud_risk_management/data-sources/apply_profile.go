@@ -99,7 +99,7 @@ func (d *ApplyProfileDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	d.client = &api.CrmClient{Client: client}
+	d.client = api.NewCrmClient(client)
 }
 
 func (d *ApplyProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
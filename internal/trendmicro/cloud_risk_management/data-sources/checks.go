@@ -0,0 +1,228 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ChecksDataSource{}
+	_ datasource.DataSourceWithConfigure = &ChecksDataSource{}
+)
+
+var (
+	riskLevels    = []string{"LOW", "MEDIUM", "HIGH", "VERY_HIGH", "EXTREME"}
+	checkStatuses = []string{"SUCCESS", "FAILURE"}
+)
+
+func NewChecksDataSource() datasource.DataSource {
+	return &ChecksDataSource{}
+}
+
+// ChecksDataSource lists the checks of Cloud Risk Management accounts.
+type ChecksDataSource struct {
+	client *api.CrmClient
+}
+
+type ChecksDataSourceModel struct {
+	AccountIDs      []types.String `tfsdk:"account_ids"`
+	RuleIDs         []types.String `tfsdk:"rule_ids"`
+	Services        []types.String `tfsdk:"services"`
+	Regions         []types.String `tfsdk:"regions"`
+	RiskLevels      []types.String `tfsdk:"risk_levels"`
+	Statuses        []types.String `tfsdk:"statuses"`
+	Tags            []types.String `tfsdk:"tags"`
+	ResourceIDRegex types.String   `tfsdk:"resource_id_regex"`
+	Suppressed      types.Bool     `tfsdk:"suppressed"`
+	MaxResults      types.Int64    `tfsdk:"max_results"`
+	IDs             []types.String `tfsdk:"ids"`
+	Checks          []CheckModel   `tfsdk:"checks"`
+}
+
+type CheckModel struct {
+	ID                   types.String `tfsdk:"id"`
+	AccountID            types.String `tfsdk:"account_id"`
+	RuleID               types.String `tfsdk:"rule_id"`
+	Service              types.String `tfsdk:"service"`
+	Region               types.String `tfsdk:"region"`
+	ResourceID           types.String `tfsdk:"resource_id"`
+	Status               types.String `tfsdk:"status"`
+	RiskLevel            types.String `tfsdk:"risk_level"`
+	Suppressed           types.Bool   `tfsdk:"suppressed"`
+	LastModifiedDateTime types.String `tfsdk:"last_modified_date_time"`
+}
+
+func (d *ChecksDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crm_checks"
+}
+
+func (d *ChecksDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Provider Data",
+			"Expected *trendmicro.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = api.NewCrmClient(client)
+}
+
+func (d *ChecksDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	stringList := func(description string, validators ...validator.String) schema.ListAttribute {
+		return schema.ListAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators:          []validator.List{listvalidator.ValueStringsAre(validators...)},
+		}
+	}
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the Cloud Risk Management checks matching the filters, for example the failed checks of an account. " +
+			"Values within one filter are alternatives; a check has to match every filter that is set.",
+		Attributes: map[string]schema.Attribute{
+			"account_ids": stringList("Only return checks of these Cloud Risk Management account IDs."),
+			"rule_ids":    stringList("Only return checks of these rules, for example `S3-021`."),
+			"services":    stringList("Only return checks of these cloud services, for example `S3`."),
+			"regions":     stringList("Only return checks in these regions, for example `us-east-1` or `global`."),
+			"risk_levels": stringList(
+				fmt.Sprintf("Only return checks with these risk levels. Accepted values: `%s`.", strings.Join(riskLevels, "`, `")),
+				stringvalidator.OneOf(riskLevels...),
+			),
+			"statuses": stringList(
+				fmt.Sprintf("Only return checks with these statuses. Accepted values: `%s`.", strings.Join(checkStatuses, "`, `")),
+				stringvalidator.OneOf(checkStatuses...),
+			),
+			"tags": stringList("Only return checks of resources carrying any of these tags, for example `environment::production`."),
+			"resource_id_regex": schema.StringAttribute{
+				MarkdownDescription: "Only return checks whose resource matches this [RE2 regular expression](https://github.com/google/re2/wiki/Syntax).",
+				Optional:            true,
+			},
+			"suppressed": schema.BoolAttribute{
+				MarkdownDescription: "Only return suppressed checks when `true`, or only checks that are not suppressed when `false`.",
+				Optional:            true,
+			},
+			"max_results": schema.Int64Attribute{
+				MarkdownDescription: "Stop once this many checks matched. By default every page of checks is read.",
+				Optional:            true,
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching checks.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"checks": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching checks.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                      computedString("The check ID, which a `visionone_crm_check_suppression` of this check can be imported with."),
+						"account_id":              computedString("The Cloud Risk Management account ID."),
+						"rule_id":                 computedString("The ID of the rule that produced the check."),
+						"service":                 computedString("The cloud service of the resource."),
+						"region":                  computedString("The region of the resource."),
+						"resource_id":             computedString("The resource the check evaluated."),
+						"status":                  computedString("The result of the check, `SUCCESS` or `FAILURE`."),
+						"risk_level":              computedString("The risk level of the check."),
+						"suppressed":              schema.BoolAttribute{MarkdownDescription: "Whether the check is suppressed.", Computed: true},
+						"last_modified_date_time": computedString("The time the check last changed."),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ChecksDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ChecksDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := api.CheckFilter{
+		AccountIDs: stringValues(data.AccountIDs),
+		RuleIDs:    stringValues(data.RuleIDs),
+		Services:   stringValues(data.Services),
+		Regions:    stringValues(data.Regions),
+		RiskLevels: stringValues(data.RiskLevels),
+		Statuses:   stringValues(data.Statuses),
+		Tags:       stringValues(data.Tags),
+		Suppressed: data.Suppressed.ValueBoolPointer(),
+		Limit:      int(data.MaxResults.ValueInt64()),
+	}
+	if !data.ResourceIDRegex.IsNull() {
+		re, err := regexp.Compile(data.ResourceIDRegex.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("resource_id_regex"), "Invalid Regular Expression", err.Error())
+			return
+		}
+		filter.ResourceID = re
+	}
+
+	checks, err := d.client.ListChecks(ctx, &filter)
+	if err != nil {
+		tflog.Error(ctx, "Failed to list CRM checks", map[string]any{
+			"error": err.Error(),
+		})
+		resp.Diagnostics.AddError(
+			"Error Reading CRM Checks",
+			fmt.Sprintf("Unable to list Cloud Risk Management checks: %s", err),
+		)
+		return
+	}
+
+	data.IDs = make([]types.String, 0, len(checks))
+	data.Checks = make([]CheckModel, 0, len(checks))
+	for _, check := range checks {
+		data.IDs = append(data.IDs, types.StringValue(check.ID))
+		data.Checks = append(data.Checks, CheckModel{
+			ID:                   types.StringValue(check.ID),
+			AccountID:            types.StringValue(check.AccountID),
+			RuleID:               types.StringValue(check.RuleID),
+			Service:              types.StringValue(check.Service),
+			Region:               types.StringValue(check.Region),
+			ResourceID:           types.StringValue(check.ResourceID),
+			Status:               types.StringValue(check.Status),
+			RiskLevel:            types.StringValue(check.RiskLevel),
+			Suppressed:           types.BoolValue(check.Suppressed),
+			LastModifiedDateTime: types.StringValue(check.LastModifiedDateTime),
+		})
+	}
+
+	tflog.Debug(ctx, "Listed CRM checks", map[string]any{"count": len(checks)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func stringValues(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+	return result
+}
@@ -0,0 +1,133 @@
+package datasources
+
+import (
+	"context"
+	"fmt"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ComplianceStandardsDataSource{}
+	_ datasource.DataSourceWithConfigure = &ComplianceStandardsDataSource{}
+)
+
+func NewComplianceStandardsDataSource() datasource.DataSource {
+	return &ComplianceStandardsDataSource{}
+}
+
+// ComplianceStandardsDataSource lists the compliance standards Cloud Risk Management maps rules to.
+type ComplianceStandardsDataSource struct {
+	client *api.CrmClient
+}
+
+type ComplianceStandardsDataSourceModel struct {
+	IDs                 []types.String            `tfsdk:"ids"`
+	ComplianceStandards []ComplianceStandardModel `tfsdk:"compliance_standards"`
+}
+
+type ComplianceStandardModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Providers   []types.String `tfsdk:"providers"`
+}
+
+func (d *ComplianceStandardsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crm_compliance_standards"
+}
+
+func (d *ComplianceStandardsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Provider Data",
+			"Expected *trendmicro.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = api.NewCrmClient(client)
+}
+
+func (d *ComplianceStandardsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the compliance standards Cloud Risk Management maps rules to, for example to set the `applied_compliance_standard_id` of a `visionone_crm_report_config`.",
+		Attributes: map[string]schema.Attribute{
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the compliance standards.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"compliance_standards": schema.ListNestedAttribute{
+				MarkdownDescription: "The compliance standards.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The compliance standard ID, for example `NIST4` or `AWAF-2025`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the compliance standard.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "The description of the compliance standard.",
+							Computed:            true,
+						},
+						"providers": schema.ListAttribute{
+							MarkdownDescription: "The cloud providers the compliance standard has rules for.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ComplianceStandardsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ComplianceStandardsDataSourceModel
+
+	standards, err := d.client.ListComplianceStandards(ctx)
+	if err != nil {
+		tflog.Error(ctx, "Failed to list CRM compliance standards", map[string]any{
+			"error": err.Error(),
+		})
+		resp.Diagnostics.AddError(
+			"Error Reading CRM Compliance Standards",
+			fmt.Sprintf("Unable to list the Cloud Risk Management compliance standards: %s", err),
+		)
+		return
+	}
+
+	data.IDs = make([]types.String, 0, len(standards))
+	data.ComplianceStandards = make([]ComplianceStandardModel, 0, len(standards))
+	for _, standard := range standards {
+		model := ComplianceStandardModel{
+			ID:          types.StringValue(standard.ID),
+			Name:        types.StringValue(standard.Name),
+			Description: types.StringValue(standard.Description),
+			Providers:   make([]types.String, 0, len(standard.Providers)),
+		}
+		for _, provider := range standard.Providers {
+			model.Providers = append(model.Providers, types.StringValue(provider))
+		}
+		data.IDs = append(data.IDs, types.StringValue(standard.ID))
+		data.ComplianceStandards = append(data.ComplianceStandards, model)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,236 @@
+package datasources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &RulesDataSource{}
+	_ datasource.DataSourceWithConfigure = &RulesDataSource{}
+)
+
+func NewRulesDataSource() datasource.DataSource {
+	return &RulesDataSource{}
+}
+
+// RulesDataSource lists the rules of the Cloud Risk Management catalog.
+type RulesDataSource struct {
+	client *api.CrmClient
+}
+
+type RulesDataSourceModel struct {
+	Providers             []types.String `tfsdk:"providers"`
+	Services              []types.String `tfsdk:"services"`
+	RiskLevels            []types.String `tfsdk:"risk_levels"`
+	Categories            []types.String `tfsdk:"categories"`
+	ComplianceStandardIDs []types.String `tfsdk:"compliance_standard_ids"`
+	IncludeDeprecated     types.Bool     `tfsdk:"include_deprecated"`
+	IDs                   []types.String `tfsdk:"ids"`
+	Rules                 []RuleModel    `tfsdk:"rules"`
+}
+
+type RuleModel struct {
+	ID                    types.String            `tfsdk:"id"`
+	Title                 types.String            `tfsdk:"title"`
+	Description           types.String            `tfsdk:"description"`
+	Provider              types.String            `tfsdk:"provider"`
+	Service               types.String            `tfsdk:"service"`
+	RiskLevel             types.String            `tfsdk:"risk_level"`
+	Categories            []types.String          `tfsdk:"categories"`
+	ComplianceStandardIDs []types.String          `tfsdk:"compliance_standard_ids"`
+	ExtraSettings         []RuleExtraSettingModel `tfsdk:"extra_settings"`
+	Deprecated            types.Bool              `tfsdk:"deprecated"`
+}
+
+type RuleExtraSettingModel struct {
+	Name         types.String `tfsdk:"name"`
+	Type         types.String `tfsdk:"type"`
+	DefaultValue types.String `tfsdk:"default_value"`
+}
+
+func (d *RulesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crm_rules"
+}
+
+func (d *RulesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid Provider Data",
+			"Expected *trendmicro.Client, got something else.",
+		)
+		return
+	}
+
+	d.client = api.NewCrmClient(client)
+}
+
+func (d *RulesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	stringList := func(description string, validators ...validator.String) schema.ListAttribute {
+		return schema.ListAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Optional:            true,
+			Validators:          []validator.List{listvalidator.ValueStringsAre(validators...)},
+		}
+	}
+	computedString := func(description string) schema.StringAttribute {
+		return schema.StringAttribute{MarkdownDescription: description, Computed: true}
+	}
+	computedList := func(description string) schema.ListAttribute {
+		return schema.ListAttribute{MarkdownDescription: description, ElementType: types.StringType, Computed: true}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists the rules of the Cloud Risk Management catalog, for example to configure the rules of a `visionone_crm_profile`. " +
+			"Values within one filter are alternatives; a rule has to match every filter that is set.",
+		Attributes: map[string]schema.Attribute{
+			"providers": stringList("Only return rules of these cloud providers, for example `aws`."),
+			"services":  stringList("Only return rules of these cloud services, for example `S3`."),
+			"risk_levels": stringList(
+				fmt.Sprintf("Only return rules with these risk levels. Accepted values: `%s`.", strings.Join(riskLevels, "`, `")),
+				stringvalidator.OneOf(riskLevels...),
+			),
+			"categories":              stringList("Only return rules in any of these categories, for example `security`."),
+			"compliance_standard_ids": stringList("Only return rules mapped to any of these compliance standards, for example `NIST4`. See the `visionone_crm_compliance_standards` data source."),
+			"include_deprecated": schema.BoolAttribute{
+				MarkdownDescription: "Also return deprecated rules. Defaults to `false`.",
+				Optional:            true,
+			},
+			"ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the matching rules.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"rules": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching rules.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id":                      computedString("The rule ID, for example `S3-001`."),
+						"title":                   computedString("The title of the rule."),
+						"description":             computedString("The description of the rule."),
+						"provider":                computedString("The cloud provider of the rule."),
+						"service":                 computedString("The cloud service of the rule."),
+						"risk_level":              computedString("The default risk level of the rule."),
+						"categories":              computedList("The categories of the rule."),
+						"compliance_standard_ids": computedList("The compliance standards the rule is mapped to."),
+						"deprecated":              schema.BoolAttribute{MarkdownDescription: "Whether the rule is deprecated.", Computed: true},
+						"extra_settings": schema.ListNestedAttribute{
+							MarkdownDescription: "The settings of the rule that can be configured in `extra_settings` blocks.",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name":          computedString("The name of the setting."),
+									"type":          computedString("The type of the setting, for example `multiple-string-values`."),
+									"default_value": computedString("The default `value` or `values` of the setting, JSON encoded. Decode it with `jsondecode`."),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *RulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data RulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.ListRules(ctx, &api.RuleFilter{
+		Providers:             stringValues(data.Providers),
+		Services:              stringValues(data.Services),
+		RiskLevels:            stringValues(data.RiskLevels),
+		Categories:            stringValues(data.Categories),
+		ComplianceStandardIDs: stringValues(data.ComplianceStandardIDs),
+		IncludeDeprecated:     data.IncludeDeprecated.ValueBool(),
+	})
+	if err != nil {
+		tflog.Error(ctx, "Failed to list CRM rules", map[string]any{
+			"error": err.Error(),
+		})
+		resp.Diagnostics.AddError(
+			"Error Reading CRM Rules",
+			fmt.Sprintf("Unable to list the Cloud Risk Management rules: %s", err),
+		)
+		return
+	}
+
+	data.IDs = make([]types.String, 0, len(rules))
+	data.Rules = make([]RuleModel, 0, len(rules))
+	for _, rule := range rules {
+		model, err := ruleToModel(&rule)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading CRM Rules",
+				fmt.Sprintf("Unable to read the settings of rule %s: %s", rule.ID, err),
+			)
+			return
+		}
+		data.IDs = append(data.IDs, types.StringValue(rule.ID))
+		data.Rules = append(data.Rules, model)
+	}
+
+	tflog.Debug(ctx, "Listed CRM rules", map[string]any{"count": len(rules)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func ruleToModel(rule *cloud_risk_management_dto.Rule) (RuleModel, error) {
+	model := RuleModel{
+		ID:                    types.StringValue(rule.ID),
+		Title:                 types.StringValue(rule.Title),
+		Description:           types.StringValue(rule.Description),
+		Provider:              types.StringValue(rule.Provider),
+		Service:               types.StringValue(rule.Service),
+		RiskLevel:             types.StringValue(rule.RiskLevel),
+		Categories:            make([]types.String, 0, len(rule.Categories)),
+		ComplianceStandardIDs: make([]types.String, 0, len(rule.ComplianceStandards)),
+		ExtraSettings:         make([]RuleExtraSettingModel, 0, len(rule.ExtraSettings)),
+		Deprecated:            types.BoolValue(rule.Deprecated),
+	}
+	for _, category := range rule.Categories {
+		model.Categories = append(model.Categories, types.StringValue(category))
+	}
+	for _, standard := range rule.ComplianceStandards {
+		model.ComplianceStandardIDs = append(model.ComplianceStandardIDs, types.StringValue(standard.ID))
+	}
+	for _, setting := range rule.ExtraSettings {
+		var defaultValue any = setting.Value
+		if setting.Values != nil {
+			defaultValue = *setting.Values
+		}
+		encoded, err := json.Marshal(defaultValue)
+		if err != nil {
+			return model, err
+		}
+		model.ExtraSettings = append(model.ExtraSettings, RuleExtraSettingModel{
+			Name:         types.StringValue(setting.Name),
+			Type:         types.StringValue(setting.Type),
+			DefaultValue: types.StringValue(string(encoded)),
+		})
+	}
+	return model, nil
+}
@@ -0,0 +1,224 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &CustomRuleEvaluateFunction{}
+
+func NewCustomRuleEvaluateFunction() function.Function {
+	return &CustomRuleEvaluateFunction{
+		now: time.Now,
+	}
+}
+
+// CustomRuleEvaluateFunction evaluates a custom rule against a sample resource without deploying it.
+type CustomRuleEvaluateFunction struct {
+	now func() time.Time
+}
+
+// customRule is a rule in either the API format, with attributes and eventRules, or the format of the
+// crm_custom_rule resource, with attribute and event_rule blocks.
+type customRule struct {
+	Attributes []cloud_risk_management_dto.ResourceAttribute `json:"attributes"`
+	EventRules []cloud_risk_management_dto.EventRule         `json:"eventRules"`
+
+	Attribute []cloud_risk_management_dto.ResourceAttribute `json:"attribute"`
+	EventRule []struct {
+		Description string `json:"description"`
+		Conditions  *struct {
+			Operator  string `json:"operator"`
+			Condition []struct {
+				Operator string  `json:"operator"`
+				Value    *string `json:"value"`
+				Path     string  `json:"path"`
+				Fact     string  `json:"fact"`
+			} `json:"condition"`
+		} `json:"conditions"`
+	} `json:"event_rule"`
+}
+
+func (f *CustomRuleEvaluateFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "crm_custom_rule_evaluate"
+}
+
+func (f *CustomRuleEvaluateFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Evaluates a Cloud Risk Management custom rule against a resource.",
+		MarkdownDescription: "Evaluates a Cloud Risk Management custom rule against a sample resource and returns the status a scan would produce, " +
+			"`SUCCESS` when every event rule's conditions hold and `FAILURE` otherwise. Use it to unit test rules before deploying them.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "rule",
+				MarkdownDescription: "The custom rule: a `visionone_crm_custom_rule` resource, an object with the same `attribute` and `event_rule` values, " +
+					"or a JSON string of the rule in the API format with `attributes` and `eventRules`.",
+			},
+			function.StringParameter{
+				Name:                "resource_json",
+				MarkdownDescription: "The resource as JSON, in the format Cloud Risk Management stores it. Attribute paths are resolved from its root, for example `data.BucketVersioning`.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *CustomRuleEvaluateFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var rule types.Dynamic
+	var resourceJSON string
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &rule, &resourceJSON))
+	if resp.Error != nil {
+		return
+	}
+
+	attributes, eventRules, err := decodeCustomRule(ctx, rule)
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	var resourceData any
+	if err := json.Unmarshal([]byte(resourceJSON), &resourceData); err != nil {
+		resp.Error = function.NewArgumentFuncError(1, "The resource is not valid JSON: "+err.Error())
+		return
+	}
+
+	result, err := utils.EvaluateCustomRule(attributes, eventRules, resourceData, f.now())
+	if err != nil {
+		resp.Error = function.NewFuncError("Could not evaluate the custom rule: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, result.Status))
+}
+
+func decodeCustomRule(ctx context.Context, rule types.Dynamic) ([]cloud_risk_management_dto.ResourceAttribute, []cloud_risk_management_dto.EventRule, error) {
+	var raw []byte
+	if s, ok := rule.UnderlyingValue().(types.String); ok {
+		raw = []byte(s.ValueString())
+	} else {
+		value, err := rule.UnderlyingValue().ToTerraformValue(ctx)
+		if err != nil {
+			return nil, nil, err
+		}
+		var fields map[string]tftypes.Value
+		if err := value.As(&fields); err != nil {
+			return nil, nil, fmt.Errorf("the rule must be an object or a JSON string: %w", err)
+		}
+		// Only convert the rule fields, the other attributes of a resource like its ID may not be known yet
+		native := map[string]any{}
+		for _, key := range []string{"attributes", "eventRules", "attribute", "event_rule"} {
+			field, ok := fields[key]
+			if !ok {
+				continue
+			}
+			if native[key], err = terraformValueToNative(field); err != nil {
+				return nil, nil, fmt.Errorf("%s: %w", key, err)
+			}
+		}
+		if raw, err = json.Marshal(native); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var decoded customRule
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, nil, fmt.Errorf("the rule is not a custom rule: %w", err)
+	}
+	if decoded.Attribute == nil && decoded.EventRule == nil {
+		return decoded.Attributes, decoded.EventRules, nil
+	}
+
+	eventRules := make([]cloud_risk_management_dto.EventRule, len(decoded.EventRule))
+	for i, eventRule := range decoded.EventRule {
+		eventRules[i].Description = eventRule.Description
+		if eventRule.Conditions == nil {
+			continue
+		}
+		conditions := make([]cloud_risk_management_dto.Condition, len(eventRule.Conditions.Condition))
+		for j, condition := range eventRule.Conditions.Condition {
+			conditions[j] = cloud_risk_management_dto.Condition{
+				Operator: condition.Operator,
+				Path:     condition.Path,
+				Fact:     condition.Fact,
+			}
+			if condition.Value != nil {
+				conditions[j].Value = utils.DecodeConditionValue(*condition.Value)
+			}
+		}
+		// Like the resource, conditions without an operator must all hold
+		if eventRule.Conditions.Operator == "any" {
+			eventRules[i].Conditions = &cloud_risk_management_dto.Conditions{Any: conditions}
+		} else {
+			eventRules[i].Conditions = &cloud_risk_management_dto.Conditions{All: conditions}
+		}
+	}
+	return decoded.Attribute, eventRules, nil
+}
+
+// terraformValueToNative converts a Terraform value to the Go values encoding/json produces.
+func terraformValueToNative(value tftypes.Value) (any, error) {
+	if !value.IsKnown() {
+		return nil, fmt.Errorf("the rule must be known, it is evaluated once the values it uses are known")
+	}
+	if value.IsNull() {
+		return nil, nil
+	}
+
+	switch typ := value.Type(); {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		var n big.Float
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		f, _ := n.Float64()
+		return f, nil
+	case typ.Is(tftypes.List{}), typ.Is(tftypes.Set{}), typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]any, len(elements))
+		for i, element := range elements {
+			native, err := terraformValueToNative(element)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = native
+		}
+		return result, nil
+	case typ.Is(tftypes.Map{}), typ.Is(tftypes.Object{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make(map[string]any, len(elements))
+		for key, element := range elements {
+			native, err := terraformValueToNative(element)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = native
+		}
+		return result, nil
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", value.Type())
+}
@@ -0,0 +1,88 @@
+package functions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const testBucket = `{"data": {"BucketVersioning": {"Status": "Enabled"}}}`
+
+func runEvaluate(t *testing.T, rule attr.Value, resourceJSON string) (string, *function.FuncError) {
+	t.Helper()
+	f := &CustomRuleEvaluateFunction{now: time.Now}
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(rule), types.StringValue(resourceJSON)}),
+	}, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestCustomRuleEvaluateResourceObject(t *testing.T) {
+	conditionType := map[string]attr.Type{"operator": types.StringType, "value": types.StringType, "path": types.StringType, "fact": types.StringType}
+	condition := types.ObjectValueMust(conditionType, map[string]attr.Value{
+		"operator": types.StringValue("equal"),
+		"value":    types.StringValue(`"Enabled"`),
+		"path":     types.StringValue("$.Status"),
+		"fact":     types.StringValue("versioning"),
+	})
+	conditionsType := map[string]attr.Type{"operator": types.StringType, "condition": types.ListType{ElemType: types.ObjectType{AttrTypes: conditionType}}}
+	eventRuleType := map[string]attr.Type{"description": types.StringType, "conditions": types.ObjectType{AttrTypes: conditionsType}}
+	attributeType := map[string]attr.Type{"name": types.StringType, "path": types.StringType, "required": types.BoolType}
+
+	rule := types.ObjectValueMust(map[string]attr.Type{
+		"id":         types.StringType,
+		"attribute":  types.ListType{ElemType: types.ObjectType{AttrTypes: attributeType}},
+		"event_rule": types.ListType{ElemType: types.ObjectType{AttrTypes: eventRuleType}},
+	}, map[string]attr.Value{
+		// Not known before the rule is created
+		"id": types.StringUnknown(),
+		"attribute": types.ListValueMust(types.ObjectType{AttrTypes: attributeType}, []attr.Value{
+			types.ObjectValueMust(attributeType, map[string]attr.Value{
+				"name": types.StringValue("versioning"), "path": types.StringValue("data.BucketVersioning"), "required": types.BoolValue(true),
+			}),
+		}),
+		"event_rule": types.ListValueMust(types.ObjectType{AttrTypes: eventRuleType}, []attr.Value{
+			types.ObjectValueMust(eventRuleType, map[string]attr.Value{
+				"description": types.StringValue("versioning enabled"),
+				"conditions": types.ObjectValueMust(conditionsType, map[string]attr.Value{
+					"operator":  types.StringValue("all"),
+					"condition": types.ListValueMust(types.ObjectType{AttrTypes: conditionType}, []attr.Value{condition}),
+				}),
+			}),
+		}),
+	})
+
+	if status, err := runEvaluate(t, rule, testBucket); err != nil || status != "SUCCESS" {
+		t.Errorf("got %q, %v, want SUCCESS", status, err)
+	}
+	if status, err := runEvaluate(t, rule, `{"data": {"BucketVersioning": {"Status": "Suspended"}}}`); err != nil || status != "FAILURE" {
+		t.Errorf("got %q, %v, want FAILURE", status, err)
+	}
+	if _, err := runEvaluate(t, rule, `{"data":`); err == nil || err.FunctionArgument == nil || *err.FunctionArgument != 1 {
+		t.Errorf("expected an error on the resource argument, got %v", err)
+	}
+}
+
+func TestCustomRuleEvaluateJSON(t *testing.T) {
+	rule := types.StringValue(`{
+		"attributes": [{"name": "versioning", "path": "data.BucketVersioning", "required": true}],
+		"eventRules": [{"description": "mfa delete", "conditions": {"any": [
+			{"fact": "versioning", "path": "$.MFADelete", "operator": "equal", "value": "Enabled"},
+			{"fact": "versioning", "path": "$.Status", "operator": "in", "value": ["Enabled", "Suspended"]}
+		]}}]
+	}`)
+	if status, err := runEvaluate(t, rule, testBucket); err != nil || status != "SUCCESS" {
+		t.Errorf("got %q, %v, want SUCCESS", status, err)
+	}
+	if _, err := runEvaluate(t, types.StringValue(`{"eventRules": [{"conditions": {"all": [{"fact": "missing", "operator": "equal"}]}}]}`), testBucket); err == nil {
+		t.Error("expected an error for an undeclared fact")
+	}
+}
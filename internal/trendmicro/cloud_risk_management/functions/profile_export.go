@@ -0,0 +1,209 @@
+package functions
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ function.Function = &ProfileExportFunction{}
+
+func NewProfileExportFunction() function.Function {
+	return &ProfileExportFunction{}
+}
+
+// ProfileExportFunction renders a profile in the JSON format the Vision One console exports.
+type ProfileExportFunction struct{}
+
+// profileObject is a crm_profile resource, with the values of its scan_rule blocks.
+type profileObject struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	ProfileJSON *string `json:"profile_json"`
+	ScanRule    []struct {
+		ID         string `json:"id"`
+		Provider   string `json:"provider"`
+		Enabled    bool   `json:"enabled"`
+		RiskLevel  string `json:"risk_level"`
+		Exceptions *struct {
+			FilterTags  []string `json:"filter_tags"`
+			ResourceIDs []string `json:"resource_ids"`
+		} `json:"exceptions"`
+		ExtraSettings []struct {
+			Name     string   `json:"name"`
+			Type     string   `json:"type"`
+			Value    *string  `json:"value"`
+			ValueSet []string `json:"value_set"`
+			Values   []struct {
+				Value               *string  `json:"value"`
+				Enabled             *bool    `json:"enabled"`
+				VpcID               *string  `json:"vpc_id"`
+				GatewayIDs          []string `json:"gateway_ids"`
+				CustomizedTags      []string `json:"customized_tags"`
+				CustomizedRiskLevel *string  `json:"customized_risk_level"`
+			} `json:"values"`
+		} `json:"extra_settings"`
+	} `json:"scan_rule"`
+}
+
+func (f *ProfileExportFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "crm_profile_export"
+}
+
+func (f *ProfileExportFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Exports a Cloud Risk Management profile as JSON.",
+		MarkdownDescription: "Renders a Cloud Risk Management profile in the JSON format the Vision One console exports, with the rules sorted by provider and ID. " +
+			"The result can be imported with the `profile_json` attribute of `visionone_crm_profile`.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name: "profile",
+				MarkdownDescription: "The profile: a `visionone_crm_profile` resource, an object with the same `name`, `description` and `scan_rule` values, " +
+					"or a JSON string of a profile exported from the Vision One console or from Cloud One Conformity.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *ProfileExportFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var value types.Dynamic
+	resp.Error = function.ConcatFuncErrors(req.Arguments.Get(ctx, &value))
+	if resp.Error != nil {
+		return
+	}
+
+	profile, err := decodeProfile(ctx, value.UnderlyingValue())
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	rendered, err := utils.RenderProfileDocument(profile)
+	if err != nil {
+		resp.Error = function.NewFuncError("Could not render the profile: " + err.Error())
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Result.Set(ctx, rendered))
+}
+
+func decodeProfile(ctx context.Context, value attr.Value) (*cloud_risk_management_dto.Profile, error) {
+	if s, ok := value.(types.String); ok {
+		return utils.ParseProfileDocument(s.ValueString())
+	}
+
+	terraformValue, err := value.ToTerraformValue(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]tftypes.Value
+	if err := terraformValue.As(&fields); err != nil {
+		return nil, fmt.Errorf("the profile must be an object or a JSON string: %w", err)
+	}
+	// Only convert the profile fields, the ID of a resource may not be known yet
+	native := map[string]any{}
+	for _, key := range []string{"name", "description", "profile_json", "scan_rule"} {
+		field, ok := fields[key]
+		if !ok {
+			continue
+		}
+		if native[key], err = terraformValueToNative(field); err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	raw, err := json.Marshal(native)
+	if err != nil {
+		return nil, err
+	}
+	var decoded profileObject
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, fmt.Errorf("the profile is not a crm_profile: %w", err)
+	}
+
+	profile := &cloud_risk_management_dto.Profile{}
+	if decoded.ProfileJSON != nil {
+		if profile, err = utils.ParseProfileDocument(*decoded.ProfileJSON); err != nil {
+			return nil, fmt.Errorf("profile_json: %w", err)
+		}
+	} else if profile.ScanRules, err = utils.ConvertScanRulesToDTO(scanRuleModels(&decoded)); err != nil {
+		return nil, err
+	}
+	if decoded.Name != nil {
+		profile.Name = *decoded.Name
+	}
+	if decoded.Description != nil {
+		profile.Description = decoded.Description
+	}
+	return profile, nil
+}
+
+// scanRuleModels converts the scan_rule blocks to the models of the resource, so they convert like the resource's.
+func scanRuleModels(profile *profileObject) []utils.ScanRuleModel {
+	stringValues := func(values []string) []types.String {
+		if values == nil {
+			return nil
+		}
+		result := make([]types.String, len(values))
+		for i, value := range values {
+			result[i] = types.StringValue(value)
+		}
+		return result
+	}
+
+	rules := make([]utils.ScanRuleModel, len(profile.ScanRule))
+	for i, rule := range profile.ScanRule {
+		rules[i] = utils.ScanRuleModel{
+			ID:        types.StringValue(rule.ID),
+			Provider:  types.StringValue(rule.Provider),
+			Enabled:   types.BoolValue(rule.Enabled),
+			RiskLevel: types.StringValue(rule.RiskLevel),
+		}
+		if rule.Exceptions != nil {
+			rules[i].Exceptions = &utils.RuleExceptionsModel{
+				FilterTags:  stringValues(rule.Exceptions.FilterTags),
+				ResourceIds: stringValues(rule.Exceptions.ResourceIDs),
+			}
+		}
+		for _, setting := range rule.ExtraSettings {
+			model := utils.ExtraSettingModel{
+				Name:     types.StringValue(setting.Name),
+				Type:     types.StringValue(setting.Type),
+				Value:    types.StringPointerValue(setting.Value),
+				ValueSet: stringValues(setting.ValueSet),
+			}
+			// Like the resource, an empty values block list is sent as an empty list
+			if setting.Values != nil {
+				model.Values = make([]utils.ExtraSettingsValuesObjectModel, 0, len(setting.Values))
+			}
+			for _, value := range setting.Values {
+				customizedTags := types.SetNull(types.StringType)
+				if value.CustomizedTags != nil {
+					elements := make([]attr.Value, len(value.CustomizedTags))
+					for j, tag := range value.CustomizedTags {
+						elements[j] = types.StringValue(tag)
+					}
+					customizedTags = types.SetValueMust(types.StringType, elements)
+				}
+				model.Values = append(model.Values, utils.ExtraSettingsValuesObjectModel{
+					Value:               types.StringPointerValue(value.Value),
+					Enabled:             types.BoolPointerValue(value.Enabled),
+					VpcId:               types.StringPointerValue(value.VpcID),
+					GatewayIds:          stringValues(value.GatewayIDs),
+					CustomizedTags:      customizedTags,
+					CustomizedRiskLevel: types.StringPointerValue(value.CustomizedRiskLevel),
+				})
+			}
+			rules[i].ExtraSettings = append(rules[i].ExtraSettings, model)
+		}
+	}
+	return rules
+}
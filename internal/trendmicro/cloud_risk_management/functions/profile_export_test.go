@@ -0,0 +1,89 @@
+package functions
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func runExport(t *testing.T, profile attr.Value) (string, *function.FuncError) {
+	t.Helper()
+	resp := &function.RunResponse{Result: function.NewResultData(types.StringUnknown())}
+	(&ProfileExportFunction{}).Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.DynamicValue(profile)}),
+	}, resp)
+	if resp.Error != nil {
+		return "", resp.Error
+	}
+	return resp.Result.Value().(types.String).ValueString(), nil
+}
+
+func TestProfileExportResourceObject(t *testing.T) {
+	valuesType := map[string]attr.Type{"value": types.StringType, "enabled": types.BoolType}
+	settingType := map[string]attr.Type{
+		"name": types.StringType, "type": types.StringType, "value": types.StringType,
+		"value_set": types.SetType{ElemType: types.StringType},
+		"values":    types.ListType{ElemType: types.ObjectType{AttrTypes: valuesType}},
+	}
+	ruleType := map[string]attr.Type{
+		"id": types.StringType, "provider": types.StringType, "enabled": types.BoolType, "risk_level": types.StringType,
+		"extra_settings": types.ListType{ElemType: types.ObjectType{AttrTypes: settingType}},
+	}
+	rule := func(id string, settings ...attr.Value) attr.Value {
+		return types.ObjectValueMust(ruleType, map[string]attr.Value{
+			"id": types.StringValue(id), "provider": types.StringValue("aws"), "enabled": types.BoolValue(true), "risk_level": types.StringValue("HIGH"),
+			"extra_settings": types.ListValueMust(types.ObjectType{AttrTypes: settingType}, settings),
+		})
+	}
+	regions := types.ObjectValueMust(settingType, map[string]attr.Value{
+		"name": types.StringValue("regions"), "type": types.StringValue("regions"), "value": types.StringNull(),
+		"value_set": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("us-east-1")}),
+		"values":    types.ListValueMust(types.ObjectType{AttrTypes: valuesType}, []attr.Value{}),
+	})
+
+	profile := types.ObjectValueMust(map[string]attr.Type{
+		"id": types.StringType, "name": types.StringType, "description": types.StringType, "profile_json": types.StringType,
+		"scan_rule": types.SetType{ElemType: types.ObjectType{AttrTypes: ruleType}},
+	}, map[string]attr.Value{
+		// Not known before the profile is created
+		"id":           types.StringUnknown(),
+		"name":         types.StringValue("baseline"),
+		"description":  types.StringNull(),
+		"profile_json": types.StringNull(),
+		"scan_rule":    types.SetValueMust(types.ObjectType{AttrTypes: ruleType}, []attr.Value{rule("S3-001", regions), rule("EC2-001")}),
+	})
+
+	rendered, err := runExport(t, profile)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Text)
+	}
+	for _, want := range []string{`"name": "baseline"`, `"riskLevel": "HIGH"`, `"value": "us-east-1"`} {
+		if !strings.Contains(rendered, want) {
+			t.Errorf("expected %s in:\n%s", want, rendered)
+		}
+	}
+	if strings.Index(rendered, `"EC2-001"`) > strings.Index(rendered, `"S3-001"`) {
+		t.Errorf("rules should be sorted by ID:\n%s", rendered)
+	}
+}
+
+func TestProfileExportConformityJSON(t *testing.T) {
+	rendered, err := runExport(t, types.StringValue(`{
+		"data": {"type": "profiles", "attributes": {"name": "Baseline"}},
+		"included": [{"type": "rules", "id": "S3-001", "attributes": {"provider": "aws", "enabled": true, "riskLevel": "LOW"}}]
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err.Text)
+	}
+	if !strings.Contains(rendered, `"scanRules"`) || !strings.Contains(rendered, `"id": "S3-001"`) {
+		t.Errorf("expected the Vision One format, got:\n%s", rendered)
+	}
+
+	if _, err := runExport(t, types.StringValue(`{"rules": []}`)); err == nil {
+		t.Error("expected an error for a document that is not a profile")
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -33,6 +35,8 @@ type accountScanRulesResource struct {
 type AccountScanRulesResourceModel struct {
 	AccountID types.String          `tfsdk:"account_id"`
 	ScanRules []utils.ScanRuleModel `tfsdk:"scan_rule"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 func NewAccountScanRulesResource() resource.Resource {
@@ -50,6 +54,7 @@ func (r *accountScanRulesResource) Schema(_ context.Context, _ resource.SchemaRe
 		Description: "Manages scan rule settings for a Vision One Cloud Risk Management account.\n\n" +
 			"Scan rules are provisioned automatically when an account is onboarded. Use this resource to customize their configurations.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"account_id": schema.StringAttribute{
 				MarkdownDescription: "The Vision One Cloud Risk Management internal account ID to manage scan rule settings for.",
 				Required:            true,
@@ -87,7 +92,7 @@ func (r *accountScanRulesResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 func (r *accountScanRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -98,6 +103,8 @@ func (r *accountScanRulesResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	accountID := plan.AccountID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Create account rule setting for account: %s", accountID))
 
@@ -112,7 +119,7 @@ func (r *accountScanRulesResource) Create(ctx context.Context, req resource.Crea
 			return
 		}
 
-		err = r.client.UpdateAccountRuleSettings(accountID, ruleSettings)
+		err = client.UpdateAccountRuleSettings(accountID, ruleSettings)
 		if err != nil {
 			tflog.Debug(ctx, err.Error())
 
@@ -138,7 +145,7 @@ func (r *accountScanRulesResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// Read back to get the current state from API
-	r.readAndUpdatePlan(ctx, &plan, &resp.Diagnostics)
+	r.readAndUpdatePlan(ctx, client, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -163,9 +170,11 @@ func (r *accountScanRulesResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	accountID := state.AccountID.ValueString()
 
-	apiRuleSettings, err := r.client.GetAccountRuleSettings(accountID)
+	apiRuleSettings, err := client.GetAccountRuleSettings(ctx, accountID)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -189,6 +198,8 @@ func (r *accountScanRulesResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	var state AccountScanRulesResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -215,7 +226,7 @@ func (r *accountScanRulesResource) Update(ctx context.Context, req resource.Upda
 	if len(removedRuleIDs) > 0 {
 		tflog.Debug(ctx, fmt.Sprintf("Resetting %d removed rule setting(s) for account %s", len(removedRuleIDs), accountID))
 
-		if err := r.deleteAndReportError(ctx, accountID, removedRuleIDs, &resp.Diagnostics); err != nil {
+		if err := r.deleteAndReportError(ctx, client, accountID, removedRuleIDs, &resp.Diagnostics); err != nil {
 			return
 		}
 	}
@@ -236,7 +247,7 @@ func (r *accountScanRulesResource) Update(ctx context.Context, req resource.Upda
 			return
 		}
 
-		err = r.client.UpdateAccountRuleSettings(accountID, ruleSettings)
+		err = client.UpdateAccountRuleSettings(accountID, ruleSettings)
 		if err != nil {
 			tflog.Debug(ctx, err.Error())
 
@@ -271,7 +282,7 @@ func (r *accountScanRulesResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	// Read back to get updated state
-	r.readAndUpdatePlan(ctx, &plan, &resp.Diagnostics)
+	r.readAndUpdatePlan(ctx, client, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -301,6 +312,7 @@ func (r *accountScanRulesResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
 	accountID := state.AccountID.ValueString()
 
 	if len(state.ScanRules) > 0 {
@@ -311,7 +323,7 @@ func (r *accountScanRulesResource) Delete(ctx context.Context, req resource.Dele
 
 		tflog.Debug(ctx, fmt.Sprintf("Resetting %d rule setting(s) for account %s", len(ruleIDs), accountID))
 
-		if err := r.deleteAndReportError(ctx, accountID, ruleIDs, &resp.Diagnostics); err != nil {
+		if err := r.deleteAndReportError(ctx, client, accountID, ruleIDs, &resp.Diagnostics); err != nil {
 			return
 		}
 	}
@@ -348,10 +360,10 @@ func convertAccountScanRulesToDTO(rules []utils.ScanRuleModel) ([]cloud_risk_man
 }
 
 // readAndUpdatePlan reads the account rule settings from the API and updates the plan/state model.
-func (r *accountScanRulesResource) readAndUpdatePlan(ctx context.Context, plan *AccountScanRulesResourceModel, diagnostics *diag.Diagnostics) {
+func (r *accountScanRulesResource) readAndUpdatePlan(ctx context.Context, client *api.CrmClient, plan *AccountScanRulesResourceModel, diagnostics *diag.Diagnostics) {
 	accountID := plan.AccountID.ValueString()
 
-	apiRuleSettings, err := r.client.GetAccountRuleSettings(accountID)
+	apiRuleSettings, err := client.GetAccountRuleSettings(ctx, accountID)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		diagnostics.AddError(
@@ -443,8 +455,8 @@ func rebuildScanRuleFromAPI(apiRuleSetting *cloud_risk_management_dto.AccountRul
 
 // deleteAndReportError calls DeleteAccountRuleSettings and adds appropriate diagnostics on error.
 // Returns the error (nil on success) so callers can decide whether to return early.
-func (r *accountScanRulesResource) deleteAndReportError(ctx context.Context, accountID string, ruleIDs []string, diagnostics *diag.Diagnostics) error {
-	err := r.client.DeleteAccountRuleSettings(accountID, ruleIDs)
+func (r *accountScanRulesResource) deleteAndReportError(ctx context.Context, client *api.CrmClient, accountID string, ruleIDs []string, diagnostics *diag.Diagnostics) error {
+	err := client.DeleteAccountRuleSettings(accountID, ruleIDs)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		var partialFailure *api.PartialFailureError
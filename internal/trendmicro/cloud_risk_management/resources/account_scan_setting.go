@@ -2,10 +2,13 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 	"time"
 
@@ -43,6 +46,8 @@ type AccountScanSettingResourceModel struct {
 	DisabledUntilDateTime types.String `tfsdk:"disabled_until_datetime"`
 	Enabled               types.Bool   `tfsdk:"enabled"`
 	Interval              types.Int64  `tfsdk:"interval"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 func NewAccountScanSettingResource() resource.Resource {
@@ -69,7 +74,7 @@ func (r *accountScanSettingResource) Configure(_ context.Context, req resource.C
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 func (r *accountScanSettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -78,6 +83,7 @@ func (r *accountScanSettingResource) Schema(_ context.Context, _ resource.Schema
 			"Account scan settings control how and when cloud posture scans are performed. " +
 			"These settings are automatically created when an account is added and can be updated to customize scan behavior.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"account_id": schema.StringAttribute{
 				MarkdownDescription: "The CRM account ID for which to manage scan settings.",
 				Required:            true,
@@ -131,6 +137,8 @@ func (r *accountScanSettingResource) Create(ctx context.Context, req resource.Cr
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	accountID := plan.AccountID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Creating account scan setting for account: %s", accountID))
 
@@ -138,7 +146,7 @@ func (r *accountScanSettingResource) Create(ctx context.Context, req resource.Cr
 	updateReq := buildUpdateRequest(&plan)
 
 	// Update the settings
-	err := r.client.UpdateAccountScanSetting(accountID, updateReq)
+	err := client.UpdateAccountScanSetting(accountID, updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Account Scan Setting",
@@ -148,7 +156,7 @@ func (r *accountScanSettingResource) Create(ctx context.Context, req resource.Cr
 	}
 
 	// Read the updated settings
-	updatedSettings, err := r.client.GetAccountScanSetting(accountID)
+	updatedSettings, err := client.GetAccountScanSetting(accountID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Updated Account Scan Settings",
@@ -175,11 +183,13 @@ func (r *accountScanSettingResource) Read(ctx context.Context, req resource.Read
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	accountID := state.AccountID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Reading account scan setting for account: %s", accountID))
 
-	settings, err := r.client.GetAccountScanSetting(accountID)
-	if api.IsNotFoundError(err) {
+	settings, err := client.GetAccountScanSetting(accountID)
+	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -208,6 +218,8 @@ func (r *accountScanSettingResource) Update(ctx context.Context, req resource.Up
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	accountID := plan.AccountID.ValueString()
 	tflog.Debug(ctx, fmt.Sprintf("Updating account scan setting for account: %s", accountID))
 
@@ -215,7 +227,7 @@ func (r *accountScanSettingResource) Update(ctx context.Context, req resource.Up
 	updateReq := buildUpdateRequest(&plan)
 
 	// Update the settings
-	err := r.client.UpdateAccountScanSetting(accountID, updateReq)
+	err := client.UpdateAccountScanSetting(accountID, updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Account Scan Setting",
@@ -225,7 +237,7 @@ func (r *accountScanSettingResource) Update(ctx context.Context, req resource.Up
 	}
 
 	// Read the updated settings
-	updatedSettings, err := r.client.GetAccountScanSetting(accountID)
+	updatedSettings, err := client.GetAccountScanSetting(accountID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Updated Account Scan Settings",
@@ -252,13 +264,15 @@ func (r *accountScanSettingResource) Delete(ctx context.Context, req resource.De
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	updateModel := &cloud_risk_management_dto.AccountScanSetting{
 		DisabledRegions:       []string{},
 		Enabled:               true,
 		Interval:              1,
 		DisabledUntilDateTime: nil,
 	}
-	err := r.client.UpdateAccountScanSetting(state.AccountID.ValueString(), updateModel)
+	err := client.UpdateAccountScanSetting(state.AccountID.ValueString(), updateModel)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Resetting Account Scan Setting",
@@ -6,6 +6,8 @@ import (
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 	crm_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -35,6 +37,8 @@ type CheckSuppressionResourceModel struct {
 	ResourceID              types.String `tfsdk:"resource_id"`
 	Note                    types.String `tfsdk:"note"`
 	SuppressedUntilDateTime types.String `tfsdk:"suppressed_until_date_time"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 func NewCheckSuppressionResource() resource.Resource {
@@ -54,6 +58,7 @@ func (r *checkSuppressionResource) Schema(_ context.Context, _ resource.SchemaRe
 			"When the Terraform resource is created, the flag is set. " +
 			"When the Terraform resource is destroyed, the flag is removed. ",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				Description: "The unique ID of the check suppression. This is automatically generated.",
 				Computed:    true,
@@ -127,7 +132,7 @@ func (r *checkSuppressionResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 
-	r.client = &api.CrmClient{Client: client}
+	r.client = api.NewCrmClient(client)
 }
 
 func (r *checkSuppressionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -138,6 +143,8 @@ func (r *checkSuppressionResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	// Construct check ID
 	// Format: ccc:{accountId}:{ruleId}:{service}:{region}:{resourceId}
 	checkID := fmt.Sprintf("ccc:%s:%s:%s:%s:%s",
@@ -164,7 +171,7 @@ func (r *checkSuppressionResource) Create(ctx context.Context, req resource.Crea
 	}
 
 	// API returns 204 No Content on success
-	err := r.client.UpdateCheck(checkID, updateReq)
+	err := client.UpdateCheck(checkID, updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error suppressing check",
@@ -193,8 +200,10 @@ func (r *checkSuppressionResource) Read(ctx context.Context, req resource.ReadRe
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	// Get current check details to verify suppression status
-	checkResp, err := r.client.GetCheck(state.ID.ValueString())
+	checkResp, err := client.GetCheck(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading check",
@@ -248,6 +257,8 @@ func (r *checkSuppressionResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	// Construct update request
 	updateReq := &crm_dto.UpdateCheckRequest{
 		Suppressed: true, // Resource existence means it's suppressed
@@ -260,7 +271,7 @@ func (r *checkSuppressionResource) Update(ctx context.Context, req resource.Upda
 	}
 
 	// Update the check via API
-	err := r.client.UpdateCheck(state.ID.ValueString(), updateReq)
+	err := client.UpdateCheck(state.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error updating check suppression",
@@ -288,6 +299,8 @@ func (r *checkSuppressionResource) Delete(ctx context.Context, req resource.Dele
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
 	// Unsuppress the check by setting suppressed to false
 	updateReq := &crm_dto.UpdateCheckRequest{
 		Suppressed: false,
@@ -295,7 +308,7 @@ func (r *checkSuppressionResource) Delete(ctx context.Context, req resource.Dele
 	}
 
 	// API returns 204 No Content on success
-	err := r.client.UpdateCheck(state.ID.ValueString(), updateReq)
+	err := client.UpdateCheck(state.ID.ValueString(), updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error unsuppressing check",
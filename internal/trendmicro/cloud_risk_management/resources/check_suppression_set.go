@@ -0,0 +1,443 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+	crm_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                     = &checkSuppressionSetResource{}
+	_ resource.ResourceWithConfigure        = &checkSuppressionSetResource{}
+	_ resource.ResourceWithConfigValidators = &checkSuppressionSetResource{}
+	_ resource.ResourceWithModifyPlan       = &checkSuppressionSetResource{}
+)
+
+const releasedCheckNote = "Re-enabled as suppression has been deleted in Terraform"
+
+type checkSuppressionSetResource struct {
+	client *api.CrmClient
+}
+
+type CheckSuppressionSetResourceModel struct {
+	ID                      types.String   `tfsdk:"id"`
+	AccountIDs              []types.String `tfsdk:"account_ids"`
+	RuleIDs                 []types.String `tfsdk:"rule_ids"`
+	Services                []types.String `tfsdk:"services"`
+	Regions                 []types.String `tfsdk:"regions"`
+	Tags                    []types.String `tfsdk:"tags"`
+	ResourceIDRegex         types.String   `tfsdk:"resource_id_regex"`
+	Note                    types.String   `tfsdk:"note"`
+	SuppressedUntilDateTime types.String   `tfsdk:"suppressed_until_date_time"`
+	CheckIDs                types.Set      `tfsdk:"check_ids"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
+func NewCheckSuppressionSetResource() resource.Resource {
+	return &checkSuppressionSetResource{
+		client: &api.CrmClient{},
+	}
+}
+
+func (r *checkSuppressionSetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_crm_check_suppression_set"
+}
+
+func (r *checkSuppressionSetResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	filterSet := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			MarkdownDescription: description,
+			ElementType:         types.StringType,
+			Optional:            true,
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Suppresses every Cloud Risk Management check matching a filter. " +
+			"Values within one filter are alternatives; a check has to match every filter that is set.\n\n" +
+			"Plans list the matching checks, so checks that appeared since the last apply are suppressed by the next one. " +
+			"Checks that no longer match are re-enabled, and destroying the resource re-enables every check it suppressed. " +
+			"Checks that were already suppressed by something else are left alone.",
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID of the check suppression set. This is automatically generated.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"account_ids": filterSet("Suppress checks of these Cloud Risk Management account IDs."),
+			"rule_ids":    filterSet("Suppress checks of these rules, for example `EC2-074`."),
+			"services":    filterSet("Suppress checks of these cloud services, for example `EC2`."),
+			"regions":     filterSet("Suppress checks in these regions, for example `ap-south-1` or `global`."),
+			"tags":        filterSet("Suppress checks of resources carrying any of these tags, for example `environment::sandbox`."),
+			"resource_id_regex": schema.StringAttribute{
+				MarkdownDescription: "Suppress checks whose resource matches this [RE2 regular expression](https://github.com/google/re2/wiki/Syntax).",
+				Optional:            true,
+				Validators:          []validator.String{regexValidator{}},
+			},
+			"note": schema.StringAttribute{
+				MarkdownDescription: "Explains why the checks have been suppressed.",
+				Required:            true,
+			},
+			"suppressed_until_date_time": schema.StringAttribute{
+				MarkdownDescription: "The date and time until which the checks will be suppressed. " +
+					"Must be in ISO 8601 format with UTC timezone (e.g., `2026-12-31T23:59:59Z`). " +
+					"If not specified, the checks will be suppressed indefinitely.",
+				Optional: true,
+			},
+			"check_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of the checks suppressed by this resource.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *checkSuppressionSetResource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	// An empty filter would suppress every check of the tenant
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("account_ids"),
+			path.MatchRoot("rule_ids"),
+			path.MatchRoot("services"),
+			path.MatchRoot("regions"),
+			path.MatchRoot("tags"),
+			path.MatchRoot("resource_id_regex"),
+		),
+	}
+}
+
+func (r *checkSuppressionSetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = api.NewCrmClient(client)
+}
+
+// ModifyPlan plans an update when the matching checks changed since the last apply, so new checks get suppressed.
+func (r *checkSuppressionSetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	// check_ids is already unknown when the configuration changed
+	var checkIDs types.Set
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("check_ids"), &checkIDs)...)
+	if resp.Diagnostics.HasError() || checkIDs.IsUnknown() {
+		return
+	}
+
+	var plan CheckSuppressionSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	owned := ownedCheckIDs(ctx, plan.CheckIDs)
+	matching, err := r.client.WithCredentials(plan.Credentials).ListChecks(ctx, plan.checkFilter())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error listing checks",
+			fmt.Sprintf("Could not list the checks matching check suppression set %s: %s", plan.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	suppress, release := planSuppressionSet(matching, owned)
+	if len(suppress) == 0 && len(release) == 0 {
+		return
+	}
+
+	tflog.Debug(ctx, "Matching checks changed, planning a check suppression set update", map[string]interface{}{
+		"id":       plan.ID.ValueString(),
+		"suppress": len(suppress),
+		"release":  len(release),
+	})
+	plan.CheckIDs = types.SetUnknown(types.StringType)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+func (r *checkSuppressionSetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan CheckSuppressionSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(uuid.NewString())
+	r.apply(ctx, &plan, nil, false, &resp.State, &resp.Diagnostics)
+}
+
+func (r *checkSuppressionSetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state CheckSuppressionSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	matching, err := client.ListChecks(ctx, state.checkFilter())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading check suppression set",
+			fmt.Sprintf("Could not list the checks matching check suppression set %s: %s", state.ID.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Checks re-enabled outside Terraform are no longer owned, the next apply suppresses them again
+	owned := ownedCheckIDs(ctx, state.CheckIDs)
+	for _, check := range matching {
+		if !check.Suppressed {
+			owned = slices.DeleteFunc(owned, func(id string) bool { return id == check.ID })
+		}
+	}
+
+	checkIDs, diags := stringSliceToSet(owned)
+	resp.Diagnostics.Append(diags...)
+	state.CheckIDs = checkIDs
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *checkSuppressionSetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state CheckSuppressionSetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	renote := !plan.Note.Equal(state.Note) || !plan.SuppressedUntilDateTime.Equal(state.SuppressedUntilDateTime)
+	r.apply(ctx, &plan, ownedCheckIDs(ctx, state.CheckIDs), renote, &resp.State, &resp.Diagnostics)
+}
+
+func (r *checkSuppressionSetResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state CheckSuppressionSetResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	for _, checkID := range ownedCheckIDs(ctx, state.CheckIDs) {
+		if err := releaseCheck(client, checkID); err != nil {
+			resp.Diagnostics.AddError(
+				"Error unsuppressing check",
+				fmt.Sprintf("Could not unsuppress check %s: %s", checkID, err.Error()),
+			)
+		}
+	}
+
+	tflog.Debug(ctx, "Check suppression set deleted", map[string]interface{}{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// apply reconciles the checks and saves the plan with the checks owned afterwards, also when only some
+// updates succeeded so the next apply does not lose track of them.
+func (r *checkSuppressionSetResource) apply(ctx context.Context, plan *CheckSuppressionSetResourceModel, owned []string, renote bool, state *tfsdk.State, diags *diag.Diagnostics) {
+	client := r.client.WithCredentials(plan.Credentials)
+
+	suppression := &crm_dto.UpdateCheckRequest{
+		Suppressed: true,
+		Note:       plan.Note.ValueString(),
+	}
+	if !plan.SuppressedUntilDateTime.IsNull() && !plan.SuppressedUntilDateTime.IsUnknown() {
+		suppression.SuppressedUntilDateTime = plan.SuppressedUntilDateTime.ValueString()
+	}
+
+	owned, err := reconcileSuppressionSet(ctx, client, plan.checkFilter(), owned, suppression, renote)
+	if err != nil {
+		diags.AddError(
+			"Error suppressing checks",
+			fmt.Sprintf("Could not update the checks of check suppression set %s: %s", plan.ID.ValueString(), err.Error()),
+		)
+	}
+
+	checkIDs, setDiags := stringSliceToSet(owned)
+	diags.Append(setDiags...)
+	plan.CheckIDs = checkIDs
+
+	tflog.Debug(ctx, "Check suppression set applied", map[string]interface{}{
+		"id":     plan.ID.ValueString(),
+		"checks": len(owned),
+	})
+
+	diags.Append(state.Set(ctx, plan)...)
+}
+
+// checkFilter returns the filter of the checks to suppress. resource_id_regex was validated with the configuration.
+func (m *CheckSuppressionSetResourceModel) checkFilter() *api.CheckFilter {
+	filter := &api.CheckFilter{
+		AccountIDs: valueStrings(m.AccountIDs),
+		RuleIDs:    valueStrings(m.RuleIDs),
+		Services:   valueStrings(m.Services),
+		Regions:    valueStrings(m.Regions),
+		Tags:       valueStrings(m.Tags),
+	}
+	if !m.ResourceIDRegex.IsNull() && !m.ResourceIDRegex.IsUnknown() {
+		filter.ResourceID = regexp.MustCompile(m.ResourceIDRegex.ValueString())
+	}
+	return filter
+}
+
+// planSuppressionSet returns the matching checks to suppress, and the owned checks to release because they no
+// longer match. Checks suppressed by something else are not taken over.
+func planSuppressionSet(matching []crm_dto.CheckResource, owned []string) (suppress, release []string) {
+	matched := map[string]bool{}
+	for _, check := range matching {
+		matched[check.ID] = true
+		if !check.Suppressed {
+			suppress = append(suppress, check.ID)
+		}
+	}
+	for _, checkID := range owned {
+		if !matched[checkID] {
+			release = append(release, checkID)
+		}
+	}
+	return suppress, release
+}
+
+// reconcileSuppressionSet suppresses the unsuppressed matching checks and releases the owned checks that no longer
+// match. With renote, the owned checks that stay are suppressed again to update their note and expiry. It returns
+// the checks owned afterwards, sorted.
+func reconcileSuppressionSet(ctx context.Context, client *api.CrmClient, filter *api.CheckFilter, owned []string, suppression *crm_dto.UpdateCheckRequest, renote bool) ([]string, error) {
+	matching, err := client.ListChecks(ctx, filter)
+	if err != nil {
+		return owned, err
+	}
+
+	result := map[string]bool{}
+	for _, checkID := range owned {
+		result[checkID] = true
+	}
+	sorted := func() []string {
+		ids := make([]string, 0, len(result))
+		for checkID := range result {
+			ids = append(ids, checkID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	suppress, release := planSuppressionSet(matching, owned)
+	if renote {
+		for _, checkID := range owned {
+			if !slices.Contains(release, checkID) && !slices.Contains(suppress, checkID) {
+				suppress = append(suppress, checkID)
+			}
+		}
+	}
+
+	for _, checkID := range release {
+		if err := releaseCheck(client, checkID); err != nil {
+			return sorted(), fmt.Errorf("could not unsuppress check %s: %w", checkID, err)
+		}
+		delete(result, checkID)
+	}
+	for _, checkID := range suppress {
+		if err := client.UpdateCheck(checkID, suppression); err != nil {
+			return sorted(), fmt.Errorf("could not suppress check %s: %w", checkID, err)
+		}
+		result[checkID] = true
+	}
+
+	tflog.Debug(ctx, "Reconciled check suppressions", map[string]interface{}{
+		"suppressed": len(suppress),
+		"released":   len(release),
+	})
+	return sorted(), nil
+}
+
+// releaseCheck re-enables a check. Checks that no longer exist are already released.
+func releaseCheck(client *api.CrmClient, checkID string) error {
+	err := client.UpdateCheck(checkID, &crm_dto.UpdateCheckRequest{
+		Suppressed: false,
+		Note:       releasedCheckNote,
+	})
+	if errors.Is(err, dto.ErrorNotFound) {
+		return nil
+	}
+	return err
+}
+
+func ownedCheckIDs(ctx context.Context, checkIDs types.Set) []string {
+	owned := []string{}
+	if checkIDs.IsNull() || checkIDs.IsUnknown() {
+		return owned
+	}
+	checkIDs.ElementsAs(ctx, &owned, false)
+	return owned
+}
+
+func valueStrings(values []types.String) []string {
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		result = append(result, value.ValueString())
+	}
+	return result
+}
+
+// Validates if a string is a valid RE2 regular expression
+type regexValidator struct{}
+
+func (v regexValidator) Description(_ context.Context) string {
+	return "value must be a valid RE2 regular expression"
+}
+
+func (v regexValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v regexValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := regexp.Compile(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Regular Expression",
+			fmt.Sprintf("The value %q is not a valid RE2 regular expression: %s", req.ConfigValue.ValueString(), err.Error()),
+		)
+	}
+}
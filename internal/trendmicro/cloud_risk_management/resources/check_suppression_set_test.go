@@ -0,0 +1,65 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	crm_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+func TestReconcileSuppressionSet(t *testing.T) {
+	server := mockserver.NewTestServer(t)
+	client := api.NewCrmClient(&trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	})
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": "c1", "accountId": "acc-1", "suppressed": false, "tags": []any{"env::sandbox"}})
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": "c2", "accountId": "acc-1", "suppressed": true, "note": "manual", "tags": []any{"env::sandbox"}})
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": "c3", "accountId": "acc-1", "suppressed": false})
+
+	ctx := context.Background()
+	filter := &api.CheckFilter{AccountIDs: []string{"acc-1"}, Tags: []string{"env::sandbox"}}
+	suppression := &crm_dto.UpdateCheckRequest{Suppressed: true, Note: "accepted risk"}
+
+	owned, err := reconcileSuppressionSet(ctx, client, filter, nil, suppression, false)
+	if err != nil {
+		t.Fatalf("reconcileSuppressionSet: %v", err)
+	}
+	if !slices.Equal(owned, []string{"c1"}) {
+		t.Fatalf("expected to own c1 only, got %v", owned)
+	}
+	if check, _ := server.Get(mockserver.CRMChecksPath, "c1"); check["suppressed"] != true || check["note"] != "accepted risk" {
+		t.Errorf("c1 was not suppressed: %v", check)
+	}
+	if check, _ := server.Get(mockserver.CRMChecksPath, "c2"); check["note"] != "manual" {
+		t.Errorf("a check suppressed by something else was taken over: %v", check)
+	}
+
+	// c1 lost its tag, c4 appeared and the owned c5 was deleted
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": "c1", "accountId": "acc-1", "suppressed": true})
+	server.Seed(mockserver.CRMChecksPath, mockserver.Object{"id": "c4", "accountId": "acc-1", "suppressed": false, "tags": []any{"env::sandbox"}})
+	owned, err = reconcileSuppressionSet(ctx, client, filter, []string{"c1", "c5"}, suppression, false)
+	if err != nil {
+		t.Fatalf("reconcileSuppressionSet: %v", err)
+	}
+	if !slices.Equal(owned, []string{"c4"}) {
+		t.Fatalf("expected to own c4 only, got %v", owned)
+	}
+	if check, _ := server.Get(mockserver.CRMChecksPath, "c1"); check["suppressed"] != false {
+		t.Errorf("c1 was not released: %v", check)
+	}
+
+	renoted := &crm_dto.UpdateCheckRequest{Suppressed: true, Note: "reviewed"}
+	if _, err := reconcileSuppressionSet(ctx, client, filter, owned, renoted, true); err != nil {
+		t.Fatalf("reconcileSuppressionSet: %v", err)
+	}
+	if check, _ := server.Get(mockserver.CRMChecksPath, "c4"); check["note"] != "reviewed" {
+		t.Errorf("the note of c4 was not updated: %v", check)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
@@ -71,6 +72,8 @@ type CommunicationConfigurationResourceModel struct {
 	JiraConfiguration       *JiraConfigurationModel       `tfsdk:"jira_configuration"`
 	ZendeskConfiguration    *ZendeskConfigurationModel    `tfsdk:"zendesk_configuration"`
 	ServiceNowConfiguration *ServiceNowConfigurationModel `tfsdk:"servicenow_configuration"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type ChecksFilterModel struct {
@@ -416,7 +419,7 @@ func (r *communicationConfigurationResource) Configure(_ context.Context, req re
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 func (r *communicationConfigurationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -426,6 +429,7 @@ func (r *communicationConfigurationResource) Schema(_ context.Context, _ resourc
 			"`ms_teams_configuration`, `slack_configuration`, `sns_configuration`, `pagerduty_configuration`, " +
 			"`webhook_configuration`, `jira_configuration`, `zendesk_configuration`, or `servicenow_configuration`.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the communication configuration.",
 				Computed:            true,
@@ -886,6 +890,8 @@ func (r *communicationConfigurationResource) Create(ctx context.Context, req res
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Create new communication configuration plan: %+v", plan))
 
 	body := &cloud_risk_management_dto.CreateCommunicationConfigurationRequest{
@@ -919,7 +925,7 @@ func (r *communicationConfigurationResource) Create(ctx context.Context, req res
 	}
 	body.ChecksFilter = checksFilter
 
-	createdConfig, err := r.client.CreateCommunicationConfiguration(body)
+	createdConfig, err := client.CreateCommunicationConfiguration(body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Communication Configuration",
@@ -929,7 +935,7 @@ func (r *communicationConfigurationResource) Create(ctx context.Context, req res
 	}
 	plan.ID = types.StringValue(createdConfig.ID)
 
-	fullConfig, err := r.client.GetCommunicationConfiguration(createdConfig.ID)
+	fullConfig, err := client.GetCommunicationConfiguration(createdConfig.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Created Communication Configuration",
@@ -955,7 +961,9 @@ func (r *communicationConfigurationResource) Read(ctx context.Context, req resou
 		return
 	}
 
-	config, err := r.client.GetCommunicationConfiguration(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	config, err := client.GetCommunicationConfiguration(state.ID.ValueString())
 	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
@@ -985,6 +993,8 @@ func (r *communicationConfigurationResource) Update(ctx context.Context, req res
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Update communication configuration plan: %+v", plan))
 
 	body := &cloud_risk_management_dto.UpdateCommunicationConfigurationRequest{
@@ -1015,7 +1025,7 @@ func (r *communicationConfigurationResource) Update(ctx context.Context, req res
 
 	tflog.Debug(ctx, fmt.Sprintf("Updating config with ID: %s", plan.ID.ValueString()))
 
-	err := r.client.UpdateCommunicationConfiguration(plan.ID.ValueString(), body)
+	err := client.UpdateCommunicationConfiguration(plan.ID.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Communication Configuration",
@@ -1025,7 +1035,7 @@ func (r *communicationConfigurationResource) Update(ctx context.Context, req res
 	}
 
 	// Refresh state
-	config, err := r.client.GetCommunicationConfiguration(plan.ID.ValueString())
+	config, err := client.GetCommunicationConfiguration(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Updated Communication Configuration",
@@ -1052,7 +1062,9 @@ func (r *communicationConfigurationResource) Delete(ctx context.Context, req res
 		return
 	}
 
-	err := r.client.DeleteCommunicationConfiguration(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteCommunicationConfiguration(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Communication Configuration",
@@ -3,11 +3,16 @@ package resources
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
@@ -25,9 +30,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &customRuleResource{}
-	_ resource.ResourceWithConfigure   = &customRuleResource{}
-	_ resource.ResourceWithImportState = &customRuleResource{}
+	_ resource.Resource                   = &customRuleResource{}
+	_ resource.ResourceWithConfigure      = &customRuleResource{}
+	_ resource.ResourceWithImportState    = &customRuleResource{}
+	_ resource.ResourceWithValidateConfig = &customRuleResource{}
 )
 
 // NewCustomRuleResource is a helper function to simplify the provider implementation.
@@ -49,6 +55,7 @@ func (r *customRuleResource) Schema(_ context.Context, _ resource.SchemaRequest,
 	resp.Schema = schema.Schema{
 		Description: "Manages a Cloud Risk Management custom rule.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the custom rule.",
 				Computed:            true,
@@ -195,6 +202,29 @@ func (r *customRuleResource) Schema(_ context.Context, _ resource.SchemaRequest,
 					},
 				},
 			},
+			"test_case": schema.ListNestedBlock{
+				MarkdownDescription: "Sample resources the rule is evaluated against when planning, failing the plan when the rule does not produce the expected status. " +
+					"Test cases are not sent to Vision One.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the test case, used in failure messages.",
+							Required:            true,
+						},
+						"resource_json": schema.StringAttribute{
+							MarkdownDescription: "The resource as JSON, in the format Cloud Risk Management stores it. Attribute paths are resolved from its root, for example `data.BucketVersioning`.",
+							Required:            true,
+						},
+						"expected_status": schema.StringAttribute{
+							MarkdownDescription: "The status the rule must produce for the resource. Allowed values: SUCCESS, FAILURE.",
+							Required:            true,
+							Validators: []validator.String{
+								stringvalidator.OneOf(utils.CheckStatusSuccess, utils.CheckStatusFailure),
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -213,7 +243,7 @@ func (r *customRuleResource) Configure(_ context.Context, req resource.Configure
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 type customRuleModel struct {
@@ -231,6 +261,9 @@ type customRuleModel struct {
 	Slug                    types.String             `tfsdk:"slug"`
 	Attributes              []resourceAttributeModel `tfsdk:"attribute"`
 	EventRules              []eventRuleModel         `tfsdk:"event_rule"`
+	TestCases               []testCaseModel          `tfsdk:"test_case"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type resourceAttributeModel struct {
@@ -256,23 +289,89 @@ type conditionModel struct {
 	Fact     types.String `tfsdk:"fact"`
 }
 
-func (r *customRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var plan customRuleModel
-	diags := req.Plan.Get(ctx, &plan)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+type testCaseModel struct {
+	Name           types.String `tfsdk:"name"`
+	ResourceJSON   types.String `tfsdk:"resource_json"`
+	ExpectedStatus types.String `tfsdk:"expected_status"`
+}
+
+// ValidateConfig evaluates the test cases against the configured rule, so broken rules fail the plan.
+func (r *customRuleResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var testCases types.List
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("test_case"), &testCases)...)
+	if resp.Diagnostics.HasError() || testCases.IsUnknown() || len(testCases.Elements()) == 0 {
 		return
 	}
 
-	var categories []string
-	diags = plan.Categories.ElementsAs(ctx, &categories, false)
-	resp.Diagnostics.Append(diags...)
-	if resp.Diagnostics.HasError() {
+	// Blocks generated from unknown values cannot be decoded yet, they are validated once known
+	var config customRuleModel
+	if diags := req.Config.Get(ctx, &config); diags.HasError() || !config.ruleKnown() {
 		return
 	}
 
-	attributes := make([]cloud_risk_management_dto.ResourceAttribute, len(plan.Attributes))
-	for i, attr := range plan.Attributes {
+	attributes, eventRules := config.ruleToDTO()
+	for i, testCase := range config.TestCases {
+		if testCase.ResourceJSON.IsUnknown() || testCase.ExpectedStatus.IsUnknown() {
+			continue
+		}
+		testCasePath := path.Root("test_case").AtListIndex(i)
+
+		var resourceData any
+		if err := json.Unmarshal([]byte(testCase.ResourceJSON.ValueString()), &resourceData); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				testCasePath.AtName("resource_json"),
+				"Invalid Test Case Resource",
+				fmt.Sprintf("The resource of test case %q is not valid JSON: %s", testCase.Name.ValueString(), err.Error()),
+			)
+			continue
+		}
+
+		result, err := utils.EvaluateCustomRule(attributes, eventRules, resourceData, time.Now())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				testCasePath,
+				"Custom Rule Cannot Be Evaluated",
+				fmt.Sprintf("Could not evaluate the custom rule for test case %q: %s", testCase.Name.ValueString(), err.Error()),
+			)
+			return
+		}
+		if result.Status != testCase.ExpectedStatus.ValueString() {
+			detail := fmt.Sprintf("Test case %q expected %s, but the custom rule evaluated to %s.", testCase.Name.ValueString(), testCase.ExpectedStatus.ValueString(), result.Status)
+			if len(result.Failures) > 0 {
+				detail += "\n\n" + strings.Join(result.Failures, "\n")
+			}
+			resp.Diagnostics.AddAttributeError(testCasePath, "Custom Rule Test Case Failed", detail)
+		}
+	}
+}
+
+// ruleKnown reports whether the attributes and event rules are known, so the rule can be evaluated.
+func (m *customRuleModel) ruleKnown() bool {
+	for _, attribute := range m.Attributes {
+		if attribute.Name.IsUnknown() || attribute.Path.IsUnknown() || attribute.Required.IsUnknown() {
+			return false
+		}
+	}
+	for _, eventRule := range m.EventRules {
+		if eventRule.Conditions == nil {
+			continue
+		}
+		if eventRule.Conditions.Operator.IsUnknown() {
+			return false
+		}
+		for _, condition := range eventRule.Conditions.Operands {
+			if condition.Operator.IsUnknown() || condition.Value.IsUnknown() || condition.Path.IsUnknown() || condition.Fact.IsUnknown() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// ruleToDTO converts the attributes and event rules to their API representation.
+func (m *customRuleModel) ruleToDTO() ([]cloud_risk_management_dto.ResourceAttribute, []cloud_risk_management_dto.EventRule) {
+	attributes := make([]cloud_risk_management_dto.ResourceAttribute, len(m.Attributes))
+	for i, attr := range m.Attributes {
 		attributes[i] = cloud_risk_management_dto.ResourceAttribute{
 			Name:     attr.Name.ValueString(),
 			Path:     attr.Path.ValueString(),
@@ -280,8 +379,8 @@ func (r *customRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		}
 	}
 
-	eventRules := make([]cloud_risk_management_dto.EventRule, len(plan.EventRules))
-	for i, er := range plan.EventRules {
+	eventRules := make([]cloud_risk_management_dto.EventRule, len(m.EventRules))
+	for i, er := range m.EventRules {
 		eventRules[i] = cloud_risk_management_dto.EventRule{
 			Description: er.Description.ValueString(),
 		}
@@ -290,6 +389,28 @@ func (r *customRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		}
 	}
 
+	return attributes, eventRules
+}
+
+func (r *customRuleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan customRuleModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	var categories []string
+	diags = plan.Categories.ElementsAs(ctx, &categories, false)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	attributes, eventRules := plan.ruleToDTO()
+
 	createReq := cloud_risk_management_dto.CreateCustomRuleRequest{
 		Name:                    plan.Name.ValueString(),
 		Description:             plan.Description.ValueString(),
@@ -310,7 +431,7 @@ func (r *customRuleResource) Create(ctx context.Context, req resource.CreateRequ
 		"name": createReq.Name,
 	})
 
-	customRule, err := r.client.CreateCustomRule(&createReq)
+	customRule, err := client.CreateCustomRule(&createReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating custom rule",
@@ -334,8 +455,10 @@ func (r *customRuleResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	customRule, err := r.client.GetCustomRule(state.ID.ValueString())
-	if api.IsNotFoundError(err) {
+	client := r.client.WithCredentials(state.Credentials)
+
+	customRule, err := client.GetCustomRule(state.ID.ValueString())
+	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -401,6 +524,8 @@ func (r *customRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	var categories []string
 	diags = plan.Categories.ElementsAs(ctx, &categories, false)
 	resp.Diagnostics.Append(diags...)
@@ -408,24 +533,7 @@ func (r *customRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		return
 	}
 
-	attributes := make([]cloud_risk_management_dto.ResourceAttribute, len(plan.Attributes))
-	for i, attr := range plan.Attributes {
-		attributes[i] = cloud_risk_management_dto.ResourceAttribute{
-			Name:     attr.Name.ValueString(),
-			Path:     attr.Path.ValueString(),
-			Required: attr.Required.ValueBool(),
-		}
-	}
-
-	eventRules := make([]cloud_risk_management_dto.EventRule, len(plan.EventRules))
-	for i, er := range plan.EventRules {
-		eventRules[i] = cloud_risk_management_dto.EventRule{
-			Description: er.Description.ValueString(),
-		}
-		if er.Conditions != nil {
-			eventRules[i].Conditions = convertConditionsToDTOForEventRule(er.Conditions)
-		}
-	}
+	attributes, eventRules := plan.ruleToDTO()
 
 	enabled := plan.Enabled.ValueBool()
 	updateReq := cloud_risk_management_dto.UpdateCustomRuleRequest{
@@ -443,7 +551,7 @@ func (r *customRuleResource) Update(ctx context.Context, req resource.UpdateRequ
 		EventRules:              eventRules,
 	}
 
-	err := r.client.UpdateCustomRule(plan.ID.ValueString(), &updateReq)
+	err := client.UpdateCustomRule(plan.ID.ValueString(), &updateReq)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating custom rule",
@@ -464,7 +572,9 @@ func (r *customRuleResource) Delete(ctx context.Context, req resource.DeleteRequ
 		return
 	}
 
-	err := r.client.DeleteCustomRule(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteCustomRule(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting custom rule",
@@ -486,17 +596,7 @@ func convertConditionsToDTOForEventRule(conditions *conditionsModel) *cloud_risk
 			Operator: op.Operator.ValueString(),
 		}
 		if !op.Value.IsNull() && !op.Value.IsUnknown() {
-			raw := strings.TrimSpace(op.Value.ValueString())
-			if raw == "" {
-				condition.Value = ""
-			} else {
-				var decoded interface{}
-				if err := json.Unmarshal([]byte(raw), &decoded); err == nil {
-					condition.Value = decoded
-				} else {
-					condition.Value = raw
-				}
-			}
+			condition.Value = utils.DecodeConditionValue(op.Value.ValueString())
 		}
 		if !op.Fact.IsNull() && !op.Fact.IsUnknown() {
 			condition.Fact = op.Fact.ValueString()
@@ -7,6 +7,7 @@ import (
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
@@ -36,6 +37,8 @@ type groupResourceModel struct {
 	ID   types.String `tfsdk:"id"`
 	Name types.String `tfsdk:"name"`
 	Tags types.Set    `tfsdk:"tags"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 func NewGroupResource() resource.Resource {
@@ -62,13 +65,14 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 func (r *groupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Manages a Cloud Risk Management Group.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the group.",
 				Computed:            true,
@@ -97,6 +101,8 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Create new group plan: %+v", plan))
 
 	body := &cloud_risk_management_dto.CreateGroupRequest{
@@ -112,7 +118,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		body.Tags = tags
 	}
 
-	group, err := r.client.CreateGroup(body)
+	group, err := client.CreateGroup(body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Creating Cloud Risk Management Group",
@@ -124,7 +130,7 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	plan.ID = types.StringValue(group.ID)
 
-	fullGroup, err := r.client.GetGroup(group.ID)
+	fullGroup, err := client.GetGroup(group.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error reading created group",
@@ -154,7 +160,9 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	group, err := r.client.GetGroup(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	group, err := client.GetGroup(state.ID.ValueString())
 	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
@@ -188,6 +196,8 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Update group plan: %+v", plan))
 
 	body := &cloud_risk_management_dto.UpdateGroupRequest{
@@ -204,7 +214,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		body.Tags = tags
 	}
 
-	err := r.client.UpdateGroup(plan.ID.ValueString(), body)
+	err := client.UpdateGroup(plan.ID.ValueString(), body)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Cloud Risk Management Group",
@@ -214,7 +224,7 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Refresh state
-	group, err := r.client.GetGroup(plan.ID.ValueString())
+	group, err := client.GetGroup(plan.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Updated Group",
@@ -244,7 +254,9 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	err := r.client.DeleteGroup(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteGroup(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Deleting Cloud Risk Management Group",
@@ -8,6 +8,7 @@ import (
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
@@ -23,9 +24,10 @@ import (
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &profileResource{}
-	_ resource.ResourceWithConfigure   = &profileResource{}
-	_ resource.ResourceWithImportState = &profileResource{}
+	_ resource.Resource                   = &profileResource{}
+	_ resource.ResourceWithConfigure      = &profileResource{}
+	_ resource.ResourceWithImportState    = &profileResource{}
+	_ resource.ResourceWithValidateConfig = &profileResource{}
 )
 
 // NewProfileResource is a helper function to simplify the provider implementation.
@@ -46,6 +48,9 @@ type ProfileResourceModel struct {
 	Name        types.String          `tfsdk:"name"`
 	Description types.String          `tfsdk:"description"`
 	ScanRules   []utils.ScanRuleModel `tfsdk:"scan_rule"`
+	ProfileJSON types.String          `tfsdk:"profile_json"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 // Metadata returns the resource type name.
@@ -58,6 +63,7 @@ func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Description: "Manages a Cloud Risk Management profile with rule settings.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the profile. If provided, the resource will update the existing profile instead of creating a new one.",
 				Computed:            true,
@@ -73,6 +79,12 @@ func (r *profileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				MarkdownDescription: "The description of the profile. For removing the description, set it to an empty string; if not set explicitly, it will keep the previous value.",
 				Optional:            true,
 			},
+			"profile_json": schema.StringAttribute{
+				MarkdownDescription: "The rule settings of a profile exported as JSON, from the Vision One console or from Cloud One Conformity, " +
+					"for example `file(\"profile.json\")`. Only the rules of the document are used, `name` and `description` are set with their attributes. " +
+					"Conflicts with `scan_rule`. Use the `provider::visionone::crm_profile_export` function to export a profile.",
+				Optional: true,
+			},
 		},
 		Blocks: map[string]schema.Block{
 			"scan_rule": schema.SetNestedBlock{
@@ -104,7 +116,7 @@ func (r *profileResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -116,27 +128,27 @@ func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Create new Profile plan: %+v", plan))
 	createReq := cloud_risk_management_dto.CreateProfileRequest{
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
 	}
 
-	if len(plan.ScanRules) > 0 {
-		scanRules, err := utils.ConvertScanRulesToDTO(plan.ScanRules)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Create Profile",
-				"An error occurred converting scan rules: "+err.Error(),
-			)
-			return
-		}
-		createReq.ScanRules = scanRules
+	scanRules, err := profileScanRules(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Profile",
+			"An error occurred converting scan rules: "+err.Error(),
+		)
+		return
 	}
+	createReq.ScanRules = scanRules
 
 	tflog.Debug(ctx, fmt.Sprintf("Create new Profile request: %+v", createReq))
 
-	apiResponse, err := r.client.CreateProfile(createReq)
+	apiResponse, err := client.CreateProfile(createReq)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -156,7 +168,7 @@ func (r *profileResource) Create(ctx context.Context, req resource.CreateRequest
 
 	// Read back to get full state
 	if !plan.ID.IsNull() && plan.ID.ValueString() != "" {
-		r.readProfileAndUpdatePlan(ctx, &plan, &resp.Diagnostics, &resp.State)
+		r.readProfileAndUpdatePlan(ctx, client, &plan, &resp.Diagnostics, &resp.State, resp.Private)
 	}
 }
 
@@ -169,7 +181,9 @@ func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	profile, err := r.client.GetProfile(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	profile, err := client.GetProfile(state.ID.ValueString())
 	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
@@ -185,6 +199,14 @@ func (r *profileResource) Read(ctx context.Context, req resource.ReadRequest, re
 	}
 
 	updatePlanFromProfile(&state, profile)
+	if err := refreshProfileJSON(&state, profile); err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Read Profile",
+			"An error occurred rendering the profile as JSON: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(trendmicro.SetETag(ctx, resp.Private, profile.ETag)...)
 
 	diags = resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -199,24 +221,35 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	updateReq := cloud_risk_management_dto.UpdateProfileRequest{
 		Name:        plan.Name.ValueString(),
 		Description: plan.Description.ValueStringPointer(),
 	}
 
-	if len(plan.ScanRules) > 0 {
-		scanRules, err := utils.ConvertScanRulesToDTO(plan.ScanRules)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				"Unable to Update Profile",
-				"An error occurred converting scan rules: "+err.Error(),
-			)
-			return
-		}
-		updateReq.ScanRules = scanRules
+	scanRules, err := profileScanRules(&plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Update Profile",
+			"An error occurred converting scan rules: "+err.Error(),
+		)
+		return
 	}
+	updateReq.ScanRules = scanRules
+
+	etag, diags := trendmicro.GetETag(ctx, req.Private)
+	resp.Diagnostics.Append(diags...)
 
-	err := r.client.UpdateProfile(plan.ID.ValueString(), updateReq)
+	err = client.UpdateProfile(plan.ID.ValueString(), etag, updateReq)
+	if errors.Is(err, dto.ErrorPreconditionFailed) {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			trendmicro.ConcurrentModificationSummary,
+			trendmicro.ConcurrentModificationDetail("Cloud Risk Management profile", plan.ID.ValueString()),
+		)
+		return
+	}
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -228,7 +261,7 @@ func (r *profileResource) Update(ctx context.Context, req resource.UpdateRequest
 	}
 
 	// Read back to get updated state
-	r.readProfileAndUpdatePlan(ctx, &plan, &resp.Diagnostics, &resp.State)
+	r.readProfileAndUpdatePlan(ctx, client, &plan, &resp.Diagnostics, &resp.State, resp.Private)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -240,7 +273,9 @@ func (r *profileResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteProfile(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteProfile(state.ID.ValueString())
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -251,6 +286,35 @@ func (r *profileResource) Delete(ctx context.Context, req resource.DeleteRequest
 	}
 }
 
+// ValidateConfig checks that profile_json is a profile document and is not combined with scan_rule blocks.
+func (r *profileResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var profileJSON types.String
+	var scanRules types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("profile_json"), &profileJSON)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("scan_rule"), &scanRules)...)
+	if resp.Diagnostics.HasError() || profileJSON.IsNull() {
+		return
+	}
+
+	if scanRules.IsUnknown() || len(scanRules.Elements()) > 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile_json"),
+			"Conflicting Profile Rules",
+			"The rules of a profile are configured either with profile_json or with scan_rule blocks, not both.",
+		)
+	}
+	if profileJSON.IsUnknown() {
+		return
+	}
+	if _, err := utils.ParseProfileDocument(profileJSON.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile_json"),
+			"Invalid Profile Document",
+			"The profile_json is not an exported profile: "+err.Error(),
+		)
+	}
+}
+
 // ImportState imports the resource state.
 func (r *profileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
@@ -265,9 +329,10 @@ type stateSetter interface {
 	Set(context.Context, any) diag.Diagnostics
 }
 
-// readProfileAndUpdatePlan reads the profile from the API, updates the plan/state model, and sets the state.
-func (r *profileResource) readProfileAndUpdatePlan(ctx context.Context, plan *ProfileResourceModel, diagnostics *diag.Diagnostics, state stateSetter) {
-	profile, err := r.client.GetProfile(plan.ID.ValueString())
+// readProfileAndUpdatePlan reads the profile from the API, updates the plan/state model, and sets the state
+// and the ETag kept in private state.
+func (r *profileResource) readProfileAndUpdatePlan(ctx context.Context, client *api.CrmClient, plan *ProfileResourceModel, diagnostics *diag.Diagnostics, state stateSetter, private trendmicro.PrivateState) {
+	profile, err := client.GetProfile(plan.ID.ValueString())
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		diagnostics.AddError(
@@ -282,6 +347,7 @@ func (r *profileResource) readProfileAndUpdatePlan(ctx context.Context, plan *Pr
 	tflog.Debug(ctx, fmt.Sprintf("Profile from API: %+v", profile))
 
 	updatePlanFromProfile(plan, profile)
+	diagnostics.Append(trendmicro.SetETag(ctx, private, profile.ETag)...)
 
 	tflog.Debug(ctx, fmt.Sprintf("Plan AFTER updatePlanFromProfile: %+v", plan))
 
@@ -317,6 +383,11 @@ func updatePlanFromProfile(plan *ProfileResourceModel, profile *cloud_risk_manag
 		plan.Description = types.StringValue("")
 	}
 
+	// The rules of profile_json are compared in refreshProfileJSON
+	if !plan.ProfileJSON.IsNull() {
+		return
+	}
+
 	// Convert scan rules back
 	if len(profile.ScanRules) > 0 {
 		plan.ScanRules = make([]utils.ScanRuleModel, len(profile.ScanRules))
@@ -341,3 +412,36 @@ func updatePlanFromProfile(plan *ProfileResourceModel, profile *cloud_risk_manag
 		}
 	}
 }
+
+// profileScanRules returns the scan rules of the plan, from profile_json or the scan_rule blocks.
+func profileScanRules(plan *ProfileResourceModel) ([]cloud_risk_management_dto.ScanRule, error) {
+	if !plan.ProfileJSON.IsNull() {
+		document, err := utils.ParseProfileDocument(plan.ProfileJSON.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return document.ScanRules, nil
+	}
+	if len(plan.ScanRules) == 0 {
+		return nil, nil
+	}
+	return utils.ConvertScanRulesToDTO(plan.ScanRules)
+}
+
+// refreshProfileJSON keeps the profile_json of the state while it configures the rules of the profile, and
+// replaces it with the exported profile when the rules were changed outside of Terraform.
+func refreshProfileJSON(state *ProfileResourceModel, profile *cloud_risk_management_dto.Profile) error {
+	if state.ProfileJSON.IsNull() {
+		return nil
+	}
+	if document, err := utils.ParseProfileDocument(state.ProfileJSON.ValueString()); err == nil && utils.ProfileRulesEqual(document.ScanRules, profile.ScanRules) {
+		return nil
+	}
+
+	rendered, err := utils.RenderProfileDocument(profile)
+	if err != nil {
+		return err
+	}
+	state.ProfileJSON = types.StringValue(rendered)
+	return nil
+}
@@ -2,12 +2,15 @@ package resources
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/api"
 	"terraform-provider-vision-one/internal/trendmicro/cloud_risk_management/utils"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
@@ -54,6 +57,7 @@ func (r *reportConfigResource) Schema(_ context.Context, _ resource.SchemaReques
 	resp.Schema = schema.Schema{
 		Description: "Manages a Cloud Risk Management report configuration for scheduled or on-demand compliance reports.",
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the report configuration.",
 				Computed:            true,
@@ -335,7 +339,7 @@ func (r *reportConfigResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	r.client = api.NewCrmClient(client.HostURL, client.BearerToken, client.ProviderVersion)
+	r.client = api.NewCrmClient(client)
 }
 
 // Creates the resource and sets the initial Terraform state.
@@ -347,6 +351,8 @@ func (r *reportConfigResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Create new Report Config plan: %+v", plan))
 
 	// Convert plan to create request
@@ -361,7 +367,7 @@ func (r *reportConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	tflog.Debug(ctx, fmt.Sprintf("Create new Report Config request: %+v", createReq))
 
-	apiResponse, err := r.client.CreateReportConfig(&createReq)
+	apiResponse, err := client.CreateReportConfig(&createReq)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -377,7 +383,7 @@ func (r *reportConfigResource) Create(ctx context.Context, req resource.CreateRe
 
 	// Read back to get full state
 	if !plan.ID.IsNull() && plan.ID.ValueString() != "" {
-		reportConfig, err := r.client.GetReportConfig(plan.ID.ValueString())
+		reportConfig, err := client.GetReportConfig(plan.ID.ValueString())
 		if err != nil {
 			tflog.Debug(ctx, err.Error())
 			resp.Diagnostics.AddError(
@@ -404,8 +410,10 @@ func (r *reportConfigResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
-	reportConfig, err := r.client.GetReportConfig(state.ID.ValueString())
-	if api.IsNotFoundError(err) {
+	client := r.client.WithCredentials(state.Credentials)
+
+	reportConfig, err := client.GetReportConfig(state.ID.ValueString())
+	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -435,6 +443,8 @@ func (r *reportConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	var state ReportConfigResourceModel
 	diags = req.State.Get(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -452,7 +462,7 @@ func (r *reportConfigResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	err = r.client.UpdateReportConfig(plan.ID.ValueString(), &updateReq)
+	err = client.UpdateReportConfig(plan.ID.ValueString(), &updateReq)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -464,7 +474,7 @@ func (r *reportConfigResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 
 	// Read back to get updated state
-	reportConfig, err := r.client.GetReportConfig(plan.ID.ValueString())
+	reportConfig, err := client.GetReportConfig(plan.ID.ValueString())
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -490,7 +500,9 @@ func (r *reportConfigResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
-	err := r.client.DeleteReportConfig(state.ID.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteReportConfig(state.ID.ValueString())
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -0,0 +1,321 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+const (
+	CheckStatusSuccess = "SUCCESS"
+	CheckStatusFailure = "FAILURE"
+)
+
+// CustomRuleEvaluation is the outcome of evaluating a custom rule against one resource.
+type CustomRuleEvaluation struct {
+	Status string
+	// Failures explains why the rule failed, one entry per missing required attribute or failed event rule.
+	Failures []string
+}
+
+// EvaluateCustomRule evaluates a custom rule the way Cloud Risk Management scans do. Attribute paths are resolved
+// from the root of the resource, for example `data.BucketVersioning`, and become the facts of the conditions. The
+// rule succeeds when every event rule's conditions hold. Errors report rules that cannot be evaluated, such as
+// unknown operators or facts.
+func EvaluateCustomRule(attributes []cloud_risk_management_dto.ResourceAttribute, eventRules []cloud_risk_management_dto.EventRule, resource any, now time.Time) (*CustomRuleEvaluation, error) {
+	result := &CustomRuleEvaluation{Status: CheckStatusSuccess}
+
+	facts := map[string]any{}
+	for _, attribute := range attributes {
+		segments, err := parsePath(attribute.Path)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", attribute.Name, err)
+		}
+		value, found := resolvePath(resource, segments)
+		if !found && attribute.Required {
+			result.Failures = append(result.Failures, fmt.Sprintf("required attribute %q was not found at %s", attribute.Name, attribute.Path))
+		}
+		facts[attribute.Name] = value
+	}
+
+	for i, eventRule := range eventRules {
+		if eventRule.Conditions == nil {
+			continue
+		}
+		ok, err := evaluateCondition(cloud_risk_management_dto.Condition{All: eventRule.Conditions.All, Any: eventRule.Conditions.Any}, facts, now)
+		if err != nil {
+			return nil, fmt.Errorf("event rule %d (%s): %w", i, eventRule.Description, err)
+		}
+		if !ok {
+			result.Failures = append(result.Failures, fmt.Sprintf("event rule %q did not match", eventRule.Description))
+		}
+	}
+
+	if len(result.Failures) > 0 {
+		result.Status = CheckStatusFailure
+	}
+	return result, nil
+}
+
+func evaluateCondition(condition cloud_risk_management_dto.Condition, facts map[string]any, now time.Time) (bool, error) {
+	switch {
+	case len(condition.All) > 0:
+		for _, nested := range condition.All {
+			ok, err := evaluateCondition(nested, facts, now)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case len(condition.Any) > 0:
+		for _, nested := range condition.Any {
+			ok, err := evaluateCondition(nested, facts, now)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	}
+
+	fact, declared := facts[condition.Fact]
+	if !declared {
+		return false, fmt.Errorf("condition uses fact %q, which is not one of the attributes", condition.Fact)
+	}
+	if condition.Path != "" {
+		segments, err := parsePath(condition.Path)
+		if err != nil {
+			return false, fmt.Errorf("fact %q: %w", condition.Fact, err)
+		}
+		fact, _ = resolvePath(fact, segments)
+	}
+
+	return evaluateOperator(condition.Operator, fact, condition.Value, now)
+}
+
+// evaluateOperator follows the json-rules-engine operators scans use: operators that expect a number or an array
+// do not match other fact values.
+func evaluateOperator(operator string, fact, value any, now time.Time) (bool, error) {
+	switch operator {
+	case "equal":
+		return reflect.DeepEqual(fact, value), nil
+	case "notEqual":
+		return !reflect.DeepEqual(fact, value), nil
+	case "lessThan", "lessThanInclusive", "greaterThan", "greaterThanInclusive":
+		a, okA := toNumber(fact)
+		b, okB := toNumber(value)
+		if !okA || !okB {
+			return false, nil
+		}
+		switch operator {
+		case "lessThan":
+			return a < b, nil
+		case "lessThanInclusive":
+			return a <= b, nil
+		case "greaterThan":
+			return a > b, nil
+		default:
+			return a >= b, nil
+		}
+	case "in", "notIn":
+		values, ok := value.([]any)
+		if !ok {
+			return false, fmt.Errorf("operator %s expects an array value, got %v", operator, value)
+		}
+		return containsValue(values, fact) == (operator == "in"), nil
+	case "contains", "doesNotContain":
+		values, ok := fact.([]any)
+		if !ok {
+			return false, nil
+		}
+		return containsValue(values, value) == (operator == "contains"), nil
+	case "pattern":
+		pattern, ok := value.(string)
+		if !ok {
+			return false, fmt.Errorf("operator pattern expects a regular expression value, got %v", value)
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, fmt.Errorf("operator pattern: %w", err)
+		}
+		s, ok := fact.(string)
+		return ok && re.MatchString(s), nil
+	case "isNullOrUndefined":
+		expected, ok := value.(bool)
+		if !ok {
+			return false, fmt.Errorf("operator isNullOrUndefined expects a boolean value, got %v", value)
+		}
+		return (fact == nil) == expected, nil
+	case "dateComparison":
+		return compareDate(fact, value, now)
+	}
+	return false, fmt.Errorf("unknown operator %q", operator)
+}
+
+// compareDate checks a date fact against a value like {"days": 90, "operator": "within"}. within matches dates
+// in the last days, olderThan dates before them.
+func compareDate(fact, value any, now time.Time) (bool, error) {
+	comparison, ok := value.(map[string]any)
+	if !ok {
+		return false, fmt.Errorf("operator dateComparison expects an object value, got %v", value)
+	}
+	days, ok := toNumber(comparison["days"])
+	if !ok {
+		return false, fmt.Errorf("operator dateComparison expects a number of days, got %v", comparison["days"])
+	}
+	s, ok := fact.(string)
+	if !ok {
+		return false, nil
+	}
+	date, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return false, nil
+	}
+
+	since := now.Sub(date)
+	limit := time.Duration(days * float64(24*time.Hour))
+	switch comparison["operator"] {
+	case "within":
+		return since <= limit, nil
+	case "olderThan":
+		return since > limit, nil
+	}
+	return false, fmt.Errorf("operator dateComparison expects the operator within or olderThan, got %v", comparison["operator"])
+}
+
+func containsValue(values []any, value any) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func toNumber(value any) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// pathWildcard selects every element of an array or object.
+const pathWildcard = "*"
+
+type pathSegment struct {
+	key   string
+	index int
+	isKey bool
+}
+
+// parsePath parses the subset of JSONPath used by custom rules: an optional `$` root, `.key`, `['key']`, `[0]`
+// and `*` wildcards. Attribute paths use the same syntax without `$`, for example `data.Rules[0].Name`.
+func parsePath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	rest := strings.TrimPrefix(strings.TrimSpace(path), "$")
+	for i := 0; rest != ""; i++ {
+		switch {
+		case rest[0] == '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			segments = append(segments, pathSegment{key: rest[:end], isKey: true})
+			rest = rest[end:]
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unclosed bracket", path)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				segments = append(segments, pathSegment{key: inner[1 : len(inner)-1], isKey: true})
+			} else if inner == pathWildcard {
+				segments = append(segments, pathSegment{key: pathWildcard, isKey: true})
+			} else if index, err := strconv.Atoi(inner); err == nil {
+				segments = append(segments, pathSegment{index: index})
+			} else {
+				return nil, fmt.Errorf("invalid path %q: unsupported selector [%s]", path, inner)
+			}
+		case i == 0:
+			// Attribute paths start with a key
+			rest = "." + rest
+		default:
+			return nil, fmt.Errorf("invalid path %q at %q", path, rest)
+		}
+	}
+	return segments, nil
+}
+
+// resolvePath returns the value at the path. Wildcards return the array of the values found below them.
+func resolvePath(value any, segments []pathSegment) (any, bool) {
+	for i, segment := range segments {
+		if segment.isKey && segment.key == pathWildcard {
+			var children []any
+			switch v := value.(type) {
+			case []any:
+				children = v
+			case map[string]any:
+				for _, key := range slices.Sorted(maps.Keys(v)) {
+					children = append(children, v[key])
+				}
+			default:
+				return nil, false
+			}
+			matches := []any{}
+			for _, child := range children {
+				if resolved, found := resolvePath(child, segments[i+1:]); found {
+					matches = append(matches, resolved)
+				}
+			}
+			return matches, true
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			child, found := v[segment.key]
+			if !segment.isKey || !found {
+				return nil, false
+			}
+			value = child
+		case []any:
+			if segment.isKey || segment.index < 0 || segment.index >= len(v) {
+				return nil, false
+			}
+			value = v[segment.index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// DecodeConditionValue decodes a condition value configured as a string or with jsonencode. Values that are not
+// valid JSON are plain strings.
+func DecodeConditionValue(value string) any {
+	raw := strings.TrimSpace(value)
+	if raw == "" {
+		return ""
+	}
+	var decoded any
+	if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+		return raw
+	}
+	return decoded
+}
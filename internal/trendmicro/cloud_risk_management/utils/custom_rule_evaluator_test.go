@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+const testBucket = `{
+	"data": {
+		"BucketVersioning": {"Status": "Enabled"},
+		"Encryption": {"Rules": [{"Algorithm": "aws:kms", "KeyRotation": "7"}]},
+		"Grants": [{"Permission": "READ", "Grantee": "AllUsers"}, {"Permission": "WRITE", "Grantee": "Owner"}],
+		"Tags": ["prod", "pci"],
+		"CreatedAt": "2026-10-01T00:00:00Z",
+		"Logging": null
+	}
+}`
+
+func TestEvaluateCustomRuleOperators(t *testing.T) {
+	var bucket any
+	if err := json.Unmarshal([]byte(testBucket), &bucket); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+	attributes := []cloud_risk_management_dto.ResourceAttribute{
+		{Name: "versioning", Path: "data.BucketVersioning", Required: true},
+		{Name: "encryption", Path: "data.Encryption"},
+		{Name: "bucket", Path: "data"},
+	}
+
+	tests := []struct {
+		fact, path, operator, value string
+		want                        bool
+	}{
+		{"versioning", "$.Status", "equal", `"Enabled"`, true},
+		{"versioning", "$.Status", "notEqual", `"Enabled"`, false},
+		{"encryption", "$.Rules[0].Algorithm", "in", `["AES256", "aws:kms"]`, true},
+		{"encryption", "$.Rules[0]['Algorithm']", "notIn", `["AES256"]`, true},
+		{"encryption", "$.Rules[0].KeyRotation", "lessThanInclusive", `7`, true},
+		{"encryption", "$.Rules[0].KeyRotation", "greaterThan", `7`, false},
+		{"encryption", "$.Rules[0].Algorithm", "greaterThan", `1`, false},
+		{"bucket", "$.Tags", "contains", `"pci"`, true},
+		{"bucket", "$.Tags", "doesNotContain", `"dev"`, true},
+		{"bucket", "$.Grants[*].Grantee", "contains", `"AllUsers"`, true},
+		{"bucket", "$.Grants[1].Permission", "pattern", `"^WR"`, true},
+		{"bucket", "$.Logging", "isNullOrUndefined", `true`, true},
+		{"bucket", "$.Missing.Key", "isNullOrUndefined", `true`, true},
+		{"bucket", "$.CreatedAt", "dateComparison", `{"days": 30, "operator": "within"}`, true},
+		{"bucket", "$.CreatedAt", "dateComparison", `{"days": 10, "operator": "olderThan"}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operator+" "+tt.path, func(t *testing.T) {
+			var value any
+			if err := json.Unmarshal([]byte(tt.value), &value); err != nil {
+				t.Fatal(err)
+			}
+			eventRules := []cloud_risk_management_dto.EventRule{{Description: "test", Conditions: &cloud_risk_management_dto.Conditions{
+				All: []cloud_risk_management_dto.Condition{{Fact: tt.fact, Path: tt.path, Operator: tt.operator, Value: value}},
+			}}}
+			result, err := EvaluateCustomRule(attributes, eventRules, bucket, now)
+			if err != nil {
+				t.Fatalf("EvaluateCustomRule: %v", err)
+			}
+			if got := result.Status == CheckStatusSuccess; got != tt.want {
+				t.Errorf("got %s %v, want success %v", result.Status, result.Failures, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateCustomRuleNesting(t *testing.T) {
+	var bucket any
+	if err := json.Unmarshal([]byte(testBucket), &bucket); err != nil {
+		t.Fatal(err)
+	}
+	attributes := []cloud_risk_management_dto.ResourceAttribute{
+		{Name: "versioning", Path: "data.BucketVersioning", Required: true},
+		{Name: "lifecycle", Path: "data.Lifecycle", Required: true},
+	}
+	eventRules := []cloud_risk_management_dto.EventRule{
+		{Description: "versioning or suspended", Conditions: &cloud_risk_management_dto.Conditions{Any: []cloud_risk_management_dto.Condition{
+			{Fact: "versioning", Path: "$.Status", Operator: "equal", Value: "Suspended"},
+			{All: []cloud_risk_management_dto.Condition{
+				{Fact: "versioning", Path: "$.Status", Operator: "equal", Value: "Enabled"},
+				{Fact: "versioning", Path: "$.MFADelete", Operator: "isNullOrUndefined", Value: true},
+			}},
+		}}},
+		{Description: "suspended", Conditions: &cloud_risk_management_dto.Conditions{All: []cloud_risk_management_dto.Condition{
+			{Fact: "versioning", Path: "$.Status", Operator: "equal", Value: "Suspended"},
+		}}},
+	}
+
+	result, err := EvaluateCustomRule(attributes, eventRules, bucket, time.Now())
+	if err != nil {
+		t.Fatalf("EvaluateCustomRule: %v", err)
+	}
+	if result.Status != CheckStatusFailure || len(result.Failures) != 2 ||
+		!strings.Contains(result.Failures[0], "lifecycle") || !strings.Contains(result.Failures[1], "suspended") {
+		t.Errorf("unexpected result %+v", result)
+	}
+
+	eventRules[0].Conditions.Any[0].Operator = "startsWith"
+	if _, err := EvaluateCustomRule(attributes, eventRules, bucket, time.Now()); err == nil || !strings.Contains(err.Error(), "startsWith") {
+		t.Errorf("expected an unknown operator error, got %v", err)
+	}
+	eventRules[0].Conditions.Any[0] = cloud_risk_management_dto.Condition{Fact: "undeclared", Operator: "equal"}
+	if _, err := EvaluateCustomRule(attributes, eventRules, bucket, time.Now()); err == nil || !strings.Contains(err.Error(), "undeclared") {
+		t.Errorf("expected an unknown fact error, got %v", err)
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	if _, err := parsePath("$.Rules[0"); err == nil {
+		t.Error("expected an error for an unclosed bracket")
+	}
+	if _, err := parsePath("$.Rules[?(@.x)]"); err == nil {
+		t.Error("expected an error for a filter expression")
+	}
+	segments, err := parsePath(`data.Rules[2]["Name"]`)
+	if err != nil {
+		t.Fatalf("parsePath: %v", err)
+	}
+	keys := []string{}
+	for _, segment := range segments {
+		keys = append(keys, segment.key)
+	}
+	if !slices.Equal(keys, []string{"data", "Rules", "", "Name"}) || segments[2].index != 2 {
+		t.Errorf("unexpected segments %+v", segments)
+	}
+}
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
+)
+
+// conformityProfileDocument is a profile exported from Cloud One Conformity, in the JSON:API format with the rule
+// settings in included.
+type conformityProfileDocument struct {
+	Data struct {
+		Attributes struct {
+			Name        string  `json:"name"`
+			Description *string `json:"description"`
+		} `json:"attributes"`
+	} `json:"data"`
+	Included []struct {
+		Type       string `json:"type"`
+		ID         string `json:"id"`
+		Attributes struct {
+			Provider      string                                       `json:"provider"`
+			Enabled       bool                                         `json:"enabled"`
+			RiskLevel     string                                       `json:"riskLevel"`
+			ExtraSettings []cloud_risk_management_dto.RuleExtraSetting `json:"extraSettings"`
+			Exceptions    *struct {
+				FilterTags []string `json:"filterTags"`
+				Tags       []string `json:"tags"`
+				Resources  []string `json:"resources"`
+			} `json:"exceptions"`
+		} `json:"attributes"`
+	} `json:"included"`
+}
+
+// ParseProfileDocument parses an exported profile. It accepts the format the Vision One console exports, which is
+// the format of the API with the rules in scanRules, and the format Cloud One Conformity exports, with the rule
+// settings in included. Values of extra settings may be plain values or objects with a value.
+func ParseProfileDocument(document string) (*cloud_risk_management_dto.Profile, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return nil, fmt.Errorf("the profile is not a JSON object: %w", err)
+	}
+
+	profile := &cloud_risk_management_dto.Profile{}
+	if _, ok := raw["data"]; ok {
+		var conformity conformityProfileDocument
+		if err := json.Unmarshal([]byte(document), &conformity); err != nil {
+			return nil, fmt.Errorf("the profile is not a Conformity profile: %w", err)
+		}
+		profile.Name = conformity.Data.Attributes.Name
+		profile.Description = conformity.Data.Attributes.Description
+		for _, included := range conformity.Included {
+			if included.Type != "" && included.Type != "rules" {
+				continue
+			}
+			rule := cloud_risk_management_dto.ScanRule{
+				ID:            included.ID,
+				Provider:      included.Attributes.Provider,
+				Enabled:       included.Attributes.Enabled,
+				RiskLevel:     included.Attributes.RiskLevel,
+				ExtraSettings: included.Attributes.ExtraSettings,
+			}
+			if exceptions := included.Attributes.Exceptions; exceptions != nil {
+				// Older exports only have tags
+				filterTags := exceptions.FilterTags
+				if filterTags == nil {
+					filterTags = exceptions.Tags
+				}
+				rule.Exceptions = &cloud_risk_management_dto.RuleExceptions{FilterTags: filterTags, ResourceIds: exceptions.Resources}
+			}
+			profile.ScanRules = append(profile.ScanRules, rule)
+		}
+	} else if _, ok := raw["scanRules"]; ok || raw["name"] != nil {
+		if err := json.Unmarshal([]byte(document), profile); err != nil {
+			return nil, fmt.Errorf("the profile is not a Vision One profile: %w", err)
+		}
+		profile.ID = ""
+	} else {
+		return nil, fmt.Errorf("the profile has neither the scanRules of a Vision One profile nor the data of a Conformity profile")
+	}
+
+	seen := map[string]bool{}
+	for i := range profile.ScanRules {
+		rule := &profile.ScanRules[i]
+		if rule.ID == "" || rule.Provider == "" {
+			return nil, fmt.Errorf("rule %d: every rule needs an id and a provider", i)
+		}
+		key := rule.Provider + "/" + rule.ID
+		if seen[key] {
+			return nil, fmt.Errorf("rule %s of %s is configured more than once", rule.ID, rule.Provider)
+		}
+		seen[key] = true
+		for j := range rule.ExtraSettings {
+			normalizeExtraSettingValues(&rule.ExtraSettings[j])
+		}
+	}
+	return profile, nil
+}
+
+// normalizeExtraSettingValues wraps plain values in the objects the API expects, like value_set does.
+func normalizeExtraSettingValues(setting *cloud_risk_management_dto.RuleExtraSetting) {
+	if setting.Values == nil {
+		return
+	}
+	values := make([]any, len(*setting.Values))
+	for i, value := range *setting.Values {
+		if _, ok := value.(map[string]any); ok {
+			values[i] = value
+		} else {
+			values[i] = map[string]any{"value": value}
+		}
+	}
+	setting.Values = &values
+}
+
+// RenderProfileDocument renders a profile in the format the Vision One console exports. Rules are sorted by
+// provider and ID so the document is stable.
+func RenderProfileDocument(profile *cloud_risk_management_dto.Profile) (string, error) {
+	document := cloud_risk_management_dto.Profile{
+		Name:        profile.Name,
+		Description: profile.Description,
+		ScanRules:   normalizeScanRules(profile.ScanRules),
+	}
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// ProfileRulesEqual reports whether two profiles configure the same rules, ignoring their order, the deprecated
+// flag and empty exceptions or extra settings.
+func ProfileRulesEqual(a, b []cloud_risk_management_dto.ScanRule) bool {
+	normalized := func(rules []cloud_risk_management_dto.ScanRule) any {
+		encoded, err := json.Marshal(normalizeScanRules(rules))
+		if err != nil {
+			return nil
+		}
+		var decoded any
+		if err := json.Unmarshal(encoded, &decoded); err != nil {
+			return nil
+		}
+		return decoded
+	}
+	return reflect.DeepEqual(normalized(a), normalized(b))
+}
+
+func normalizeScanRules(rules []cloud_risk_management_dto.ScanRule) []cloud_risk_management_dto.ScanRule {
+	result := make([]cloud_risk_management_dto.ScanRule, len(rules))
+	for i, rule := range rules {
+		rule.Deprecated = false
+		if rule.Exceptions != nil && len(rule.Exceptions.FilterTags) == 0 && len(rule.Exceptions.ResourceIds) == 0 {
+			rule.Exceptions = nil
+		}
+		if len(rule.ExtraSettings) == 0 {
+			rule.ExtraSettings = nil
+		} else {
+			rule.ExtraSettings = slices.Clone(rule.ExtraSettings)
+			for j := range rule.ExtraSettings {
+				normalizeExtraSettingValues(&rule.ExtraSettings[j])
+			}
+			slices.SortStableFunc(rule.ExtraSettings, func(a, b cloud_risk_management_dto.RuleExtraSetting) int {
+				return strings.Compare(a.Name, b.Name)
+			})
+		}
+		result[i] = rule
+	}
+	slices.SortStableFunc(result, func(a, b cloud_risk_management_dto.ScanRule) int {
+		if c := strings.Compare(a.Provider, b.Provider); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return result
+}
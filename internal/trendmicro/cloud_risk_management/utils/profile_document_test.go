@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+const testConformityProfile = `{
+	"data": {
+		"type": "profiles",
+		"attributes": {"name": "Baseline", "description": "Exported from Conformity"},
+		"relationships": {"ruleSettings": {"data": [{"type": "rules", "id": "S3-001"}, {"type": "rules", "id": "EC2-001"}]}}
+	},
+	"included": [
+		{"type": "rules", "id": "S3-001", "attributes": {
+			"provider": "aws", "enabled": true, "riskLevel": "HIGH",
+			"exceptions": {"filterTags": ["env::dev"], "resources": [], "tags": []},
+			"extraSettings": [
+				{"name": "regions", "type": "regions", "values": ["us-east-1", "eu-west-1"]},
+				{"name": "ttl", "type": "ttl", "value": 72}
+			]}},
+		{"type": "rules", "id": "EC2-001", "attributes": {
+			"provider": "aws", "enabled": false, "riskLevel": "MEDIUM",
+			"extraSettings": [
+				{"name": "instanceTypes", "type": "multiple-string-values", "values": [{"value": "t2.micro"}]},
+				{"name": "checks", "type": "choice-multiple-value", "values": [{"value": "public", "enabled": true}, {"value": "private", "enabled": false}]}
+			]}}
+	]
+}`
+
+func TestParseConformityProfileDocument(t *testing.T) {
+	profile, err := ParseProfileDocument(testConformityProfile)
+	if err != nil {
+		t.Fatalf("ParseProfileDocument: %v", err)
+	}
+	if profile.Name != "Baseline" || profile.Description == nil || len(profile.ScanRules) != 2 {
+		t.Fatalf("unexpected profile %+v", profile)
+	}
+
+	s3 := profile.ScanRules[0]
+	if s3.ID != "S3-001" || s3.Provider != "aws" || !s3.Enabled || s3.RiskLevel != "HIGH" {
+		t.Errorf("unexpected rule %+v", s3)
+	}
+	if s3.Exceptions == nil || len(s3.Exceptions.FilterTags) != 1 || s3.Exceptions.FilterTags[0] != "env::dev" {
+		t.Errorf("unexpected exceptions %+v", s3.Exceptions)
+	}
+	regions := *s3.ExtraSettings[0].Values
+	if len(regions) != 2 || regions[0].(map[string]any)["value"] != "us-east-1" {
+		t.Errorf("plain values should be wrapped in objects, got %v", regions)
+	}
+	if s3.ExtraSettings[1].Value != float64(72) {
+		t.Errorf("unexpected ttl %v", s3.ExtraSettings[1].Value)
+	}
+
+	rendered, err := RenderProfileDocument(profile)
+	if err != nil {
+		t.Fatalf("RenderProfileDocument: %v", err)
+	}
+	if strings.Index(rendered, `"EC2-001"`) > strings.Index(rendered, `"S3-001"`) {
+		t.Errorf("rules should be sorted by ID:\n%s", rendered)
+	}
+	reparsed, err := ParseProfileDocument(rendered)
+	if err != nil {
+		t.Fatalf("ParseProfileDocument of the rendered profile: %v", err)
+	}
+	if reparsed.Name != "Baseline" || !ProfileRulesEqual(profile.ScanRules, reparsed.ScanRules) {
+		t.Errorf("the rendered profile does not parse back to the same rules:\n%s", rendered)
+	}
+
+	reparsed.ScanRules[1].ExtraSettings[0].Value = float64(24)
+	if ProfileRulesEqual(profile.ScanRules, reparsed.ScanRules) {
+		t.Error("a changed ttl should not be equal")
+	}
+}
+
+func TestParseProfileDocumentErrors(t *testing.T) {
+	tests := map[string]string{
+		"not json":      `[`,
+		"unknown":       `{"rules": []}`,
+		"no provider":   `{"name": "p", "scanRules": [{"id": "S3-001"}]}`,
+		"duplicate":     `{"name": "p", "scanRules": [{"id": "S3-001", "provider": "aws"}, {"id": "S3-001", "provider": "aws"}]}`,
+		"invalid rules": `{"name": "p", "scanRules": {}}`,
+	}
+	for name, document := range tests {
+		if _, err := ParseProfileDocument(document); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	profile, err := ParseProfileDocument(`{"name": "p", "scanRules": [{"id": "S3-001", "provider": "aws", "enabled": true, "riskLevel": "LOW", "exceptions": {"tags": [], "resourceIds": []}}]}`)
+	if err != nil {
+		t.Fatalf("ParseProfileDocument: %v", err)
+	}
+	withoutExceptions, _ := ParseProfileDocument(`{"name": "p", "scanRules": [{"id": "S3-001", "provider": "aws", "enabled": true, "riskLevel": "LOW", "deprecated": true}]}`)
+	if !ProfileRulesEqual(profile.ScanRules, withoutExceptions.ScanRules) {
+		t.Error("empty exceptions and the deprecated flag should be ignored")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"terraform-provider-vision-one/pkg/dto"
 	cloud_risk_management_dto "terraform-provider-vision-one/pkg/dto/cloud_risk_management"
 
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -30,6 +31,8 @@ type ReportConfigResourceModel struct {
 	ControlsType                types.String    `tfsdk:"controls_type"`
 	Schedule                    []ScheduleModel `tfsdk:"schedule"`
 	ChecksFilter                []FilterModel   `tfsdk:"checks_filter"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type ScheduleModel struct {
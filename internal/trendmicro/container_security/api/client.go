@@ -1,7 +1,18 @@
 package api
 
-import "terraform-provider-vision-one/internal/trendmicro"
+import (
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
+)
 
 type CsClient struct {
 	Client *trendmicro.Client
 }
+
+// WithCredentials returns a client for the tenant named by override, or c when it is unset.
+func (c *CsClient) WithCredentials(override *dto.CredentialsOverrideModel) *CsClient {
+	if override == nil {
+		return c
+	}
+	return &CsClient{Client: c.Client.WithCredentialsOverride(override.ApiKey.ValueString(), override.RegFQDN.ValueString())}
+}
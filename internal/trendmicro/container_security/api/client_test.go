@@ -0,0 +1,296 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newTestCsClient(t *testing.T) (*CsClient, *mockserver.Server) {
+	server := mockserver.NewTestServer(t)
+	return &CsClient{Client: &trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	}}, server
+}
+
+func TestClusterLifecycle(t *testing.T) {
+	client, server := newTestCsClient(t)
+
+	created, err := client.CreateCluster(&dto.CreateClusterRequest{Name: "prod", Description: "production"})
+	if err != nil {
+		t.Fatalf("CreateCluster: %v", err)
+	}
+	if created.ID == "" || created.ApiKey == "" || created.Endpoint == "" {
+		t.Fatalf("CreateCluster returned incomplete response %+v", created)
+	}
+
+	if err := client.UpdateCluster(created.ID, &dto.UpdateClusterRequest{Description: "updated", PolicyId: "policy-1"}); err != nil {
+		t.Fatalf("UpdateCluster: %v", err)
+	}
+
+	got, err := client.GetCluster(&dto.GetClusterRequest{ID: created.ID})
+	if err != nil {
+		t.Fatalf("GetCluster: %v", err)
+	}
+	if got.Item.Name != "prod" || got.Item.Description != "updated" || got.Item.PolicyId != "policy-1" {
+		t.Errorf("unexpected cluster after update: %+v", got.Item)
+	}
+
+	list, err := client.GetClusterList(context.Background())
+	if err != nil {
+		t.Fatalf("GetClusterList: %v", err)
+	}
+	if len(list.Items) != 1 || list.Items[0].ID != created.ID {
+		t.Errorf("unexpected cluster list: %+v", list.Items)
+	}
+
+	if err := client.DeleteCluster(&dto.DeleteClusterRequest{ID: created.ID}); err != nil {
+		t.Fatalf("DeleteCluster: %v", err)
+	}
+	if _, ok := server.Get(mockserver.KubernetesClustersPath, created.ID); ok {
+		t.Error("cluster still stored after delete")
+	}
+
+	_, err = client.GetCluster(&dto.GetClusterRequest{ID: created.ID})
+	var apiErr *trendmicro.APIError
+	if !errors.Is(err, dto.ErrorNotFound) || !errors.As(err, &apiErr) || apiErr.TraceID == "" {
+		t.Errorf("expected a traced NotFound error after delete, got %v", err)
+	}
+}
+
+func TestClusterGroupsAndTags(t *testing.T) {
+	client, server := newTestCsClient(t)
+
+	group, err := client.CreateClusterGroup(&dto.ClusterGroupRequest{Name: "payments", Description: "payment clusters"})
+	if err != nil {
+		t.Fatalf("CreateClusterGroup: %v", err)
+	}
+	group, err = client.UpdateClusterGroup(group.ID, &dto.ClusterGroupRequest{Name: "payments-prod", Description: "payment clusters"})
+	if err != nil {
+		t.Fatalf("UpdateClusterGroup: %v", err)
+	}
+	groups, err := client.ListClusterGroups(context.Background())
+	if err != nil {
+		t.Fatalf("ListClusterGroups: %v", err)
+	}
+	if len(groups) != 1 || groups[0].ID != group.ID || groups[0].Name != "payments-prod" {
+		t.Errorf("unexpected cluster groups %+v", groups)
+	}
+
+	tag, err := client.CreateCustomizableTag(&dto.CustomizableTagRequest{Key: "environment", Value: "production"})
+	if err != nil {
+		t.Fatalf("CreateCustomizableTag: %v", err)
+	}
+	if tag.ID == "" || tag.Key != "environment" || tag.Value != "production" {
+		t.Errorf("unexpected tag %+v", tag)
+	}
+
+	cluster, err := client.CreateCluster(&dto.CreateClusterRequest{Name: "prod", GroupId: group.ID, CustomizableTagIDs: []string{tag.ID}})
+	if err != nil {
+		t.Fatalf("CreateCluster: %v", err)
+	}
+	state := dto.ClusterResourceModel{ID: types.StringValue(cluster.ID), CustomizableTagIDs: types.SetNull(types.ObjectType{})}
+	if err := client.UpdateCurrentState(&state); err != nil {
+		t.Fatalf("UpdateCurrentState: %v", err)
+	}
+	if state.GroupId.ValueString() != group.ID || len(state.CustomizableTagIDs.Elements()) != 1 ||
+		state.CustomizableTagIDs.Elements()[0].(types.Object).Attributes()["id"].(types.String).ValueString() != tag.ID {
+		t.Errorf("group and tags not read back: %s %s", state.GroupId, state.CustomizableTagIDs)
+	}
+
+	if err := client.DeleteCustomizableTag(tag.ID); err != nil {
+		t.Fatalf("DeleteCustomizableTag: %v", err)
+	}
+	if _, ok := server.Get(mockserver.CustomTagsPath, tag.ID); ok {
+		t.Error("tag still stored after delete")
+	}
+}
+
+func TestEcsClusterPolicyAssignment(t *testing.T) {
+	client, server := newTestCsClient(t)
+	arn := "arn:aws:ecs:us-east-1:123456789012:cluster/payments"
+	server.Seed(mockserver.AmazonEcsClustersPath, mockserver.Object{"id": "ecs-1", "name": "payments", "awsAccountId": "123456789012", "region": "us-east-1", "clusterArn": arn})
+
+	discovered, err := client.GetEcsClusterByArn(context.Background(), arn)
+	if err != nil {
+		t.Fatalf("GetEcsClusterByArn: %v", err)
+	}
+	if _, err := client.GetEcsClusterByArn(context.Background(), arn+"-missing"); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("expected NotFound for an unknown ARN, got %v", err)
+	}
+
+	updated, err := client.UpdateEcsCluster(discovered.ID, &dto.UpdateEcsClusterRequest{PolicyId: "policy-1", RuntimeSecurityEnabled: true})
+	if err != nil {
+		t.Fatalf("UpdateEcsCluster: %v", err)
+	}
+	if updated.PolicyId != "policy-1" || !updated.RuntimeSecurityEnabled || updated.VulnerabilityScanEnabled || updated.Region != "us-east-1" {
+		t.Errorf("unexpected cluster after update %+v", updated)
+	}
+
+	reset, err := client.UpdateEcsCluster(discovered.ID, &dto.UpdateEcsClusterRequest{})
+	if err != nil {
+		t.Fatalf("UpdateEcsCluster: %v", err)
+	}
+	if reset.PolicyId != "" || reset.RuntimeSecurityEnabled {
+		t.Errorf("expected the policy to be unassigned, got %+v", reset)
+	}
+}
+
+func TestRegenerateClusterAPIKey(t *testing.T) {
+	client, _ := newTestCsClient(t)
+
+	created, err := client.CreateCluster(&dto.CreateClusterRequest{Name: "prod"})
+	if err != nil {
+		t.Fatalf("CreateCluster: %v", err)
+	}
+	first, err := client.RegenerateClusterAPIKey(created.ID)
+	if err != nil {
+		t.Fatalf("RegenerateClusterAPIKey: %v", err)
+	}
+	second, err := client.RegenerateClusterAPIKey(created.ID)
+	if err != nil {
+		t.Fatalf("RegenerateClusterAPIKey: %v", err)
+	}
+	if first.ApiKey == "" || first.ApiKey == created.ApiKey || first.ApiKey == second.ApiKey {
+		t.Errorf("expected a new key on every rotation, got %q, %q and %q", created.ApiKey, first.ApiKey, second.ApiKey)
+	}
+
+	if _, err := client.RegenerateClusterAPIKey("missing"); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("expected NotFound for a missing cluster, got %v", err)
+	}
+}
+
+func TestRulesetCreateFollowsLocation(t *testing.T) {
+	client, _ := newTestCsClient(t)
+
+	created, err := client.CreateRuleset(&dto.CreateRulesetRequest{Name: "audit", Description: "audit rules"})
+	if err != nil {
+		t.Fatalf("CreateRuleset: %v", err)
+	}
+	if created.Id == "" || created.Name != "audit" || created.CreatedDateTime == "" {
+		t.Fatalf("unexpected ruleset %+v", created)
+	}
+
+	updated, err := client.UpdateRuleset(created.Id, &dto.CreateRulesetRequest{Name: "audit", Description: "changed"})
+	if err != nil {
+		t.Fatalf("UpdateRuleset: %v", err)
+	}
+	if updated.Description != "changed" {
+		t.Errorf("UpdateRuleset did not read back the change: %+v", updated)
+	}
+
+	if err := client.DeleteRuleset(created.Id); err != nil {
+		t.Fatalf("DeleteRuleset: %v", err)
+	}
+	if err := client.DeleteRuleset(created.Id); !errors.Is(err, dto.ErrorNotFound) {
+		t.Errorf("deleting twice should report NotFound, got %v", err)
+	}
+}
+
+func TestRulesetCustomRules(t *testing.T) {
+	client, _ := newTestCsClient(t)
+
+	customRule := dto.CustomRule{
+		Name:      "netcat in container",
+		Condition: "spawned_process and container and proc.name = nc",
+		Output:    "netcat started (container=%container.id)",
+		Priority:  "warning",
+		Tags:      []string{"T1059"},
+	}
+	created, err := client.CreateRuleset(&dto.CreateRulesetRequest{Name: "custom", CustomRules: []dto.CustomRule{customRule}})
+	if err != nil {
+		t.Fatalf("CreateRuleset: %v", err)
+	}
+	if len(created.CustomRules) != 1 || created.CustomRules[0].Condition != customRule.Condition || created.CustomRules[0].Tags[0] != "T1059" {
+		t.Fatalf("custom rules not read back: %+v", created.CustomRules)
+	}
+
+	updated, err := client.UpdateRuleset(created.Id, &dto.CreateRulesetRequest{Name: "custom", CustomRules: []dto.CustomRule{}})
+	if err != nil {
+		t.Fatalf("UpdateRuleset: %v", err)
+	}
+	if len(updated.CustomRules) != 0 {
+		t.Errorf("expected the custom rules to be removed, got %+v", updated.CustomRules)
+	}
+}
+
+func TestListManagedRules(t *testing.T) {
+	client, server := newTestCsClient(t)
+	for i, tags := range [][]any{{"T1059", "execution"}, {"T1611"}} {
+		server.Seed(mockserver.ManagedRulesPath, mockserver.Object{
+			"id":         fmt.Sprintf("TM-00000%d", i+1),
+			"name":       fmt.Sprintf("rule %d", i+1),
+			"tags":       tags,
+			"mitigation": "log",
+		})
+	}
+
+	rules, err := client.ListManagedRules(context.Background())
+	if err != nil {
+		t.Fatalf("ListManagedRules: %v", err)
+	}
+	if len(rules) != 2 || rules[0].ID != "TM-000001" || rules[0].Tags[0] != "T1059" || rules[1].Mitigation != "log" {
+		t.Errorf("unexpected managed rules %+v", rules)
+	}
+}
+
+func TestPolicyCreateRejectsWrongKey(t *testing.T) {
+	client, server := newTestCsClient(t)
+	server.SetAPIKey("rotated")
+
+	_, err := client.CreatePolicy(&dto.CreatePolicyRequest{Name: "default"})
+	if !errors.Is(err, dto.Unauthorized) {
+		t.Fatalf("expected Unauthorized, got %v", err)
+	}
+	if len(server.List(mockserver.PoliciesPath)) != 0 {
+		t.Error("policy created despite the rejected key")
+	}
+}
+
+func TestUpdatePolicyIfMatch(t *testing.T) {
+	client, server := newTestCsClient(t)
+	server.Seed(mockserver.PoliciesPath, mockserver.Object{"id": "p1", "name": "default", "description": "v1"})
+
+	read, err := client.GetPolicy("p1")
+	if err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	if read.ETag == "" {
+		t.Fatal("GetPolicy did not capture the ETag")
+	}
+
+	// Someone else changes the policy after Terraform read it.
+	if _, err := client.UpdatePolicy("p1", "", &dto.UpdatePolicyRequest{Description: "console edit"}); err != nil {
+		t.Fatalf("unconditional UpdatePolicy: %v", err)
+	}
+
+	_, err = client.UpdatePolicy("p1", read.ETag, &dto.UpdatePolicyRequest{Description: "terraform"})
+	if !errors.Is(err, dto.ErrorPreconditionFailed) {
+		t.Fatalf("expected PreconditionFailed for a stale ETag, got %v", err)
+	}
+	if stored, _ := server.Get(mockserver.PoliciesPath, "p1"); stored["description"] != "console edit" {
+		t.Errorf("stale update overwrote the console edit: %v", stored["description"])
+	}
+
+	fresh, err := client.GetPolicy("p1")
+	if err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	updated, err := client.UpdatePolicy("p1", fresh.ETag, &dto.UpdatePolicyRequest{Description: "terraform"})
+	if err != nil {
+		t.Fatalf("UpdatePolicy with the current ETag: %v", err)
+	}
+	if updated.Description != "terraform" || updated.ETag == fresh.ETag {
+		t.Errorf("unexpected policy after update %+v", updated)
+	}
+}
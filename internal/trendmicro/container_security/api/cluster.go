@@ -2,12 +2,14 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"path"
+	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/pkg/dto"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
@@ -57,44 +59,58 @@ func (c *CsClient) CreateCluster(data *dto.CreateClusterRequest) (*dto.CreateClu
 	return &createClusterResponse, nil
 }
 
-func (c *CsClient) GetClusterList() (*dto.ListClusterResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusters", c.Client.HostURL), http.NoBody)
+// GetClusterList returns every Kubernetes cluster, following nextLink across pages.
+func (c *CsClient) GetClusterList(ctx context.Context) (*dto.ListClusterResponse, error) {
+	items, err := trendmicro.ListAll[dto.ClusterItem](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusters", c.Client.HostURL), trendmicro.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.Client.DoRequest(req)
+	return &dto.ListClusterResponse{Items: items, TotalCount: len(items), Count: len(items)}, nil
+}
+
+func (c *CsClient) GetCluster(data *dto.GetClusterRequest) (resp *dto.GetClusterResponse, err error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusters/%s", c.Client.HostURL, data.ID), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
-	resp := dto.ListClusterResponse{}
-	err = json.Unmarshal(body, &resp)
+	body, err := c.Client.DoRequest(req)
+	if err != nil {
+		return resp, err
+	}
+
+	resp = &dto.GetClusterResponse{}
+	err = json.Unmarshal(body, &resp.Item)
 	if err != nil {
 		return nil, err
 	}
 
-	return &resp, nil
+	return resp, nil
 }
 
-func (c *CsClient) GetCluster(data *dto.GetClusterRequest) (resp *dto.GetClusterResponse, err error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusters/%s", c.Client.HostURL, data.ID), http.NoBody)
+// RegenerateClusterAPIKey replaces the API key the cluster agents enroll with and returns the new
+// key. The previous key stops working.
+func (c *CsClient) RegenerateClusterAPIKey(clusterID string) (*dto.RegenerateClusterAPIKeyResponse, error) {
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusters/%s/regenerateApiKey", c.Client.HostURL, clusterID), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
 	body, err := c.Client.DoRequest(req)
 	if err != nil {
-		return resp, err
+		return nil, err
 	}
 
-	resp = &dto.GetClusterResponse{}
-	err = json.Unmarshal(body, &resp.Item)
-	if err != nil {
+	resp := dto.RegenerateClusterAPIKeyResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
 		return nil, err
 	}
+	if resp.ApiKey == "" {
+		return nil, fmt.Errorf("no API key returned for cluster %s", clusterID)
+	}
 
-	return resp, nil
+	return &resp, nil
 }
 
 func (c *CsClient) UpdateCurrentState(resource *dto.ClusterResourceModel) error {
@@ -108,18 +124,33 @@ func (c *CsClient) UpdateCurrentState(resource *dto.ClusterResourceModel) error
 		resource.Description = types.StringValue(latest.Item.Description)
 	}
 	resource.Orchestrator = types.StringValue(latest.Item.Orchestrator)
+	// Imported clusters take the features their agents run, configured ones keep the toggles of their Helm values
+	if resource.RuntimeSecurityEnabled.IsNull() {
+		resource.RuntimeSecurityEnabled = types.BoolValue(latest.Item.RuntimeSecurityEnabled)
+	}
+	if resource.VulnerabilityScanEnabled.IsNull() {
+		resource.VulnerabilityScanEnabled = types.BoolValue(latest.Item.VulnerabilityScanEnabled)
+	}
+	if resource.MalwareScanEnabled.IsNull() {
+		resource.MalwareScanEnabled = types.BoolValue(latest.Item.MalwareScanEnabled)
+	}
 	if latest.Item.PolicyId != "" {
 		resource.PolicyId = types.StringValue(latest.Item.PolicyId)
 	}
 	if latest.Item.ResourceId != "" {
 		resource.ResourceId = types.StringValue(latest.Item.ResourceId)
 	}
-	if latest.Item.CustomizableTagIDs != nil {
+	if latest.Item.GroupId != "" {
+		resource.GroupId = types.StringValue(latest.Item.GroupId)
+	}
+	// Keep an unset customizable_tags unset when the cluster has no tags
+	if len(latest.Item.CustomizableTagIDs) > 0 || (!resource.CustomizableTagIDs.IsNull() && !resource.CustomizableTagIDs.IsUnknown()) {
+		tagType := types.ObjectType{AttrTypes: map[string]attr.Type{"id": types.StringType}}
 		values := make([]attr.Value, len(latest.Item.CustomizableTagIDs))
 		for i, v := range latest.Item.CustomizableTagIDs {
-			values[i] = types.StringValue(v)
+			values[i] = types.ObjectValueMust(tagType.AttrTypes, map[string]attr.Value{"id": types.StringValue(v)})
 		}
-		resource.CustomizableTagIDs = types.SetValueMust(types.StringType, values)
+		resource.CustomizableTagIDs = types.SetValueMust(tagType, values)
 	}
 	resource.CreatedDateTime = types.StringValue(latest.Item.CreatedDateTime)
 	resource.UpdatedDateTime = types.StringValue(latest.Item.UpdatedDateTime)
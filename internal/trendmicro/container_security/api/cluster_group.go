@@ -0,0 +1,89 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+func (c *CsClient) CreateClusterGroup(data *dto.ClusterGroupRequest) (*dto.ClusterGroupResponse, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusterGroups", c.Client.HostURL), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.DoRequestWithFullResponse(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	groupID, err := c.Client.ExtractIDFromLocationHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetClusterGroup(groupID)
+}
+
+// ListClusterGroups returns every Kubernetes cluster group, following nextLink across pages.
+func (c *CsClient) ListClusterGroups(ctx context.Context) ([]dto.ClusterGroupResponse, error) {
+	return trendmicro.ListAll[dto.ClusterGroupResponse](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusterGroups", c.Client.HostURL), trendmicro.ListOptions{})
+}
+
+func (c *CsClient) GetClusterGroup(id string) (*dto.ClusterGroupResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusterGroups/%s", c.Client.HostURL, id), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.Client.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.ClusterGroupResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *CsClient) UpdateClusterGroup(id string, data *dto.ClusterGroupRequest) (*dto.ClusterGroupResponse, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusterGroups/%s", c.Client.HostURL, id), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.Client.DoRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.GetClusterGroup(id)
+}
+
+// DeleteClusterGroup deletes an empty cluster group. Vision One rejects deleting a group that still has clusters.
+func (c *CsClient) DeleteClusterGroup(id string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v3.0/containerSecurity/kubernetesClusterGroups/%s", c.Client.HostURL, id), http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.DoRequest(req)
+	return err
+}
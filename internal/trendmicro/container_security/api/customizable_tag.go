@@ -0,0 +1,91 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+// Custom tags are managed by Attack Surface Risk Management and assigned to clusters through customizableTagIds.
+
+func (c *CsClient) CreateCustomizableTag(data *dto.CustomizableTagRequest) (*dto.CustomizableTagResponse, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/v3.0/asrm/attackSurfaceCustomTags", c.Client.HostURL), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.Client.DoRequestWithFullResponse(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	tagID, err := c.Client.ExtractIDFromLocationHeader(resp.Header)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.GetCustomizableTag(tagID)
+}
+
+// ListCustomizableTags returns every custom tag, following nextLink across pages.
+func (c *CsClient) ListCustomizableTags(ctx context.Context) ([]dto.CustomizableTagResponse, error) {
+	return trendmicro.ListAll[dto.CustomizableTagResponse](ctx, c.Client, fmt.Sprintf("%s/v3.0/asrm/attackSurfaceCustomTags", c.Client.HostURL), trendmicro.ListOptions{})
+}
+
+func (c *CsClient) GetCustomizableTag(id string) (*dto.CustomizableTagResponse, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/asrm/attackSurfaceCustomTags/%s", c.Client.HostURL, id), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.Client.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.CustomizableTagResponse{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *CsClient) UpdateCustomizableTag(id string, data *dto.CustomizableTagRequest) (*dto.CustomizableTagResponse, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/v3.0/asrm/attackSurfaceCustomTags/%s", c.Client.HostURL, id), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.Client.DoRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.GetCustomizableTag(id)
+}
+
+// DeleteCustomizableTag deletes a custom tag and removes it from every asset it is assigned to.
+func (c *CsClient) DeleteCustomizableTag(id string) error {
+	req, err := http.NewRequest("DELETE", fmt.Sprintf("%s/v3.0/asrm/attackSurfaceCustomTags/%s", c.Client.HostURL, id), http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.Client.DoRequest(req)
+	return err
+}
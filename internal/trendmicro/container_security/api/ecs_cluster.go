@@ -0,0 +1,70 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+// Amazon ECS clusters are discovered in the AWS accounts connected to Vision One, they cannot be
+// created or deleted through the API.
+
+// ListEcsClusters returns every Amazon ECS cluster, following nextLink across pages.
+func (c *CsClient) ListEcsClusters(ctx context.Context) ([]dto.EcsClusterItem, error) {
+	return trendmicro.ListAll[dto.EcsClusterItem](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/amazonEcsClusters", c.Client.HostURL), trendmicro.ListOptions{})
+}
+
+// GetEcsClusterByArn returns the discovered ECS cluster with the given ARN, or dto.ErrorNotFound.
+func (c *CsClient) GetEcsClusterByArn(ctx context.Context, clusterArn string) (*dto.EcsClusterItem, error) {
+	clusters, err := c.ListEcsClusters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range clusters {
+		if clusters[i].ClusterArn == clusterArn {
+			return &clusters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("ECS cluster %s: %w", clusterArn, dto.ErrorNotFound)
+}
+
+func (c *CsClient) GetEcsCluster(id string) (*dto.EcsClusterItem, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/amazonEcsClusters/%s", c.Client.HostURL, id), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := c.Client.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := dto.EcsClusterItem{}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, err
+	}
+
+	return &resp, nil
+}
+
+func (c *CsClient) UpdateEcsCluster(id string, data *dto.UpdateEcsClusterRequest) (*dto.EcsClusterItem, error) {
+	jsonData, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", fmt.Sprintf("%s/v3.0/containerSecurity/amazonEcsClusters/%s", c.Client.HostURL, id), bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := c.Client.DoRequest(req); err != nil {
+		return nil, err
+	}
+
+	return c.GetEcsCluster(id)
+}
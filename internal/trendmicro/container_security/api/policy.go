@@ -2,11 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/pkg/dto"
 )
 
@@ -42,7 +44,7 @@ func (c *CsClient) CreatePolicy(data *dto.CreatePolicyRequest) (*dto.PolicyRespo
 	if data.MalwareScan != nil {
 		if data.MalwareScan.Schedule != nil {
 			if !*data.MalwareScan.Schedule.Enabled {
-				_, err = c.UpdatePolicy(policyID, &dto.UpdatePolicyRequest{
+				_, err = c.UpdatePolicy(policyID, "", &dto.UpdatePolicyRequest{
 					MalwareScan: &dto.MalwareScan{
 						Schedule: &dto.MalwareSchedule{
 							Enabled: data.MalwareScan.Schedule.Enabled,
@@ -61,12 +63,13 @@ func (c *CsClient) CreatePolicy(data *dto.CreatePolicyRequest) (*dto.PolicyRespo
 	if data.SecretScan != nil {
 		if data.SecretScan.Schedule != nil {
 			if !*data.SecretScan.Schedule.Enabled {
-				_, err = c.UpdatePolicy(policyID, &dto.UpdatePolicyRequest{
+				_, err = c.UpdatePolicy(policyID, "", &dto.UpdatePolicyRequest{
 					SecretScan: &dto.SecretScan{
 						Schedule: &dto.SecretSchedule{
 							Enabled: data.SecretScan.Schedule.Enabled,
 						},
 					},
+					XdrEnabled: data.XdrEnabled,
 				})
 				if err != nil {
 					return nil, err
@@ -83,47 +86,42 @@ func (c *CsClient) CreatePolicy(data *dto.CreatePolicyRequest) (*dto.PolicyRespo
 	return createdPolicy, nil
 }
 
+// GetPolicy reads a policy together with its ETag.
 func (c *CsClient) GetPolicy(id string) (*dto.PolicyResponse, error) {
 	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/policies/%s", c.Client.HostURL, id), http.NoBody)
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.Client.DoRequest(req)
+	httpResp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
 		return nil, err
 	}
+	defer httpResp.Body.Close()
 
 	resp := dto.PolicyResponse{}
-	err = json.Unmarshal(body, &resp)
+	err = json.NewDecoder(httpResp.Body).Decode(&resp)
 	if err != nil {
 		return nil, err
 	}
+	resp.ETag = httpResp.Header.Get("ETag")
 
 	return &resp, nil
 }
 
-func (c *CsClient) GetPolicyList() (*dto.ListPolicyResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/policies", c.Client.HostURL), http.NoBody)
-	if err != nil {
-		return nil, err
-	}
-
-	body, err := c.Client.DoRequest(req)
+// GetPolicyList returns every policy, following nextLink across pages.
+func (c *CsClient) GetPolicyList(ctx context.Context) (*dto.ListPolicyResponse, error) {
+	items, err := trendmicro.ListAll[dto.PolicyResponse](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/policies", c.Client.HostURL), trendmicro.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	resp := dto.ListPolicyResponse{}
-	err = json.Unmarshal(body, &resp)
-	if err != nil {
-		return nil, err
-	}
-
-	return &resp, nil
+	return &dto.ListPolicyResponse{Items: items, TotalCount: len(items), Count: len(items)}, nil
 }
 
-func (c *CsClient) UpdatePolicy(id string, data *dto.UpdatePolicyRequest) (*dto.PolicyResponse, error) {
+// UpdatePolicy patches a policy and reads it back. A non-empty etag makes the update fail with
+// dto.ErrorPreconditionFailed when the policy changed since it was read.
+func (c *CsClient) UpdatePolicy(id, etag string, data *dto.UpdatePolicyRequest) (*dto.PolicyResponse, error) {
 	jsonData, err := json.Marshal(data)
 	if err != nil {
 		return nil, err
@@ -133,6 +131,7 @@ func (c *CsClient) UpdatePolicy(id string, data *dto.UpdatePolicyRequest) (*dto.
 	if err != nil {
 		return nil, err
 	}
+	trendmicro.SetIfMatch(req, etag)
 
 	resp, err := c.Client.DoRequestWithFullResponse(req)
 	if err != nil {
@@ -2,11 +2,13 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
 	"path"
+	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/pkg/dto"
 )
 
@@ -45,24 +47,19 @@ func (c *CsClient) CreateRuleset(data *dto.CreateRulesetRequest) (*dto.RulesetRe
 	return createdRuleset, nil
 }
 
-func (c *CsClient) ListRulesets() (*dto.ListRulesetsResponse, error) {
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/v3.0/containerSecurity/rulesets", c.Client.HostURL), http.NoBody)
+// ListRulesets returns every ruleset, following nextLink across pages.
+func (c *CsClient) ListRulesets(ctx context.Context) (*dto.ListRulesetsResponse, error) {
+	items, err := trendmicro.ListAll[*dto.RulesetResponse](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/rulesets", c.Client.HostURL), trendmicro.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
 
-	body, err := c.Client.DoRequest(req)
-	if err != nil {
-		return nil, err
-	}
-
-	resp := dto.ListRulesetsResponse{}
-	err = json.Unmarshal(body, &resp)
-	if err != nil {
-		return nil, err
-	}
+	return &dto.ListRulesetsResponse{Items: items}, nil
+}
 
-	return &resp, nil
+// ListManagedRules returns the catalog of managed runtime rules, following nextLink across pages.
+func (c *CsClient) ListManagedRules(ctx context.Context) ([]dto.ManagedRule, error) {
+	return trendmicro.ListAll[dto.ManagedRule](ctx, c.Client, fmt.Sprintf("%s/v3.0/containerSecurity/managedRules", c.Client.HostURL), trendmicro.ListOptions{})
 }
 
 func (c *CsClient) GetRuleset(id string) (*dto.RulesetResponse, error) {
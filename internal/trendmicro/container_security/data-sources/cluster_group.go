@@ -0,0 +1,96 @@
+package datasources
+
+import (
+	"context"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ClusterGroupDataSource{}
+	_ datasource.DataSourceWithConfigure = &ClusterGroupDataSource{}
+)
+
+func NewClusterGroupDataSource() datasource.DataSource {
+	return &ClusterGroupDataSource{}
+}
+
+// ClusterGroupDataSource looks up one cluster group by name, for groups created in the console.
+type ClusterGroupDataSource struct {
+	client *api.CsClient
+}
+
+type clusterGroupDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	CreatedDateTime types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime types.String `tfsdk:"updated_date_time"`
+}
+
+func (d *ClusterGroupDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_CLUSTER_GROUP
+}
+
+func (d *ClusterGroupDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *ClusterGroupDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up the ID of a Kubernetes cluster group by its name. Reading fails unless exactly one group has the name.",
+		Attributes: map[string]schema.Attribute{
+			"name":              schema.StringAttribute{MarkdownDescription: "The name of the cluster group.", Required: true},
+			"id":                schema.StringAttribute{MarkdownDescription: "The ID of the cluster group, to use as the `group_id` of a cluster.", Computed: true},
+			"description":       schema.StringAttribute{MarkdownDescription: "The description of the cluster group.", Computed: true},
+			"created_date_time": schema.StringAttribute{MarkdownDescription: "The time the cluster group was created.", Computed: true},
+			"updated_date_time": schema.StringAttribute{MarkdownDescription: "The time the cluster group was last updated.", Computed: true},
+		},
+	}
+}
+
+func (d *ClusterGroupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clusterGroupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	groups, err := d.client.ListClusterGroups(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Cluster Groups",
+			"An unexpected error occurred when listing the Container Security cluster groups.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	matches := 0
+	for _, group := range groups {
+		if group.Name != data.Name.ValueString() {
+			continue
+		}
+		matches++
+		data.ID = types.StringValue(group.ID)
+		data.Description = types.StringValue(group.Description)
+		data.CreatedDateTime = types.StringValue(group.CreatedDateTime)
+		data.UpdatedDateTime = optionalString(group.UpdatedDateTime)
+	}
+	if matches != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Cluster Group Not Found",
+			lookupErrorDetail(matches, "cluster groups", "named "+data.Name.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
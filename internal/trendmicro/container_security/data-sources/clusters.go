@@ -0,0 +1,217 @@
+package datasources
+
+import (
+	"context"
+	"maps"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ClustersDataSource{}
+	_ datasource.DataSourceWithConfigure = &ClustersDataSource{}
+)
+
+func NewClustersDataSource() datasource.DataSource {
+	return &ClustersDataSource{}
+}
+
+type ClustersDataSource struct {
+	client *api.CsClient
+}
+
+type clustersDataSourceModel struct {
+	Name         types.String   `tfsdk:"name"`
+	NameRegex    types.String   `tfsdk:"name_regex"`
+	Orchestrator types.String   `tfsdk:"orchestrator"`
+	PolicyID     types.String   `tfsdk:"policy_id"`
+	GroupID      types.String   `tfsdk:"group_id"`
+	IDs          []types.String `tfsdk:"ids"`
+	Clusters     []clusterModel `tfsdk:"clusters"`
+}
+
+type clusterModel struct {
+	ID                       types.String `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	Description              types.String `tfsdk:"description"`
+	Orchestrator             types.String `tfsdk:"orchestrator"`
+	PolicyID                 types.String `tfsdk:"policy_id"`
+	GroupID                  types.String `tfsdk:"group_id"`
+	ResourceID               types.String `tfsdk:"resource_id"`
+	RuntimeSecurityEnabled   types.Bool   `tfsdk:"runtime_security_enabled"`
+	VulnerabilityScanEnabled types.Bool   `tfsdk:"vulnerability_scan_enabled"`
+	MalwareScanEnabled       types.Bool   `tfsdk:"malware_scan_enabled"`
+	CreatedDateTime          types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime          types.String `tfsdk:"updated_date_time"`
+	LastEvaluatedDateTime    types.String `tfsdk:"last_evaluated_date_time"`
+	Nodes                    []nodeModel  `tfsdk:"nodes"`
+}
+
+type nodeModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+	Pods []podModel   `tfsdk:"pods"`
+}
+
+type podModel struct {
+	ID   types.String `tfsdk:"id"`
+	Name types.String `tfsdk:"name"`
+}
+
+func (d *ClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_CLUSTERS
+}
+
+func (d *ClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *ClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	idAndName := map[string]schema.Attribute{
+		"id":   schema.StringAttribute{MarkdownDescription: "The ID.", Computed: true},
+		"name": schema.StringAttribute{MarkdownDescription: "The name.", Computed: true},
+	}
+
+	attributes := nameFilterAttributes("clusters")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"orchestrator": schema.StringAttribute{
+			MarkdownDescription: "Only return clusters run by this orchestrator, for example `Amazon EKS` or `Azure AKS`.",
+			Optional:            true,
+		},
+		"policy_id": schema.StringAttribute{
+			MarkdownDescription: "Only return clusters using this policy.",
+			Optional:            true,
+		},
+		"group_id": schema.StringAttribute{
+			MarkdownDescription: "Only return clusters in this cluster group.",
+			Optional:            true,
+		},
+		"ids": schema.ListAttribute{
+			MarkdownDescription: "IDs of the matching clusters.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"clusters": schema.ListNestedAttribute{
+			MarkdownDescription: "The matching clusters.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":                         schema.StringAttribute{MarkdownDescription: "The cluster ID.", Computed: true},
+					"name":                       schema.StringAttribute{MarkdownDescription: "The cluster name.", Computed: true},
+					"description":                schema.StringAttribute{MarkdownDescription: "The cluster description.", Computed: true},
+					"orchestrator":               schema.StringAttribute{MarkdownDescription: "The orchestrator of the cluster.", Computed: true},
+					"policy_id":                  schema.StringAttribute{MarkdownDescription: "The ID of the policy assigned to the cluster.", Computed: true},
+					"group_id":                   schema.StringAttribute{MarkdownDescription: "The ID of the cluster group.", Computed: true},
+					"resource_id":                schema.StringAttribute{MarkdownDescription: "The cloud resource ID of the cluster, for example an EKS cluster ARN.", Computed: true},
+					"runtime_security_enabled":   schema.BoolAttribute{MarkdownDescription: "Whether runtime security is enabled.", Computed: true},
+					"vulnerability_scan_enabled": schema.BoolAttribute{MarkdownDescription: "Whether vulnerability scanning is enabled.", Computed: true},
+					"malware_scan_enabled":       schema.BoolAttribute{MarkdownDescription: "Whether malware scanning is enabled.", Computed: true},
+					"created_date_time":          schema.StringAttribute{MarkdownDescription: "The time the cluster was registered.", Computed: true},
+					"updated_date_time":          schema.StringAttribute{MarkdownDescription: "The time the cluster was last updated.", Computed: true},
+					"last_evaluated_date_time":   schema.StringAttribute{MarkdownDescription: "The time the cluster was last evaluated.", Computed: true},
+					"nodes": schema.ListNestedAttribute{
+						MarkdownDescription: "The nodes reported by the cluster agent.",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"id":   idAndName["id"],
+								"name": idAndName["name"],
+								"pods": schema.ListNestedAttribute{
+									MarkdownDescription: "The pods running on the node.",
+									Computed:            true,
+									NestedObject:        schema.NestedAttributeObject{Attributes: idAndName},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up Kubernetes clusters registered with Container Security.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data clustersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchName, diags := nameMatcher(data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.GetClusterList(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Clusters",
+			"An unexpected error occurred when listing the Container Security clusters.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	data.IDs = []types.String{}
+	data.Clusters = []clusterModel{}
+	for _, item := range list.Items {
+		if !matchName(item.Name) ||
+			!matchOptional(data.Orchestrator, item.Orchestrator) ||
+			!matchOptional(data.PolicyID, item.PolicyId) ||
+			!matchOptional(data.GroupID, item.GroupId) {
+			continue
+		}
+		data.IDs = append(data.IDs, types.StringValue(item.ID))
+		data.Clusters = append(data.Clusters, newClusterModel(&item))
+	}
+
+	tflog.Trace(ctx, "read container clusters", map[string]any{"count": len(data.Clusters)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func matchOptional(filter types.String, value string) bool {
+	return filter.IsNull() || filter.ValueString() == value
+}
+
+func newClusterModel(item *dto.ClusterItem) clusterModel {
+	nodes := make([]nodeModel, 0, len(item.Nodes))
+	for _, node := range item.Nodes {
+		pods := make([]podModel, 0, len(node.Pods))
+		for _, pod := range node.Pods {
+			pods = append(pods, podModel{ID: types.StringValue(pod.ID), Name: types.StringValue(pod.Name)})
+		}
+		nodes = append(nodes, nodeModel{ID: types.StringValue(node.ID), Name: types.StringValue(node.Name), Pods: pods})
+	}
+
+	return clusterModel{
+		ID:                       types.StringValue(item.ID),
+		Name:                     types.StringValue(item.Name),
+		Description:              types.StringValue(item.Description),
+		Orchestrator:             optionalString(item.Orchestrator),
+		PolicyID:                 optionalString(item.PolicyId),
+		GroupID:                  optionalString(item.GroupId),
+		ResourceID:               optionalString(item.ResourceId),
+		RuntimeSecurityEnabled:   types.BoolValue(item.RuntimeSecurityEnabled),
+		VulnerabilityScanEnabled: types.BoolValue(item.VulnerabilityScanEnabled),
+		MalwareScanEnabled:       types.BoolValue(item.MalwareScanEnabled),
+		CreatedDateTime:          types.StringValue(item.CreatedDateTime),
+		UpdatedDateTime:          types.StringValue(item.UpdatedDateTime),
+		LastEvaluatedDateTime:    optionalString(item.LastEvaluatedDateTime),
+		Nodes:                    nodes,
+	}
+}
@@ -0,0 +1,89 @@
+package datasources
+
+import (
+	"context"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &CustomizableTagDataSource{}
+	_ datasource.DataSourceWithConfigure = &CustomizableTagDataSource{}
+)
+
+func NewCustomizableTagDataSource() datasource.DataSource {
+	return &CustomizableTagDataSource{}
+}
+
+// CustomizableTagDataSource looks up the ID of a custom tag by its key and value.
+type CustomizableTagDataSource struct {
+	client *api.CsClient
+}
+
+type customizableTagDataSourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+}
+
+func (d *CustomizableTagDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_CUSTOMIZABLE_TAG
+}
+
+func (d *CustomizableTagDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *CustomizableTagDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up the ID of a custom tag by its key and value. Reading fails unless exactly one tag matches.",
+		Attributes: map[string]schema.Attribute{
+			"key":   schema.StringAttribute{MarkdownDescription: "The key of the tag.", Required: true},
+			"value": schema.StringAttribute{MarkdownDescription: "The value of the tag.", Required: true},
+			"id":    schema.StringAttribute{MarkdownDescription: "The ID of the tag, to use in the `customizable_tags` of a cluster.", Computed: true},
+		},
+	}
+}
+
+func (d *CustomizableTagDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data customizableTagDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tags, err := d.client.ListCustomizableTags(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Custom Tags",
+			"An unexpected error occurred when listing the custom tags.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	matches := 0
+	for _, tag := range tags {
+		if tag.Key != data.Key.ValueString() || tag.Value != data.Value.ValueString() {
+			continue
+		}
+		matches++
+		data.ID = types.StringValue(tag.ID)
+	}
+	if matches != 1 {
+		resp.Diagnostics.AddAttributeError(path.Root("key"), "Custom Tag Not Found",
+			lookupErrorDetail(matches, "custom tags", data.Key.ValueString()+":"+data.Value.ValueString()))
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,109 @@
+package datasources
+
+import (
+	"context"
+	"errors"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &EcsClusterDataSource{}
+	_ datasource.DataSourceWithConfigure = &EcsClusterDataSource{}
+)
+
+func NewEcsClusterDataSource() datasource.DataSource {
+	return &EcsClusterDataSource{}
+}
+
+// EcsClusterDataSource looks up a discovered Amazon ECS cluster by its ARN.
+type EcsClusterDataSource struct {
+	client *api.CsClient
+}
+
+type ecsClusterDataSourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	ClusterArn               types.String `tfsdk:"cluster_arn"`
+	Name                     types.String `tfsdk:"name"`
+	AwsAccountID             types.String `tfsdk:"aws_account_id"`
+	Region                   types.String `tfsdk:"region"`
+	PolicyID                 types.String `tfsdk:"policy_id"`
+	RuntimeSecurityEnabled   types.Bool   `tfsdk:"runtime_security_enabled"`
+	VulnerabilityScanEnabled types.Bool   `tfsdk:"vulnerability_scan_enabled"`
+	CreatedDateTime          types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime          types.String `tfsdk:"updated_date_time"`
+	LastEvaluatedDateTime    types.String `tfsdk:"last_evaluated_date_time"`
+}
+
+func (d *EcsClusterDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_ECS_CLUSTER
+}
+
+func (d *EcsClusterDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *EcsClusterDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up an Amazon ECS cluster Vision One discovered in a connected AWS account.",
+		Attributes: map[string]schema.Attribute{
+			"cluster_arn":                schema.StringAttribute{MarkdownDescription: "The ARN of the ECS cluster.", Required: true},
+			"id":                         schema.StringAttribute{MarkdownDescription: "The ID Vision One assigned to the ECS cluster.", Computed: true},
+			"name":                       schema.StringAttribute{MarkdownDescription: "The name of the ECS cluster.", Computed: true},
+			"aws_account_id":             schema.StringAttribute{MarkdownDescription: "The ID of the AWS account the cluster runs in.", Computed: true},
+			"region":                     schema.StringAttribute{MarkdownDescription: "The AWS region the cluster runs in.", Computed: true},
+			"policy_id":                  schema.StringAttribute{MarkdownDescription: "The ID of the policy assigned to the cluster.", Computed: true},
+			"runtime_security_enabled":   schema.BoolAttribute{MarkdownDescription: "Whether runtime security is enabled.", Computed: true},
+			"vulnerability_scan_enabled": schema.BoolAttribute{MarkdownDescription: "Whether vulnerability scanning is enabled.", Computed: true},
+			"created_date_time":          schema.StringAttribute{MarkdownDescription: "The time the cluster was discovered.", Computed: true},
+			"updated_date_time":          schema.StringAttribute{MarkdownDescription: "The time the cluster was last updated.", Computed: true},
+			"last_evaluated_date_time":   schema.StringAttribute{MarkdownDescription: "The time the cluster was last evaluated against its policy.", Computed: true},
+		},
+	}
+}
+
+func (d *EcsClusterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ecsClusterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cluster, err := d.client.GetEcsClusterByArn(ctx, data.ClusterArn.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.Diagnostics.AddAttributeError(path.Root("cluster_arn"), "ECS Cluster Not Found",
+				"Vision One has not discovered the ECS cluster "+data.ClusterArn.ValueString()+".")
+			return
+		}
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List ECS Clusters",
+			"An unexpected error occurred when listing the Container Security ECS clusters.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(cluster.ID)
+	data.Name = types.StringValue(cluster.Name)
+	data.AwsAccountID = types.StringValue(cluster.AwsAccountID)
+	data.Region = types.StringValue(cluster.Region)
+	data.PolicyID = optionalString(cluster.PolicyId)
+	data.RuntimeSecurityEnabled = types.BoolValue(cluster.RuntimeSecurityEnabled)
+	data.VulnerabilityScanEnabled = types.BoolValue(cluster.VulnerabilityScanEnabled)
+	data.CreatedDateTime = optionalString(cluster.CreatedDateTime)
+	data.UpdatedDateTime = optionalString(cluster.UpdatedDateTime)
+	data.LastEvaluatedDateTime = optionalString(cluster.LastEvaluatedDateTime)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
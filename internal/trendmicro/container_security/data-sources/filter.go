@@ -0,0 +1,85 @@
+package datasources
+
+import (
+	"fmt"
+	"regexp"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nameFilterAttributes returns the name and name_regex arguments shared by the container data sources.
+func nameFilterAttributes(kind string) map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"name": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Only return %s with exactly this name.", kind),
+			Optional:            true,
+			Validators: []validator.String{
+				stringvalidator.ConflictsWith(path.MatchRoot("name_regex")),
+			},
+		},
+		"name_regex": schema.StringAttribute{
+			MarkdownDescription: fmt.Sprintf("Only return %s whose name matches this [RE2 regular expression](https://github.com/google/re2/wiki/Syntax).", kind),
+			Optional:            true,
+		},
+	}
+}
+
+// nameMatcher returns a function reporting whether a name passes the name and name_regex arguments.
+func nameMatcher(name, nameRegex types.String) (func(string) bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !name.IsNull() {
+		want := name.ValueString()
+		return func(n string) bool { return n == want }, diags
+	}
+	if !nameRegex.IsNull() {
+		re, err := regexp.Compile(nameRegex.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("name_regex"), "Invalid Regular Expression", err.Error())
+			return nil, diags
+		}
+		return re.MatchString, diags
+	}
+	return func(string) bool { return true }, diags
+}
+
+// configureCsClient turns the provider data into a Container Security client.
+func configureCsClient(req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) *api.CsClient {
+	if req.ProviderData == nil {
+		return nil
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return nil
+	}
+
+	return &api.CsClient{Client: client.ForService(trendmicro.ServiceContainerSecurity)}
+}
+
+func optionalString(s string) types.String {
+	if s == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(s)
+}
+
+// lookupErrorDetail explains why a data source looking up a single object did not find exactly one.
+func lookupErrorDetail(matches int, kind, what string) string {
+	if matches == 0 {
+		return fmt.Sprintf("No %s %s were found.", kind, what)
+	}
+	return fmt.Sprintf("%d %s %s were found, the lookup needs exactly one.", matches, kind, what)
+}
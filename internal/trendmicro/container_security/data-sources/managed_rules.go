@@ -0,0 +1,152 @@
+package datasources
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &ManagedRulesDataSource{}
+	_ datasource.DataSourceWithConfigure = &ManagedRulesDataSource{}
+)
+
+func NewManagedRulesDataSource() datasource.DataSource {
+	return &ManagedRulesDataSource{}
+}
+
+type ManagedRulesDataSource struct {
+	client *api.CsClient
+}
+
+type managedRulesDataSourceModel struct {
+	Name       types.String       `tfsdk:"name"`
+	NameRegex  types.String       `tfsdk:"name_regex"`
+	Tag        types.String       `tfsdk:"tag"`
+	Mitigation types.String       `tfsdk:"mitigation"`
+	IDs        []types.String     `tfsdk:"ids"`
+	Rules      []managedRuleModel `tfsdk:"rules"`
+}
+
+type managedRuleModel struct {
+	ID                types.String   `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Description       types.String   `tfsdk:"description"`
+	Tags              []types.String `tfsdk:"tags"`
+	DefaultMitigation types.String   `tfsdk:"default_mitigation"`
+}
+
+func (d *ManagedRulesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_MANAGED_RULES
+}
+
+func (d *ManagedRulesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *ManagedRulesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("managed rules")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"tag": schema.StringAttribute{
+			MarkdownDescription: "Only return managed rules with this tag, for example the MITRE ATT&CK technique `T1059`. Tags are compared case-insensitively.",
+			Optional:            true,
+		},
+		"mitigation": schema.StringAttribute{
+			MarkdownDescription: "Only return managed rules with this default mitigation. Enum: [log, isolate, terminate].",
+			Optional:            true,
+			Validators:          []validator.String{stringvalidator.OneOf("log", "isolate", "terminate")},
+		},
+		"ids": schema.ListAttribute{
+			MarkdownDescription: "IDs of the matching managed rules, for the `rules` of a `visionone_container_ruleset`.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"rules": schema.ListNestedAttribute{
+			MarkdownDescription: "The matching managed rules.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":                 schema.StringAttribute{MarkdownDescription: "The rule ID.", Computed: true},
+					"name":               schema.StringAttribute{MarkdownDescription: "The rule name.", Computed: true},
+					"description":        schema.StringAttribute{MarkdownDescription: "The rule description.", Computed: true},
+					"tags":               schema.ListAttribute{MarkdownDescription: "The rule tags, including MITRE ATT&CK tactics and techniques.", ElementType: types.StringType, Computed: true},
+					"default_mitigation": schema.StringAttribute{MarkdownDescription: "The mitigation applied when a ruleset enables the rule without one.", Computed: true},
+				},
+			},
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up the catalog of Container Security managed runtime rules, for example to build a ruleset from every rule of a MITRE technique.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *ManagedRulesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data managedRulesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchName, diags := nameMatcher(data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rules, err := d.client.ListManagedRules(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Managed Rules",
+			"An unexpected error occurred when listing the Container Security managed rules.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	data.IDs = []types.String{}
+	data.Rules = []managedRuleModel{}
+	for _, rule := range rules {
+		if !matchName(rule.Name) || !matchManagedRule(rule, data.Tag, data.Mitigation) {
+			continue
+		}
+		tags := make([]types.String, 0, len(rule.Tags))
+		for _, tag := range rule.Tags {
+			tags = append(tags, types.StringValue(tag))
+		}
+		data.IDs = append(data.IDs, types.StringValue(rule.ID))
+		data.Rules = append(data.Rules, managedRuleModel{
+			ID:                types.StringValue(rule.ID),
+			Name:              types.StringValue(rule.Name),
+			Description:       types.StringValue(rule.Description),
+			Tags:              tags,
+			DefaultMitigation: optionalString(rule.Mitigation),
+		})
+	}
+
+	tflog.Trace(ctx, "read container managed rules", map[string]any{"count": len(data.Rules)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// matchManagedRule reports whether rule passes the tag and mitigation arguments.
+func matchManagedRule(rule dto.ManagedRule, tag, mitigation types.String) bool {
+	if !tag.IsNull() && !slices.ContainsFunc(rule.Tags, func(t string) bool { return strings.EqualFold(t, tag.ValueString()) }) {
+		return false
+	}
+	return mitigation.IsNull() || rule.Mitigation == mitigation.ValueString()
+}
@@ -0,0 +1,130 @@
+package datasources
+
+import (
+	"context"
+	"maps"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &PoliciesDataSource{}
+	_ datasource.DataSourceWithConfigure = &PoliciesDataSource{}
+)
+
+func NewPoliciesDataSource() datasource.DataSource {
+	return &PoliciesDataSource{}
+}
+
+type PoliciesDataSource struct {
+	client *api.CsClient
+}
+
+type policiesDataSourceModel struct {
+	Name      types.String   `tfsdk:"name"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	IDs       []types.String `tfsdk:"ids"`
+	Policies  []policyModel  `tfsdk:"policies"`
+}
+
+type policyModel struct {
+	ID                      types.String `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Description             types.String `tfsdk:"description"`
+	XdrEnabled              types.Bool   `tfsdk:"xdr_enabled"`
+	CreatedDateTime         types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime         types.String `tfsdk:"updated_date_time"`
+	RulesetsUpdatedDateTime types.String `tfsdk:"rulesets_updated_date_time"`
+}
+
+func (d *PoliciesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_POLICIES
+}
+
+func (d *PoliciesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *PoliciesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("policies")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"ids": schema.ListAttribute{
+			MarkdownDescription: "IDs of the matching policies.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"policies": schema.ListNestedAttribute{
+			MarkdownDescription: "The matching policies.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":                         schema.StringAttribute{MarkdownDescription: "The policy ID.", Computed: true},
+					"name":                       schema.StringAttribute{MarkdownDescription: "The policy name.", Computed: true},
+					"description":                schema.StringAttribute{MarkdownDescription: "The policy description.", Computed: true},
+					"xdr_enabled":                schema.BoolAttribute{MarkdownDescription: "Whether XDR telemetry is enabled.", Computed: true},
+					"created_date_time":          schema.StringAttribute{MarkdownDescription: "The time the policy was created.", Computed: true},
+					"updated_date_time":          schema.StringAttribute{MarkdownDescription: "The time the policy was last updated.", Computed: true},
+					"rulesets_updated_date_time": schema.StringAttribute{MarkdownDescription: "The time the rulesets of the policy were last updated.", Computed: true},
+				},
+			},
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up Container Security policies, for example to attach a policy owned by another workspace to a cluster by name.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *PoliciesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data policiesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchName, diags := nameMatcher(data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.GetPolicyList(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Policies",
+			"An unexpected error occurred when listing the Container Security policies.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	data.IDs = []types.String{}
+	data.Policies = []policyModel{}
+	for _, item := range list.Items {
+		if !matchName(item.Name) {
+			continue
+		}
+		data.IDs = append(data.IDs, types.StringValue(item.ID))
+		data.Policies = append(data.Policies, policyModel{
+			ID:                      types.StringValue(item.ID),
+			Name:                    types.StringValue(item.Name),
+			Description:             types.StringValue(item.Description),
+			XdrEnabled:              types.BoolValue(item.XdrEnabled),
+			CreatedDateTime:         types.StringValue(item.CreatedDateTime),
+			UpdatedDateTime:         optionalString(item.UpdatedDateTime),
+			RulesetsUpdatedDateTime: optionalString(item.RulesetsUpdatedDateTime),
+		})
+	}
+
+	tflog.Trace(ctx, "read container policies", map[string]any{"count": len(data.Policies)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,124 @@
+package datasources
+
+import (
+	"context"
+	"maps"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &RulesetsDataSource{}
+	_ datasource.DataSourceWithConfigure = &RulesetsDataSource{}
+)
+
+func NewRulesetsDataSource() datasource.DataSource {
+	return &RulesetsDataSource{}
+}
+
+type RulesetsDataSource struct {
+	client *api.CsClient
+}
+
+type rulesetsDataSourceModel struct {
+	Name      types.String   `tfsdk:"name"`
+	NameRegex types.String   `tfsdk:"name_regex"`
+	IDs       []types.String `tfsdk:"ids"`
+	Rulesets  []rulesetModel `tfsdk:"rulesets"`
+}
+
+type rulesetModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	CreatedDateTime types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime types.String `tfsdk:"updated_date_time"`
+}
+
+func (d *RulesetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.DATA_SOURCE_TYPE_RULESETS
+}
+
+func (d *RulesetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if client := configureCsClient(req, resp); client != nil {
+		d.client = client
+	}
+}
+
+func (d *RulesetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attributes := nameFilterAttributes("rulesets")
+	maps.Copy(attributes, map[string]schema.Attribute{
+		"ids": schema.ListAttribute{
+			MarkdownDescription: "IDs of the matching rulesets.",
+			ElementType:         types.StringType,
+			Computed:            true,
+		},
+		"rulesets": schema.ListNestedAttribute{
+			MarkdownDescription: "The matching rulesets.",
+			Computed:            true,
+			NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":                schema.StringAttribute{MarkdownDescription: "The ruleset ID.", Computed: true},
+					"name":              schema.StringAttribute{MarkdownDescription: "The ruleset name.", Computed: true},
+					"description":       schema.StringAttribute{MarkdownDescription: "The ruleset description.", Computed: true},
+					"created_date_time": schema.StringAttribute{MarkdownDescription: "The time the ruleset was created.", Computed: true},
+					"updated_date_time": schema.StringAttribute{MarkdownDescription: "The time the ruleset was last updated.", Computed: true},
+				},
+			},
+		},
+	})
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to look up Container Security runtime rulesets.",
+		Attributes:          attributes,
+	}
+}
+
+func (d *RulesetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data rulesetsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	matchName, diags := nameMatcher(data.Name, data.NameRegex)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	list, err := d.client.ListRulesets(ctx)
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to List Rulesets",
+			"An unexpected error occurred when listing the Container Security rulesets.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	data.IDs = []types.String{}
+	data.Rulesets = []rulesetModel{}
+	for _, item := range list.Items {
+		if item == nil || !matchName(item.Name) {
+			continue
+		}
+		data.IDs = append(data.IDs, types.StringValue(item.Id))
+		data.Rulesets = append(data.Rulesets, rulesetModel{
+			ID:              types.StringValue(item.Id),
+			Name:            types.StringValue(item.Name),
+			Description:     types.StringValue(item.Description),
+			CreatedDateTime: types.StringValue(item.CreatedDateTime),
+			UpdatedDateTime: optionalString(item.UpdatedDateTime),
+		})
+	}
+
+	tflog.Trace(ctx, "read container rulesets", map[string]any{"count": len(data.Rulesets)})
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -8,15 +8,19 @@ import (
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
 	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 	"terraform-provider-vision-one/pkg/dto"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -54,6 +58,7 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Description: config.RESOURCE_TYPE_CLUSTER_DESCRIPTION,
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID of the cluster.",
 				Computed:            true,
@@ -117,7 +122,7 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Computed:            true,
 			},
 			"group_id": schema.StringAttribute{
-				MarkdownDescription: "The ID of the group associated with the cluster. To get IDs of the groups within the user's management scope, use the Kubernetes cluster groups API to list these IDs.",
+				MarkdownDescription: "The ID of the group associated with the cluster. Use the `id` of a `" + config.RESOURCE_TYPE_CLUSTER_GROUP + "` resource, or look up an existing group with the `" + config.DATA_SOURCE_TYPE_CLUSTER_GROUP + "` data source.",
 				Required:            true,
 			},
 			"namespaces": schema.SetAttribute{
@@ -214,13 +219,44 @@ func (r *clusterResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 					},
 				},
 			},
+			"platform": schema.StringAttribute{
+				MarkdownDescription: "The platform the Helm chart is installed on, used to render platform specific settings in `helm_values_yaml`. Accepted values: `KUBERNETES`, `EKS`, `EKS_FARGATE`, `AKS`, `GKE`, `OPENSHIFT`. When unset, OpenShift is detected from `orchestrator` and the generic Kubernetes settings are used otherwise.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(platforms...),
+				},
+			},
+			"helm_values_yaml": schema.StringAttribute{
+				MarkdownDescription: "Ready-to-use `overrides.yaml` for the visionone-container-security Helm chart, built from `api_key`, `endpoint`, `namespaces`, `proxy`, the feature toggles and `platform`. Pass it to the `values` of a `helm_release`. `OPENSHIFT` excludes the `openshift*` namespaces and `EKS_FARGATE` enables the Fargate injector; `EKS`, `AKS` and `GKE` need no overrides and get the generic Kubernetes settings. Null for imported clusters, whose API key is unknown.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"deletion_protection": schema.SingleNestedAttribute{
+				MarkdownDescription: "Refuse to delete the cluster while its agents are still reporting, for example because the Helm release was not uninstalled yet. " +
+					"The diagnostics list the nodes that still report.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"agent_window": schema.StringAttribute{
+						MarkdownDescription: "Agents count as reporting when the cluster was evaluated within this period, for example `15m`. Defaults to `15m`.",
+						Optional:            true,
+						Computed:            true,
+						Default:             stringdefault.StaticString(defaultAgentWindow),
+						Validators:          []validator.String{periodValidator{}},
+					},
+					"wait_for_agents_gone": schema.StringAttribute{
+						MarkdownDescription: "Instead of failing right away, wait up to this period for the agents to stop reporting, for example `10m`.",
+						Optional:            true,
+						Validators:          []validator.String{periodValidator{}},
+					},
+				},
+			},
 			"customizable_tags": schema.SetNestedAttribute{
-				MarkdownDescription: "The custom tags and platform tags associated with the cluster. To obtain custom tags, refer to the following URL: https://portal.xdr.trendmicro.com/index.html#/admin/automation_center?goto=api&from=v3.0&tag=tag/Attack-Surface-Discovery/paths/~1v3.0~1asrm~1attackSurfaceCustomTags/get. However, platform tags will be provided through an additional API in the future. The difference between custom tags and platform tags is that properties of platform tags are defined by Trend Micro, while properties and values of custom tags can be created and updated by users.",
+				MarkdownDescription: "The custom tags and platform tags associated with the cluster. Create custom tags with the `" + config.RESOURCE_TYPE_CUSTOMIZABLE_TAG + "` resource, or look up existing ones with the `" + config.DATA_SOURCE_TYPE_CUSTOMIZABLE_TAG + "` data source. The difference between custom tags and platform tags is that properties of platform tags are defined by Trend Micro, while properties and values of custom tags can be created and updated by users.",
 				Optional:            true,
 				NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
 						"id": schema.StringAttribute{
-							MarkdownDescription: "The tag ID of the custom tag. Note: this is not a plain text value but a tag ID obtained from the API.",
+							MarkdownDescription: "The tag ID of the custom tag. Note: this is not a plain text value but the `id` of a `" + config.RESOURCE_TYPE_CUSTOMIZABLE_TAG + "`.",
 							Required:            true,
 						},
 					},
@@ -239,6 +275,8 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	tflog.Debug(ctx, fmt.Sprintf("Create new Cluster plan: %+v", plan))
 
 	data := dto.CreateClusterRequest{
@@ -276,7 +314,7 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 
 	tflog.Debug(ctx, fmt.Sprintf("Create new Cluster request: %v", data))
-	apiResponse, err := r.client.CreateCluster(&data)
+	apiResponse, err := client.CreateCluster(&data)
 
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
@@ -304,7 +342,10 @@ func (r *clusterResource) Create(ctx context.Context, req resource.CreateRequest
 	plan.Endpoint = types.StringValue(apiResponse.Endpoint)
 	plan.ApiKey = types.StringValue(apiResponse.ApiKey)
 
-	err = r.client.UpdateCurrentState(&plan)
+	err = client.UpdateCurrentState(&plan)
+	if err == nil {
+		err = setHelmValues(ctx, &plan)
+	}
 
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
@@ -330,10 +371,15 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("id"), &id)...)
 
 	var state dto.ClusterResourceModel
-	req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
 	state.ID = types.StringValue(id)
 
-	err := r.client.UpdateCurrentState(&state)
+	err := client.UpdateCurrentState(&state)
 	if errors.Is(err, dto.ErrorNotFound) {
 		resp.State.RemoveResource(ctx)
 		return
@@ -352,6 +398,11 @@ func (r *clusterResource) Read(ctx context.Context, req resource.ReadRequest, re
 	// assign default value for user
 	state.Namespaces = types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kube-system")})
 
+	if err := setHelmValues(ctx, &state); err != nil {
+		resp.Diagnostics.AddError("Unable to Render Helm Values", err.Error())
+		return
+	}
+
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -368,6 +419,8 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
+	client := r.client.WithCredentials(plan.Credentials)
+
 	err := proxyHandler(&plan)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
@@ -401,7 +454,7 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		updateRequest.CustomizableTagIDs = tagIDs
 	}
 
-	err = r.client.UpdateCluster(plan.ID.ValueString(), &updateRequest)
+	err = client.UpdateCluster(plan.ID.ValueString(), &updateRequest)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -413,7 +466,10 @@ func (r *clusterResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	err = r.client.UpdateCurrentState(&plan)
+	err = client.UpdateCurrentState(&plan)
+	if err == nil {
+		err = setHelmValues(ctx, &plan)
+	}
 
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
@@ -442,11 +498,39 @@ func (r *clusterResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
+	client := r.client.WithCredentials(state.Credentials)
+
+	if state.DeletionProtection != nil {
+		nodes, err := waitForAgentsGone(ctx, client, state.ID.ValueString(), state.DeletionProtection)
+		if err != nil {
+			if errors.Is(err, dto.ErrorNotFound) {
+				return
+			}
+			resp.Diagnostics.AddError(
+				"Unable to Delete the Cluster",
+				"An unexpected error occurred when checking the agents of the cluster. "+
+					"If the error is not clear, please contact the provider developers.\n\n"+
+					"TrendMicro Client: "+err.Error(),
+			)
+			return
+		}
+		if len(nodes) > 0 {
+			resp.Diagnostics.AddError(
+				"Cluster Agents Still Reporting",
+				"Deletion protection kept cluster "+state.ID.ValueString()+" because agents on these nodes are still reporting:\n\n"+
+					describeNodes(nodes)+"\n\n"+
+					"Uninstall the Container Security Helm release from the cluster and try again, set wait_for_agents_gone to wait for the agents, "+
+					"or remove deletion_protection.",
+			)
+			return
+		}
+	}
+
 	deleteClusterRequest := dto.DeleteClusterRequest{
 		ID: state.ID.ValueString(),
 	}
 
-	err := r.client.DeleteCluster(&deleteClusterRequest)
+	err := client.DeleteCluster(&deleteClusterRequest)
 	if errors.Is(err, dto.ErrorNotFound) {
 		return
 	}
@@ -481,11 +565,16 @@ func (r *clusterResource) Configure(ctx context.Context, req resource.ConfigureR
 
 		return
 	}
-	r.client.Client = client
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
 }
 
 func (r *clusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// The API does not return the proxy, secret scan and inventory settings of the Helm values, an imported
+	// cluster starts with their defaults. Read takes the other features from the API.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("proxy"), dto.ProxyDetailModel{})...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("secret_scan_enabled"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("inventory_collection"), true)...)
 }
 
 func proxyHandler(model *dto.ClusterResourceModel) error {
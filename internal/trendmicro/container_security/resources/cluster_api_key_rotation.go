@@ -0,0 +1,230 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ClusterAPIKeyRotationResource{}
+	_ resource.ResourceWithConfigure   = &ClusterAPIKeyRotationResource{}
+	_ resource.ResourceWithModifyPlan  = &ClusterAPIKeyRotationResource{}
+	_ resource.ResourceWithImportState = &ClusterAPIKeyRotationResource{}
+)
+
+func NewClusterAPIKeyRotationResource() resource.Resource {
+	return &ClusterAPIKeyRotationResource{
+		client: &api.CsClient{},
+		now:    time.Now,
+	}
+}
+
+// ClusterAPIKeyRotationResource regenerates the API key of a cluster when it is created or replaced.
+// Changing rotation_trigger, or the key getting older than rotate_after, replaces it.
+type ClusterAPIKeyRotationResource struct {
+	client *api.CsClient
+	now    func() time.Time
+}
+
+func (r *ClusterAPIKeyRotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.RESOURCE_TYPE_CLUSTER_API_KEY_ROTATION
+}
+
+func (r *ClusterAPIKeyRotationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: config.RESOURCE_TYPE_CLUSTER_API_KEY_ROTATION_DESCRIPTION,
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the cluster.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the `" + config.RESOURCE_TYPE_CLUSTER + "` whose API key is rotated.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotation_trigger": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that rotate the API key when they change, for example a date or a release version.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_after": schema.StringAttribute{
+				MarkdownDescription: "Rotate the API key on the first plan after it got older than this period, for example `90d` or `2160h`. " +
+					"Accepts days (`d`) and Go duration units (`h`, `m`, `s`).",
+				Optional:   true,
+				Validators: []validator.String{periodValidator{}},
+			},
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "The regenerated API key. The key the cluster was created with, and the ones of earlier rotations, stop working.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"rotated_at": schema.StringAttribute{
+				MarkdownDescription: "The time the API key was regenerated, in RFC 3339 format.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ClusterAPIKeyRotationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
+}
+
+// ModifyPlan replaces the rotation, which regenerates the key, once the key is older than rotate_after.
+func (r *ClusterAPIKeyRotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var state, plan dto.ClusterAPIKeyRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.RotateAfter.IsNull() || plan.RotateAfter.IsUnknown() {
+		return
+	}
+
+	due, err := rotationDue(state.RotatedAt.ValueString(), plan.RotateAfter.ValueString(), r.now())
+	if err != nil || !due {
+		return
+	}
+
+	tflog.Debug(ctx, "cluster API key is older than rotate_after, planning a rotation", map[string]any{"cluster_id": state.ClusterID.ValueString()})
+	plan.ApiKey = types.StringUnknown()
+	plan.RotatedAt = types.StringUnknown()
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("rotated_at"))
+}
+
+func (r *ClusterAPIKeyRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dto.ClusterAPIKeyRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	regenerated, err := client.RegenerateClusterAPIKey(plan.ClusterID.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to rotate the cluster API key",
+			"An unexpected error occurred when regenerating the API key of the Container Security cluster "+plan.ClusterID.ValueString()+". "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	plan.ID = plan.ClusterID
+	plan.ApiKey = types.StringValue(regenerated.ApiKey)
+	plan.RotatedAt = types.StringValue(r.now().UTC().Format(time.RFC3339))
+	tflog.Trace(ctx, "rotated a cluster API key")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterAPIKeyRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dto.ClusterAPIKeyRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The key cannot be read back, only check that the cluster still exists
+	client := r.client.WithCredentials(state.Credentials)
+	if _, err := client.GetCluster(&dto.GetClusterRequest{ID: state.ClusterID.ValueString()}); err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to read cluster",
+			"Cluster ID "+state.ClusterID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only changes rotate_after, which needs no API call.
+func (r *ClusterAPIKeyRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dto.ClusterAPIKeyRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the rotation from the state, the cluster keeps its current key.
+func (r *ClusterAPIKeyRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+func (r *ClusterAPIKeyRotationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.AddError(
+		"Import Not Supported",
+		"A cluster API key cannot be read back from Vision One. Create the rotation resource instead, it regenerates the key.")
+}
+
+// rotationDue reports whether a key regenerated at rotatedAt is older than rotateAfter.
+func rotationDue(rotatedAt, rotateAfter string, now time.Time) (bool, error) {
+	period, err := parsePeriod(rotateAfter)
+	if err != nil {
+		return false, err
+	}
+	at, err := time.Parse(time.RFC3339, rotatedAt)
+	if err != nil {
+		return false, err
+	}
+	return !now.Before(at.Add(period)), nil
+}
@@ -0,0 +1,48 @@
+package resources
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePeriod(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		err   bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"2160h", 2160 * time.Hour, false},
+		{"36h30m", 36*time.Hour + 30*time.Minute, false},
+		{"0d", 0, true},
+		{"-1h", 0, true},
+		{"1.5d", 0, true},
+		{"ninety", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parsePeriod(tt.value)
+		if (err != nil) != tt.err || got != tt.want {
+			t.Errorf("parsePeriod(%q) = %v, %v; want %v, error %t", tt.value, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestRotationDue(t *testing.T) {
+	rotatedAt := "2026-01-01T00:00:00Z"
+	tests := []struct {
+		now  time.Time
+		want bool
+	}{
+		{time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC), false},
+		{time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), true},
+		{time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, tt := range tests {
+		if got, err := rotationDue(rotatedAt, "90d", tt.now); err != nil || got != tt.want {
+			t.Errorf("rotationDue at %s = %t, %v; want %t", tt.now, got, err, tt.want)
+		}
+	}
+	if _, err := rotationDue("", "90d", time.Now()); err == nil {
+		t.Error("expected an error without a rotation time")
+	}
+}
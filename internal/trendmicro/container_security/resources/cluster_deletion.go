@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const defaultAgentWindow = "15m"
+
+// agentPollInterval is how often a protected delete reads the cluster again while it waits for the agents.
+var agentPollInterval = 30 * time.Second
+
+// reportingNodes returns the nodes of the cluster when it was evaluated within window before now.
+// Vision One evaluates a cluster whenever its agents report, so an older evaluation means the agents are gone.
+func reportingNodes(cluster dto.ClusterItem, window time.Duration, now time.Time) []dto.Node {
+	if len(cluster.Nodes) == 0 {
+		return nil
+	}
+	lastEvaluated, err := time.Parse(time.RFC3339, cluster.LastEvaluatedDateTime)
+	if err != nil {
+		// Keep the cluster when the evaluation time is missing or cannot be read, deletion protection errs on the safe side
+		return cluster.Nodes
+	}
+	if now.Sub(lastEvaluated) >= window {
+		return nil
+	}
+	return cluster.Nodes
+}
+
+// waitForAgentsGone returns the nodes still reporting once wait_for_agents_gone ran out, or right away
+// without it. It returns no nodes when the agents stopped reporting.
+func waitForAgentsGone(ctx context.Context, client *api.CsClient, clusterID string, protection *dto.DeletionProtectionModel) ([]dto.Node, error) {
+	agentWindow := defaultAgentWindow
+	if !protection.AgentWindow.IsNull() && !protection.AgentWindow.IsUnknown() {
+		agentWindow = protection.AgentWindow.ValueString()
+	}
+	window, err := parsePeriod(agentWindow)
+	if err != nil {
+		return nil, err
+	}
+	var deadline time.Time
+	if !protection.WaitForAgentsGone.IsNull() {
+		wait, err := parsePeriod(protection.WaitForAgentsGone.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		deadline = time.Now().Add(wait)
+	}
+
+	for {
+		cluster, err := client.GetCluster(&dto.GetClusterRequest{ID: clusterID})
+		if err != nil {
+			return nil, err
+		}
+		nodes := reportingNodes(cluster.Item, window, time.Now())
+		if len(nodes) == 0 || !time.Now().Before(deadline) {
+			return nodes, nil
+		}
+
+		tflog.Debug(ctx, "waiting for the cluster agents to stop reporting", map[string]any{"cluster_id": clusterID, "nodes": len(nodes)})
+		select {
+		case <-ctx.Done():
+			return nodes, nil
+		case <-time.After(min(agentPollInterval, time.Until(deadline))):
+		}
+	}
+}
+
+// describeNodes lists nodes one per line for a diagnostic.
+func describeNodes(nodes []dto.Node) string {
+	lines := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		name := node.Name
+		if name == "" {
+			name = node.ID
+		}
+		lines = append(lines, fmt.Sprintf("  - %s (%d pods)", name, len(node.Pods)))
+	}
+	return strings.Join(lines, "\n")
+}
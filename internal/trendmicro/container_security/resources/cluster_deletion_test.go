@@ -0,0 +1,87 @@
+package resources
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestReportingNodes(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	nodes := []dto.Node{{ID: "n1", Name: "worker-1"}}
+	tests := []struct {
+		name          string
+		cluster       dto.ClusterItem
+		wantReporting bool
+	}{
+		{"recent", dto.ClusterItem{Nodes: nodes, LastEvaluatedDateTime: "2026-05-01T11:50:00Z"}, true},
+		{"stale", dto.ClusterItem{Nodes: nodes, LastEvaluatedDateTime: "2026-05-01T11:45:00Z"}, false},
+		{"no nodes", dto.ClusterItem{LastEvaluatedDateTime: "2026-05-01T11:59:00Z"}, false},
+		{"nodes without evaluation time", dto.ClusterItem{Nodes: nodes}, true},
+		{"never evaluated without nodes", dto.ClusterItem{}, false},
+		{"unreadable time", dto.ClusterItem{Nodes: nodes, LastEvaluatedDateTime: "yesterday"}, true},
+	}
+	for _, tt := range tests {
+		if got := reportingNodes(tt.cluster, 15*time.Minute, now); (len(got) > 0) != tt.wantReporting {
+			t.Errorf("%s: got %+v, want reporting %t", tt.name, got, tt.wantReporting)
+		}
+	}
+}
+
+func TestWaitForAgentsGone(t *testing.T) {
+	server := mockserver.NewTestServer(t)
+	client := &api.CsClient{Client: &trendmicro.Client{HostURL: server.URL, HTTPClient: &http.Client{}, BearerToken: server.APIKey()}}
+	server.Seed(mockserver.KubernetesClustersPath, mockserver.Object{
+		"id":                    "reporting",
+		"lastEvaluatedDateTime": time.Now().UTC().Format(time.RFC3339),
+		"nodes":                 []any{mockserver.Object{"id": "n1", "name": "worker-1", "pods": []any{mockserver.Object{"id": "p1"}}}},
+	})
+	server.Seed(mockserver.KubernetesClustersPath, mockserver.Object{
+		"id":                    "gone",
+		"lastEvaluatedDateTime": time.Now().Add(-time.Hour).UTC().Format(time.RFC3339),
+		"nodes":                 []any{mockserver.Object{"id": "n1", "name": "worker-1"}},
+	})
+	server.Seed(mockserver.KubernetesClustersPath, mockserver.Object{
+		"id":    "unevaluated",
+		"nodes": []any{mockserver.Object{"id": "n1", "name": "worker-1"}},
+	})
+
+	defer func(interval time.Duration) { agentPollInterval = interval }(agentPollInterval)
+	agentPollInterval = 10 * time.Millisecond
+	protection := &dto.DeletionProtectionModel{AgentWindow: types.StringValue("15m"), WaitForAgentsGone: types.StringValue("50ms")}
+
+	nodes, err := waitForAgentsGone(context.Background(), client, "reporting", protection)
+	if err != nil {
+		t.Fatalf("waitForAgentsGone: %v", err)
+	}
+	if description := describeNodes(nodes); !strings.Contains(description, "worker-1 (1 pods)") {
+		t.Errorf("expected the reporting node to be listed, got %q", description)
+	}
+	var gets int
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodGet {
+			gets++
+		}
+	}
+	if gets < 2 {
+		t.Errorf("expected the cluster to be polled, got %d reads", gets)
+	}
+
+	if nodes, err := waitForAgentsGone(context.Background(), client, "gone", &dto.DeletionProtectionModel{AgentWindow: types.StringNull(), WaitForAgentsGone: types.StringNull()}); err != nil || len(nodes) != 0 {
+		t.Errorf("expected no reporting nodes, got %+v, %v", nodes, err)
+	}
+
+	// Nodes without an evaluation time may still run agents
+	if nodes, err := waitForAgentsGone(context.Background(), client, "unevaluated", &dto.DeletionProtectionModel{AgentWindow: types.StringNull(), WaitForAgentsGone: types.StringNull()}); err != nil || len(nodes) != 1 {
+		t.Errorf("expected the unevaluated node to be reporting, got %+v, %v", nodes, err)
+	}
+}
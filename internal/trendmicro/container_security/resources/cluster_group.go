@@ -0,0 +1,220 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &ClusterGroupResource{}
+	_ resource.ResourceWithConfigure   = &ClusterGroupResource{}
+	_ resource.ResourceWithImportState = &ClusterGroupResource{}
+)
+
+func NewClusterGroupResource() resource.Resource {
+	return &ClusterGroupResource{
+		client: &api.CsClient{},
+	}
+}
+
+// ClusterGroupResource manages a Kubernetes cluster group that container_cluster resources are assigned to with group_id.
+type ClusterGroupResource struct {
+	client *api.CsClient
+}
+
+func (r *ClusterGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.RESOURCE_TYPE_CLUSTER_GROUP
+}
+
+func (r *ClusterGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: config.RESOURCE_TYPE_CLUSTER_GROUP_DESCRIPTION,
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The unique ID assigned to this cluster group. Use it as the `group_id` of a `" + config.RESOURCE_TYPE_CLUSTER + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the cluster group.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the cluster group.",
+				Optional:            true,
+			},
+			"created_date_time": schema.StringAttribute{
+				MarkdownDescription: "The time when the cluster group was created.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_date_time": schema.StringAttribute{
+				MarkdownDescription: "The time when the cluster group was last updated.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ClusterGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
+}
+
+func (r *ClusterGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dto.ClusterGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	group, err := client.CreateClusterGroup(generateClusterGroupRequest(&plan))
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to Create a cluster group",
+			"An unexpected error occurred when creating the Container Security cluster group. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	saveStateClusterGroup(&plan, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dto.ClusterGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	group, err := client.GetClusterGroup(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to read cluster group",
+			"Cluster group ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateClusterGroup(&state, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *ClusterGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dto.ClusterGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	group, err := client.UpdateClusterGroup(plan.ID.ValueString(), generateClusterGroupRequest(&plan))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update cluster group",
+			"Cluster group ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateClusterGroup(&plan, group)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *ClusterGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dto.ClusterGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteClusterGroup(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to delete cluster group",
+			"Cluster group ID "+state.ID.ValueString()+": "+err.Error()+"\n\n"+
+				"A cluster group can only be deleted once no cluster is assigned to it.",
+		)
+		return
+	}
+}
+
+func (r *ClusterGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func generateClusterGroupRequest(plan *dto.ClusterGroupResourceModel) *dto.ClusterGroupRequest {
+	return &dto.ClusterGroupRequest{
+		Name:        plan.Name.ValueString(),
+		Description: plan.Description.ValueString(),
+	}
+}
+
+func saveStateClusterGroup(state *dto.ClusterGroupResourceModel, group *dto.ClusterGroupResponse) {
+	state.ID = types.StringValue(group.ID)
+	state.Name = types.StringValue(group.Name)
+	// Keep an unset description unset, the API returns it as empty
+	if group.Description != "" || !state.Description.IsNull() {
+		state.Description = types.StringValue(group.Description)
+	}
+	state.CreatedDateTime = types.StringValue(group.CreatedDateTime)
+	state.UpdatedDateTime = types.StringValue(group.UpdatedDateTime)
+}
@@ -1,11 +1,31 @@
 package config
 
 const (
-	RESOURCE_TYPE_CLUSTER = "container_cluster"
-	RESOURCE_TYPE_POLICY  = "container_policy"
-	RESOURCE_TYPE_RULESET = "container_ruleset"
+	RESOURCE_TYPE_CLUSTER                  = "container_cluster"
+	RESOURCE_TYPE_CLUSTER_API_KEY_ROTATION = "container_cluster_api_key_rotation"
+	RESOURCE_TYPE_CLUSTER_GROUP            = "container_cluster_group"
+	RESOURCE_TYPE_CUSTOMIZABLE_TAG         = "customizable_tag"
+	RESOURCE_TYPE_ECS_CLUSTER              = "container_ecs_cluster"
+	RESOURCE_TYPE_POLICY                   = "container_policy"
+	RESOURCE_TYPE_POLICY_NAMESPACED        = "container_policy_namespaced"
+	RESOURCE_TYPE_RULESET                  = "container_ruleset"
 
-	RESOURCE_TYPE_CLUSTER_DESCRIPTION = "The `" + RESOURCE_TYPE_CLUSTER + "` resource allows you to manage Kubernetes cluster."
-	RESOURCE_TYPE_POLICY_DESCRIPTION  = "The `" + RESOURCE_TYPE_POLICY + "` resource allows you to manage policies which define the rules that are used to control what is allowed to run in your Kubernetes cluster."
-	RESOURCE_TYPE_RULESET_DESCRIPTION = "The `" + RESOURCE_TYPE_RULESET + "` resource allows you to manage several managed rules provided by Trend Micro to define a set of rules that you want to enforce for runtime security."
+	RESOURCE_TYPE_CLUSTER_DESCRIPTION                  = "The `" + RESOURCE_TYPE_CLUSTER + "` resource allows you to manage Kubernetes cluster."
+	RESOURCE_TYPE_CLUSTER_API_KEY_ROTATION_DESCRIPTION = "The `" + RESOURCE_TYPE_CLUSTER_API_KEY_ROTATION + "` resource allows you to regenerate the API key of a Kubernetes cluster, on demand or once the key reached a maximum age."
+	RESOURCE_TYPE_CLUSTER_GROUP_DESCRIPTION            = "The `" + RESOURCE_TYPE_CLUSTER_GROUP + "` resource allows you to manage groups that organize Kubernetes clusters."
+	RESOURCE_TYPE_CUSTOMIZABLE_TAG_DESCRIPTION         = "The `" + RESOURCE_TYPE_CUSTOMIZABLE_TAG + "` resource allows you to manage custom tags, key and value pairs assigned to Kubernetes clusters and other assets."
+	RESOURCE_TYPE_ECS_CLUSTER_DESCRIPTION              = "The `" + RESOURCE_TYPE_ECS_CLUSTER + "` resource allows you to manage the policy and protection settings of an Amazon ECS cluster discovered in a connected AWS account."
+	RESOURCE_TYPE_POLICY_DESCRIPTION                   = "The `" + RESOURCE_TYPE_POLICY + "` resource allows you to manage policies which define the rules that are used to control what is allowed to run in your Kubernetes cluster."
+	RESOURCE_TYPE_POLICY_NAMESPACED_DESCRIPTION        = "The `" + RESOURCE_TYPE_POLICY_NAMESPACED + "` resource allows you to manage one namespaced definition of an existing `" + RESOURCE_TYPE_POLICY + "`, so namespace overrides can live next to the workloads they apply to."
+	RESOURCE_TYPE_RULESET_DESCRIPTION                  = "The `" + RESOURCE_TYPE_RULESET + "` resource allows you to manage several managed rules provided by Trend Micro to define a set of rules that you want to enforce for runtime security."
+)
+
+const (
+	DATA_SOURCE_TYPE_CLUSTERS         = "container_clusters"
+	DATA_SOURCE_TYPE_CLUSTER_GROUP    = "container_cluster_group"
+	DATA_SOURCE_TYPE_CUSTOMIZABLE_TAG = "customizable_tag"
+	DATA_SOURCE_TYPE_ECS_CLUSTER      = "container_ecs_cluster"
+	DATA_SOURCE_TYPE_POLICIES         = "container_policies"
+	DATA_SOURCE_TYPE_RULESETS         = "container_rulesets"
+	DATA_SOURCE_TYPE_MANAGED_RULES    = "container_managed_rules"
 )
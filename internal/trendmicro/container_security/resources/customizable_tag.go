@@ -0,0 +1,200 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &CustomizableTagResource{}
+	_ resource.ResourceWithConfigure   = &CustomizableTagResource{}
+	_ resource.ResourceWithImportState = &CustomizableTagResource{}
+)
+
+func NewCustomizableTagResource() resource.Resource {
+	return &CustomizableTagResource{
+		client: &api.CsClient{},
+	}
+}
+
+// CustomizableTagResource manages a custom tag that container_cluster resources reference in customizable_tags.
+type CustomizableTagResource struct {
+	client *api.CsClient
+}
+
+func (r *CustomizableTagResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.RESOURCE_TYPE_CUSTOMIZABLE_TAG
+}
+
+func (r *CustomizableTagResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: config.RESOURCE_TYPE_CUSTOMIZABLE_TAG_DESCRIPTION,
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The tag ID. Use it in the `customizable_tags` of a `" + config.RESOURCE_TYPE_CLUSTER + "`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key": schema.StringAttribute{
+				MarkdownDescription: "The key of the tag, for example `environment`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"value": schema.StringAttribute{
+				MarkdownDescription: "The value of the tag, for example `production`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+	}
+}
+
+func (r *CustomizableTagResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
+}
+
+func (r *CustomizableTagResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dto.CustomizableTagResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	tag, err := client.CreateCustomizableTag(&dto.CustomizableTagRequest{Key: plan.Key.ValueString(), Value: plan.Value.ValueString()})
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to Create a custom tag",
+			"An unexpected error occurred when creating the custom tag "+plan.Key.ValueString()+":"+plan.Value.ValueString()+". "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	saveStateCustomizableTag(&plan, tag)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CustomizableTagResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dto.CustomizableTagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	tag, err := client.GetCustomizableTag(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to read custom tag",
+			"Tag ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateCustomizableTag(&state, tag)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update changes the tag in place, so the clusters keep referencing the same ID.
+func (r *CustomizableTagResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dto.CustomizableTagResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	tag, err := client.UpdateCustomizableTag(plan.ID.ValueString(), &dto.CustomizableTagRequest{Key: plan.Key.ValueString(), Value: plan.Value.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to update custom tag",
+			"Tag ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateCustomizableTag(&plan, tag)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *CustomizableTagResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dto.CustomizableTagResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteCustomizableTag(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to delete custom tag",
+			"Tag ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *CustomizableTagResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func saveStateCustomizableTag(state *dto.CustomizableTagResourceModel, tag *dto.CustomizableTagResponse) {
+	state.ID = types.StringValue(tag.ID)
+	state.Key = types.StringValue(tag.Key)
+	state.Value = types.StringValue(tag.Value)
+}
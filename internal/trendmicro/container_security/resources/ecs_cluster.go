@@ -0,0 +1,297 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource                = &EcsClusterResource{}
+	_ resource.ResourceWithConfigure   = &EcsClusterResource{}
+	_ resource.ResourceWithImportState = &EcsClusterResource{}
+)
+
+// ecsClusterArnPattern matches the ARN of an ECS cluster, in any AWS partition.
+var ecsClusterArnPattern = regexp.MustCompile(`^arn:aws[a-z-]*:ecs:[a-z0-9-]+:\d{12}:cluster/.+$`)
+
+func NewEcsClusterResource() resource.Resource {
+	return &EcsClusterResource{
+		client: &api.CsClient{},
+	}
+}
+
+// EcsClusterResource manages the Container Security settings of an Amazon ECS cluster. Vision One
+// discovers ECS clusters in the connected AWS accounts, so the resource adopts an existing cluster
+// instead of creating one.
+type EcsClusterResource struct {
+	client *api.CsClient
+}
+
+func (r *EcsClusterResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_" + config.RESOURCE_TYPE_ECS_CLUSTER
+}
+
+func (r *EcsClusterResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: config.RESOURCE_TYPE_ECS_CLUSTER_DESCRIPTION,
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The ID Vision One assigned to the ECS cluster.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"cluster_arn": schema.StringAttribute{
+				MarkdownDescription: "The ARN of the ECS cluster. The AWS account of the cluster must be connected to Vision One.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(ecsClusterArnPattern, "must be the ARN of an ECS cluster"),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the ECS cluster.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"aws_account_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the AWS account the cluster runs in.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "The AWS region the cluster runs in.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy_id": schema.StringAttribute{
+				MarkdownDescription: "The ID of the `" + config.RESOURCE_TYPE_POLICY + "` assigned to the cluster.",
+				Optional:            true,
+			},
+			"runtime_security_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether runtime security is enabled for the cluster.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"vulnerability_scan_enabled": schema.BoolAttribute{
+				MarkdownDescription: "Whether vulnerability scan is enabled for the cluster.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"created_date_time": schema.StringAttribute{
+				MarkdownDescription: "The time when the cluster was discovered.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_date_time": schema.StringAttribute{
+				MarkdownDescription: "The time when the cluster was last updated.",
+				Computed:            true,
+			},
+			"last_evaluated_date_time": schema.StringAttribute{
+				MarkdownDescription: "Last time of the cluster was evaluated against the policy rules.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *EcsClusterResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*trendmicro.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
+}
+
+// Create adopts the discovered cluster with the planned ARN and applies the planned settings.
+func (r *EcsClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan dto.EcsClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	discovered, err := client.GetEcsClusterByArn(ctx, plan.ClusterArn.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("cluster_arn"),
+				"ECS Cluster Not Found",
+				"Vision One has not discovered the ECS cluster "+plan.ClusterArn.ValueString()+". "+
+					"Connect its AWS account to Vision One and wait for the cluster to be discovered.",
+			)
+			return
+		}
+		resp.Diagnostics.AddError(
+			"Unable to List ECS Clusters",
+			"An unexpected error occurred when looking up the ECS cluster. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	cluster, err := client.UpdateEcsCluster(discovered.ID, generateEcsClusterRequest(&plan))
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to Update the ECS Cluster",
+			"An unexpected error occurred when applying the settings of the ECS cluster "+plan.ClusterArn.ValueString()+". "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	saveStateEcsCluster(&plan, cluster)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *EcsClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state dto.EcsClusterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	cluster, err := client.GetEcsCluster(state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to read ECS cluster",
+			"ECS cluster ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateEcsCluster(&state, cluster)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *EcsClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan dto.EcsClusterResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(plan.Credentials)
+
+	cluster, err := client.UpdateEcsCluster(plan.ID.ValueString(), generateEcsClusterRequest(&plan))
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		resp.Diagnostics.AddError(
+			"Unable to Update the ECS Cluster",
+			"ECS cluster ID "+plan.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	saveStateEcsCluster(&plan, cluster)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete unassigns the policy and disables the features, the cluster itself stays discovered.
+func (r *EcsClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state dto.EcsClusterResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client := r.client.WithCredentials(state.Credentials)
+
+	_, err := client.UpdateEcsCluster(state.ID.ValueString(), &dto.UpdateEcsClusterRequest{})
+	if err != nil {
+		if errors.Is(err, dto.ErrorNotFound) {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Unable to reset ECS cluster",
+			"ECS cluster ID "+state.ID.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+}
+
+func (r *EcsClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func generateEcsClusterRequest(plan *dto.EcsClusterResourceModel) *dto.UpdateEcsClusterRequest {
+	return &dto.UpdateEcsClusterRequest{
+		PolicyId:                 plan.PolicyId.ValueString(),
+		RuntimeSecurityEnabled:   plan.RuntimeSecurityEnabled.ValueBool(),
+		VulnerabilityScanEnabled: plan.VulnerabilityScanEnabled.ValueBool(),
+	}
+}
+
+func saveStateEcsCluster(state *dto.EcsClusterResourceModel, cluster *dto.EcsClusterItem) {
+	state.ID = types.StringValue(cluster.ID)
+	state.ClusterArn = types.StringValue(cluster.ClusterArn)
+	state.Name = types.StringValue(cluster.Name)
+	state.AwsAccountID = types.StringValue(cluster.AwsAccountID)
+	state.Region = types.StringValue(cluster.Region)
+	if cluster.PolicyId != "" {
+		state.PolicyId = types.StringValue(cluster.PolicyId)
+	} else {
+		state.PolicyId = types.StringNull()
+	}
+	state.RuntimeSecurityEnabled = types.BoolValue(cluster.RuntimeSecurityEnabled)
+	state.VulnerabilityScanEnabled = types.BoolValue(cluster.VulnerabilityScanEnabled)
+	state.CreatedDateTime = types.StringValue(cluster.CreatedDateTime)
+	state.UpdatedDateTime = types.StringValue(cluster.UpdatedDateTime)
+	state.LastEvaluatedDateTime = types.StringValue(cluster.LastEvaluatedDateTime)
+}
@@ -0,0 +1,137 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Platforms accepted by the platform argument of the cluster resource.
+const (
+	platformKubernetes = "KUBERNETES"
+	platformEKS        = "EKS"
+	platformEKSFargate = "EKS_FARGATE"
+	platformAKS        = "AKS"
+	platformGKE        = "GKE"
+	platformOpenShift  = "OPENSHIFT"
+)
+
+var platforms = []string{platformKubernetes, platformEKS, platformEKSFargate, platformAKS, platformGKE, platformOpenShift}
+
+// openShiftNamespaces is the exclusion added for the OpenShift system namespaces.
+const openShiftNamespaces = "openshift*"
+
+// helmValues mirrors the overrides.yaml of the visionone-container-security Helm chart.
+type helmValues struct {
+	VisionOne helmVisionOne `yaml:"visionOne"`
+	Proxy     *helmProxy    `yaml:"proxy,omitempty"`
+}
+
+type helmVisionOne struct {
+	BootstrapToken        string        `yaml:"bootstrapToken"`
+	Endpoint              string        `yaml:"endpoint"`
+	Exclusion             helmExclusion `yaml:"exclusion"`
+	RuntimeSecurity       helmToggle    `yaml:"runtimeSecurity"`
+	VulnerabilityScanning helmToggle    `yaml:"vulnerabilityScanning"`
+	MalwareScanning       helmToggle    `yaml:"malwareScanning"`
+	SecretScanning        helmToggle    `yaml:"secretScanning"`
+	InventoryCollection   helmToggle    `yaml:"inventoryCollection"`
+	FargateInjector       *helmToggle   `yaml:"fargateInjector,omitempty"`
+}
+
+type helmExclusion struct {
+	Namespaces []string `yaml:"namespaces"`
+}
+
+type helmToggle struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+type helmProxy struct {
+	HttpsProxy string `yaml:"httpsProxy"`
+	Username   string `yaml:"username,omitempty"`
+	Password   string `yaml:"password,omitempty"`
+}
+
+// clusterPlatform returns the platform the chart is rendered for. An unset platform argument falls
+// back to the orchestrator reported by the cluster agent.
+func clusterPlatform(model *dto.ClusterResourceModel) string {
+	if platform := model.Platform.ValueString(); platform != "" {
+		return strings.ToUpper(platform)
+	}
+	if strings.Contains(strings.ToLower(model.Orchestrator.ValueString()), "openshift") {
+		return platformOpenShift
+	}
+	return platformKubernetes
+}
+
+// renderHelmValues renders the Helm overrides for the cluster. It must run after proxyHandler so
+// the proxy endpoint is known.
+func renderHelmValues(ctx context.Context, model *dto.ClusterResourceModel) (string, error) {
+	namespaces := []string{}
+	if !model.Namespaces.IsNull() && !model.Namespaces.IsUnknown() {
+		if diags := model.Namespaces.ElementsAs(ctx, &namespaces, false); diags.HasError() {
+			return "", fmt.Errorf("unable to read namespaces: %v", diags)
+		}
+	}
+
+	values := helmValues{
+		VisionOne: helmVisionOne{
+			BootstrapToken:        model.ApiKey.ValueString(),
+			Endpoint:              model.Endpoint.ValueString(),
+			RuntimeSecurity:       helmToggle{Enabled: model.RuntimeSecurityEnabled.ValueBool()},
+			VulnerabilityScanning: helmToggle{Enabled: model.VulnerabilityScanEnabled.ValueBool()},
+			MalwareScanning:       helmToggle{Enabled: model.MalwareScanEnabled.ValueBool()},
+			SecretScanning:        helmToggle{Enabled: model.SecretScanEnabled.ValueBool()},
+			InventoryCollection:   helmToggle{Enabled: model.InventoryCollection.ValueBool()},
+		},
+	}
+
+	switch clusterPlatform(model) {
+	case platformOpenShift:
+		if !slices.Contains(namespaces, openShiftNamespaces) {
+			namespaces = append(namespaces, openShiftNamespaces)
+		}
+	case platformEKSFargate:
+		values.VisionOne.FargateInjector = &helmToggle{Enabled: true}
+	default:
+		// The chart runs on EKS, AKS and GKE nodes with its generic Kubernetes settings
+	}
+	slices.Sort(namespaces)
+	values.VisionOne.Exclusion.Namespaces = namespaces
+
+	if !model.Proxy.HttpsProxy.IsNull() {
+		values.Proxy = &helmProxy{
+			HttpsProxy: model.Proxy.HttpsProxy.ValueString(),
+			Username:   model.Proxy.Username.ValueString(),
+			Password:   model.Proxy.Password.ValueString(),
+		}
+	}
+
+	out, err := yaml.Marshal(&values)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+// setHelmValues stores the rendered Helm overrides in the model. Clusters imported without their
+// enrollment API key get no overrides.
+func setHelmValues(ctx context.Context, model *dto.ClusterResourceModel) error {
+	if model.ApiKey.IsNull() || model.ApiKey.IsUnknown() {
+		model.HelmValuesYaml = types.StringNull()
+		return nil
+	}
+	rendered, err := renderHelmValues(ctx, model)
+	if err != nil {
+		return err
+	}
+	model.HelmValuesYaml = types.StringValue(rendered)
+	return nil
+}
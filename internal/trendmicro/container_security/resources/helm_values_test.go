@@ -0,0 +1,115 @@
+package resources
+
+import (
+	"context"
+	"testing"
+
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+func newHelmTestModel() *dto.ClusterResourceModel {
+	return &dto.ClusterResourceModel{
+		ApiKey:                   types.StringValue("bootstrap-token"),
+		Endpoint:                 types.StringValue("https://container.example.com"),
+		Namespaces:               types.SetValueMust(types.StringType, []attr.Value{types.StringValue("kube-system")}),
+		RuntimeSecurityEnabled:   types.BoolValue(true),
+		VulnerabilityScanEnabled: types.BoolValue(false),
+		MalwareScanEnabled:       types.BoolValue(true),
+		SecretScanEnabled:        types.BoolValue(false),
+		InventoryCollection:      types.BoolValue(true),
+		Orchestrator:             types.StringNull(),
+		Platform:                 types.StringNull(),
+		Proxy: dto.ProxyDetailModel{
+			HttpsProxy: types.StringNull(),
+		},
+	}
+}
+
+func renderTestValues(t *testing.T, model *dto.ClusterResourceModel) helmValues {
+	t.Helper()
+	if err := setHelmValues(context.Background(), model); err != nil {
+		t.Fatalf("setHelmValues: %v", err)
+	}
+	var values helmValues
+	if err := yaml.Unmarshal([]byte(model.HelmValuesYaml.ValueString()), &values); err != nil {
+		t.Fatalf("rendered values are not YAML: %v\n%s", err, model.HelmValuesYaml.ValueString())
+	}
+	return values
+}
+
+func TestRenderHelmValues(t *testing.T) {
+	model := newHelmTestModel()
+	model.Proxy = dto.ProxyDetailModel{
+		HttpsProxy: types.StringValue("http://10.0.0.1:3128"),
+		Username:   types.StringValue("user"),
+		Password:   types.StringValue("secret"),
+	}
+
+	values := renderTestValues(t, model)
+	v1 := values.VisionOne
+	if v1.BootstrapToken != "bootstrap-token" || v1.Endpoint != "https://container.example.com" {
+		t.Errorf("unexpected enrollment settings %+v", v1)
+	}
+	if !v1.RuntimeSecurity.Enabled || v1.VulnerabilityScanning.Enabled || !v1.MalwareScanning.Enabled ||
+		v1.SecretScanning.Enabled || !v1.InventoryCollection.Enabled {
+		t.Errorf("unexpected feature toggles %+v", v1)
+	}
+	if len(v1.Exclusion.Namespaces) != 1 || v1.Exclusion.Namespaces[0] != "kube-system" {
+		t.Errorf("unexpected exclusions %v", v1.Exclusion.Namespaces)
+	}
+	if v1.FargateInjector != nil {
+		t.Error("fargate injector rendered for a generic cluster")
+	}
+	if values.Proxy == nil || values.Proxy.HttpsProxy != "http://10.0.0.1:3128" || values.Proxy.Password != "secret" {
+		t.Errorf("unexpected proxy %+v", values.Proxy)
+	}
+}
+
+func TestRenderHelmValuesPlatforms(t *testing.T) {
+	model := newHelmTestModel()
+	model.Orchestrator = types.StringValue("Red Hat OpenShift")
+	values := renderTestValues(t, model)
+	if got := values.VisionOne.Exclusion.Namespaces; len(got) != 2 || got[1] != openShiftNamespaces {
+		t.Errorf("expected OpenShift namespaces to be excluded, got %v", got)
+	}
+	if values.Proxy != nil {
+		t.Errorf("proxy rendered without a proxy block: %+v", values.Proxy)
+	}
+
+	model = newHelmTestModel()
+	model.Platform = types.StringValue(platformEKSFargate)
+	values = renderTestValues(t, model)
+	if values.VisionOne.FargateInjector == nil || !values.VisionOne.FargateInjector.Enabled {
+		t.Error("expected the fargate injector on EKS Fargate")
+	}
+
+	generic := newHelmTestModel()
+	if err := setHelmValues(context.Background(), generic); err != nil {
+		t.Fatal(err)
+	}
+	for _, platform := range []string{platformEKS, platformAKS, platformGKE} {
+		model = newHelmTestModel()
+		model.Platform = types.StringValue(platform)
+		if err := setHelmValues(context.Background(), model); err != nil {
+			t.Fatal(err)
+		}
+		if !model.HelmValuesYaml.Equal(generic.HelmValuesYaml) {
+			t.Errorf("expected %s to render the generic Kubernetes values, got\n%s", platform, model.HelmValuesYaml.ValueString())
+		}
+	}
+}
+
+func TestSetHelmValuesWithoutAPIKey(t *testing.T) {
+	model := newHelmTestModel()
+	model.ApiKey = types.StringNull()
+	if err := setHelmValues(context.Background(), model); err != nil {
+		t.Fatalf("setHelmValues: %v", err)
+	}
+	if !model.HelmValuesYaml.IsNull() {
+		t.Errorf("expected no values for an imported cluster, got %q", model.HelmValuesYaml.ValueString())
+	}
+}
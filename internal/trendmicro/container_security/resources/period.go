@@ -0,0 +1,57 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// parsePeriod parses a Go duration, also accepting a whole number of days such as 90d.
+func parsePeriod(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n <= 0 {
+			return 0, fmt.Errorf("invalid number of days %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("period %q must be positive", s)
+	}
+	return d, nil
+}
+
+var _ validator.String = periodValidator{}
+
+// periodValidator checks that a string parses with parsePeriod.
+type periodValidator struct{}
+
+func (v periodValidator) Description(ctx context.Context) string {
+	return "value must be a positive number of days such as 90d, or a Go duration such as 2160h"
+}
+
+func (v periodValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a positive number of days such as `90d`, or a Go duration such as `2160h`"
+}
+
+func (v periodValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := parsePeriod(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Period",
+			"The value must be a positive number of days such as '90d', or a duration such as '2160h'. Got: "+req.ConfigValue.ValueString(),
+		)
+	}
+}
@@ -17,9 +17,10 @@ import (
 )
 
 var (
-	_ resource.Resource                = &PolicyResource{}
-	_ resource.ResourceWithConfigure   = &PolicyResource{}
-	_ resource.ResourceWithImportState = &PolicyResource{}
+	_ resource.Resource                   = &PolicyResource{}
+	_ resource.ResourceWithConfigure      = &PolicyResource{}
+	_ resource.ResourceWithImportState    = &PolicyResource{}
+	_ resource.ResourceWithValidateConfig = &PolicyResource{}
 )
 
 var (
@@ -55,7 +56,7 @@ func (p *PolicyResource) Configure(ctx context.Context, request resource.Configu
 		return
 	}
 
-	p.client.Client = client
+	p.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
 }
 
 func (p *PolicyResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
@@ -66,12 +67,32 @@ func (p *PolicyResource) Schema(ctx context.Context, request resource.SchemaRequ
 	response.Schema = generatePolicySchema()
 }
 
+func (p *PolicyResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data dto.PolicyResourceModel
+	// Lists that are not known yet cannot be read into the model, they are validated on a later call
+	if diags := request.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+
+	if data.PolicyDefault != nil {
+		validatePolicyRules(path.Root(DefaultSchemaName).AtName(RulesSchemaName), data.PolicyDefault.PolicyRuleList, &response.Diagnostics)
+		validatePolicyRules(path.Root(DefaultSchemaName).AtName(ExceptionsSchemaName), data.PolicyDefault.PolicyExceptionList, &response.Diagnostics)
+	}
+	for i, namespaced := range data.PolicyNamespacedList {
+		namespacedPath := path.Root(NamespacedListSchemaName).AtListIndex(i)
+		validatePolicyRules(namespacedPath.AtName(RulesSchemaName), namespaced.PolicyRuleList, &response.Diagnostics)
+		validatePolicyRules(namespacedPath.AtName(ExceptionsSchemaName), namespaced.PolicyExceptionList, &response.Diagnostics)
+	}
+}
+
 func (p *PolicyResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
 	var data dto.PolicyResourceModel
 
 	// Read Terraform plan data into the model
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
 
+	client := p.client.WithCredentials(data.Credentials)
+
 	if response.Diagnostics.HasError() {
 		return
 	}
@@ -86,7 +107,7 @@ func (p *PolicyResource) Create(ctx context.Context, request resource.CreateRequ
 		return
 	}
 
-	apiResponse, err := p.client.CreatePolicy(&apiRequest)
+	apiResponse, err := client.CreatePolicy(&apiRequest)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		response.Diagnostics.AddError(
@@ -103,6 +124,7 @@ func (p *PolicyResource) Create(ctx context.Context, request resource.CreateRequ
 	data.RulesetsUpdatedDateTime = types.StringValue(apiResponse.RulesetsUpdatedDateTime)
 	data.MalwareScanEnabled = types.BoolValue(*apiResponse.MalwareScan.Schedule.Enabled)
 	data.SecretScanEnabled = types.BoolValue(*apiResponse.SecretScan.Schedule.Enabled)
+	response.Diagnostics.Append(trendmicro.SetETag(ctx, response.Private, apiResponse.ETag)...)
 
 	tflog.Trace(ctx, "created a policy resource")
 
@@ -243,9 +265,6 @@ func generatePolicyRequestForPolicyRuleList(data []dto.PolicyRuleResourceModel)
 	}
 	for _, rule := range data {
 		policyRule := dto.PolicyRule{}
-		if !rule.Type.IsNull() {
-			policyRule.Type = rule.Type.ValueString()
-		}
 		if !rule.Enabled.IsNull() {
 			policyRule.Enabled = rule.Enabled.ValueBool()
 		}
@@ -256,23 +275,8 @@ func generatePolicyRequestForPolicyRuleList(data []dto.PolicyRuleResourceModel)
 			policyRule.Mitigation = rule.Mitigation.ValueString()
 		}
 
-		if rule.PolicyRuleStatement != nil && rule.PolicyRuleStatement.PolicyRulePropertyList != nil {
-			policyRule.PolicyRuleStatement = &dto.PolicyRuleStatement{}
-			for _, property := range rule.PolicyRuleStatement.PolicyRulePropertyList {
-				policyRuleProperty := dto.PolicyRuleProperty{}
-				if !property.Key.IsNull() {
-					policyRuleProperty.Key = property.Key.ValueString()
-				}
-				if !property.Value.IsNull() {
-					policyRuleProperty.Value = property.Value.ValueString()
-				}
-
-				policyRule.PolicyRuleStatement.PolicyRulePropertyList = append(
-					policyRule.PolicyRuleStatement.PolicyRulePropertyList, policyRuleProperty)
-			}
-		} else {
-			policyRule.PolicyRuleStatement = nil
-		}
+		// Typed statements are converted to the key/value properties of their rule type
+		policyRule.Type, policyRule.PolicyRuleStatement = ruleTypeAndStatement(&rule)
 
 		result = append(result, policyRule)
 	}
@@ -286,11 +290,13 @@ func (p *PolicyResource) Read(ctx context.Context, request resource.ReadRequest,
 	// Read Terraform prior state data into the model
 	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
 
+	client := p.client.WithCredentials(data.Credentials)
+
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	apiResponse, err := p.client.GetPolicy(data.ID.ValueString())
+	apiResponse, err := client.GetPolicy(data.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, dto.ErrorNotFound) {
 			tflog.Debug(ctx, err.Error())
@@ -308,29 +314,35 @@ func (p *PolicyResource) Read(ctx context.Context, request resource.ReadRequest,
 	}
 
 	tflog.Trace(ctx, "read a resource")
+	response.Diagnostics.Append(trendmicro.SetETag(ctx, response.Private, apiResponse.ETag)...)
 	data.ID = types.StringValue(apiResponse.ID)
 	data.Name = types.StringValue(apiResponse.Name)
 	if apiResponse.Description != "" {
 		data.Description = types.StringValue(apiResponse.Description)
 	}
 
+	priorDefault := dto.PolicyDefaultResourceModel{}
+	if data.PolicyDefault != nil {
+		priorDefault = *data.PolicyDefault
+	}
 	if apiResponse.Default != nil {
 		data.PolicyDefault = &dto.PolicyDefaultResourceModel{}
 		if apiResponse.Default.PolicyRuleList != nil {
 			// Save the policy default object's rule list state
-			data.PolicyDefault.PolicyRuleList = saveStatePolicyRuleList(apiResponse.Default.PolicyRuleList)
+			data.PolicyDefault.PolicyRuleList = saveStatePolicyRuleList(apiResponse.Default.PolicyRuleList, priorDefault.PolicyRuleList)
 		}
 		if apiResponse.Default.PolicyExceptionList != nil {
 			// Save the policy default object's exception list state
-			data.PolicyDefault.PolicyExceptionList = saveStatePolicyRuleList(apiResponse.Default.PolicyExceptionList)
+			data.PolicyDefault.PolicyExceptionList = saveStatePolicyRuleList(apiResponse.Default.PolicyExceptionList, priorDefault.PolicyExceptionList)
 		}
 	} else {
 		data.PolicyDefault = nil
 	}
 
-	// Save the policy namespaced list state
-	if apiResponse.Namespaced != nil {
-		data.PolicyNamespacedList = saveStatePolicyNamespacedList(apiResponse.Namespaced)
+	// Save the policy namespaced list state, leaving out the definitions of container_policy_namespaced resources
+	owned := ownedPolicyNamespaced(apiResponse.Namespaced, data.PolicyNamespacedList)
+	if apiResponse.Namespaced != nil && (len(owned) > 0 || data.PolicyNamespacedList != nil) {
+		data.PolicyNamespacedList = saveStatePolicyNamespacedList(owned, data.PolicyNamespacedList)
 	} else {
 		data.PolicyNamespacedList = nil
 	}
@@ -343,10 +355,10 @@ func (p *PolicyResource) Read(ctx context.Context, request resource.ReadRequest,
 	data.UpdatedDateTime = types.StringValue(apiResponse.UpdatedDateTime)
 	data.RulesetsUpdatedDateTime = types.StringValue(apiResponse.RulesetsUpdatedDateTime)
 	data.MalwareScanEnabled = types.BoolValue(*apiResponse.MalwareScan.Schedule.Enabled)
-	data.MalwareScanSchedule = types.StringValue(*apiResponse.MalwareScan.Schedule.Cron)
+	data.MalwareScanSchedule = types.StringPointerValue(apiResponse.MalwareScan.Schedule.Cron)
 	data.MalwareScanMitigation = types.StringValue(*apiResponse.MalwareScan.Mitigation)
 	data.SecretScanEnabled = types.BoolValue(*apiResponse.SecretScan.Schedule.Enabled)
-	data.SecretScanSchedule = types.StringValue(*apiResponse.SecretScan.Schedule.Cron)
+	data.SecretScanSchedule = types.StringPointerValue(apiResponse.SecretScan.Schedule.Cron)
 	data.SecretScanMitigation = types.StringValue(*apiResponse.SecretScan.Mitigation)
 	data.SecretScanSkipIfRuleNotChange = types.BoolValue(*apiResponse.SecretScan.Schedule.SkipIfRuleNotChange)
 	data.SecretScanExcludePaths = []types.String{}
@@ -360,6 +372,46 @@ func (p *PolicyResource) Read(ctx context.Context, request resource.ReadRequest,
 	}
 }
 
+// ownedPolicyNamespaced returns the namespaced definitions of a policy that the policy resource
+// manages: those in its prior state. The others belong to container_policy_namespaced resources. An
+// imported policy has no prior state and owns none, the first apply claims the configured ones.
+func ownedPolicyNamespaced(apiNamespaced []dto.PolicyNamespaced, prior []dto.PolicyNamespacedResourceModel) []dto.PolicyNamespaced {
+	names := namespacedNames(prior)
+	owned := make([]dto.PolicyNamespaced, 0, len(apiNamespaced))
+	for _, namespaced := range apiNamespaced {
+		if names[namespaced.Name] {
+			owned = append(owned, namespaced)
+		}
+	}
+	return owned
+}
+
+// mergePolicyNamespaced returns the planned namespaced definitions followed by the current ones the
+// policy resource neither plans nor had in its prior state, so updating the policy keeps the
+// definitions of container_policy_namespaced resources.
+func mergePolicyNamespaced(planned, current []dto.PolicyNamespaced, prior []dto.PolicyNamespacedResourceModel) []dto.PolicyNamespaced {
+	names := namespacedNames(prior)
+	result := make([]dto.PolicyNamespaced, 0, len(planned)+len(current))
+	for _, namespaced := range planned {
+		names[namespaced.Name] = true
+		result = append(result, namespaced)
+	}
+	for _, namespaced := range current {
+		if !names[namespaced.Name] {
+			result = append(result, namespaced)
+		}
+	}
+	return result
+}
+
+func namespacedNames(namespacedList []dto.PolicyNamespacedResourceModel) map[string]bool {
+	names := make(map[string]bool, len(namespacedList))
+	for _, namespaced := range namespacedList {
+		names[namespaced.Name.ValueString()] = true
+	}
+	return names
+}
+
 func saveStatePolicyRuntime(apiResponsePolicyRuntime *dto.PolicyRuntime) *dto.PolicyRuntimeResourceModel {
 	result := &dto.PolicyRuntimeResourceModel{}
 	resultRulesetList := make([]dto.PolicyRulesetResourceModel, 0)
@@ -380,9 +432,13 @@ func saveStatePolicyRuntime(apiResponsePolicyRuntime *dto.PolicyRuntime) *dto.Po
 	return result
 }
 
-func saveStatePolicyNamespacedList(apiResponsePolicyNamespaced []dto.PolicyNamespaced) []dto.PolicyNamespacedResourceModel {
+func saveStatePolicyNamespacedList(apiResponsePolicyNamespaced []dto.PolicyNamespaced, prior []dto.PolicyNamespacedResourceModel) []dto.PolicyNamespacedResourceModel {
 	resultList := make([]dto.PolicyNamespacedResourceModel, 0)
-	for _, namespaced := range apiResponsePolicyNamespaced {
+	for i, namespaced := range apiResponsePolicyNamespaced {
+		var priorNamespaced dto.PolicyNamespacedResourceModel
+		if i < len(prior) {
+			priorNamespaced = prior[i]
+		}
 		namespacedResult := dto.PolicyNamespacedResourceModel{}
 		namespacedResult.Name = types.StringValue(namespaced.Name)
 		namespacedResult.Namespaces = make([]types.String, 0)
@@ -392,13 +448,13 @@ func saveStatePolicyNamespacedList(apiResponsePolicyNamespaced []dto.PolicyNames
 
 		if namespaced.PolicyRuleList != nil {
 			// Save the namespaced object's rule list state
-			namespacedResult.PolicyRuleList = saveStatePolicyRuleList(namespaced.PolicyRuleList)
+			namespacedResult.PolicyRuleList = saveStatePolicyRuleList(namespaced.PolicyRuleList, priorNamespaced.PolicyRuleList)
 		} else {
 			namespacedResult.PolicyRuleList = nil
 		}
 		if namespaced.PolicyExceptionList != nil {
 			// Save the namespaced object's exception list state
-			namespacedResult.PolicyExceptionList = saveStatePolicyRuleList(namespaced.PolicyExceptionList)
+			namespacedResult.PolicyExceptionList = saveStatePolicyRuleList(namespaced.PolicyExceptionList, priorNamespaced.PolicyExceptionList)
 		} else {
 			namespacedResult.PolicyExceptionList = nil
 		}
@@ -409,9 +465,11 @@ func saveStatePolicyNamespacedList(apiResponsePolicyNamespaced []dto.PolicyNames
 	return resultList
 }
 
-func saveStatePolicyRuleList(apiResponsePolicyRule []dto.PolicyRule) []dto.PolicyRuleResourceModel {
+// saveStatePolicyRuleList converts the API rules to state. prior is the state the rules are read
+// into, so each rule keeps the statement form it was configured with.
+func saveStatePolicyRuleList(apiResponsePolicyRule []dto.PolicyRule, prior []dto.PolicyRuleResourceModel) []dto.PolicyRuleResourceModel {
 	resultList := make([]dto.PolicyRuleResourceModel, 0)
-	for _, rule := range apiResponsePolicyRule {
+	for i, rule := range apiResponsePolicyRule {
 		ruleResult := dto.PolicyRuleResourceModel{}
 		ruleResult.Type = types.StringValue(rule.Type)
 		ruleResult.Enabled = types.BoolValue(rule.Enabled)
@@ -419,20 +477,11 @@ func saveStatePolicyRuleList(apiResponsePolicyRule []dto.PolicyRule) []dto.Polic
 		ruleResult.Mitigation = types.StringValue(rule.Mitigation)
 
 		// Save the  default object's rule statement object's property list
-		if rule.PolicyRuleStatement != nil && rule.PolicyRuleStatement.PolicyRulePropertyList != nil {
-			ruleResult.PolicyRuleStatement = &dto.PolicyRuleStatementResourceModel{}
-			ruleResult.PolicyRuleStatement.PolicyRulePropertyList = make([]dto.PolicyRulePropertyResourceModel, 0)
-			for _, property := range rule.PolicyRuleStatement.PolicyRulePropertyList {
-				propertyResult := dto.PolicyRulePropertyResourceModel{}
-				propertyResult.Key = types.StringValue(property.Key)
-				propertyResult.Value = types.StringValue(property.Value)
-
-				ruleResult.PolicyRuleStatement.PolicyRulePropertyList = append(
-					ruleResult.PolicyRuleStatement.PolicyRulePropertyList, propertyResult)
-			}
-		} else {
-			ruleResult.PolicyRuleStatement = nil
+		var priorRule *dto.PolicyRuleResourceModel
+		if i < len(prior) {
+			priorRule = &prior[i]
 		}
+		loadRuleStatement(&ruleResult, rule.PolicyRuleStatement, priorRule)
 
 		resultList = append(resultList, ruleResult)
 	}
@@ -446,6 +495,8 @@ func (p *PolicyResource) Update(ctx context.Context, request resource.UpdateRequ
 	// Read Terraform plan data into the model
 	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
 
+	client := p.client.WithCredentials(data.Credentials)
+
 	if response.Diagnostics.HasError() {
 		return
 	}
@@ -460,9 +511,40 @@ func (p *PolicyResource) Update(ctx context.Context, request resource.UpdateRequ
 		return
 	}
 
-	apiResponse, err := p.client.UpdatePolicy(data.ID.ValueString(), &apiRequest)
+	etag, diags := trendmicro.GetETag(ctx, request.Private)
+	response.Diagnostics.Append(diags...)
+
+	var priorNamespaced []dto.PolicyNamespacedResourceModel
+	response.Diagnostics.Append(request.State.GetAttribute(ctx, path.Root(NamespacedListSchemaName), &priorNamespaced)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	// Keep the namespaced definitions of container_policy_namespaced resources
+	unlock := lockPolicy(data.ID.ValueString())
+	defer unlock()
+	currentPolicy, err := client.GetPolicy(data.ID.ValueString())
 	if err != nil {
-		if errors.Is(err, dto.ErrorNotFound) {
+		tflog.Debug(ctx, err.Error())
+		response.Diagnostics.AddError(
+			"Unable to Get a policy id "+data.ID.ValueString(),
+			"An unexpected error occurred when getting the Container Security policy. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+	namespacedList := mergePolicyNamespaced(generatePolicyRequestForPolicyNamespacedList(data.PolicyNamespacedList), currentPolicy.Namespaced, priorNamespaced)
+	apiRequest.PolicyNamespacedList = &namespacedList
+
+	apiResponse, err := client.UpdatePolicy(data.ID.ValueString(), etag, &apiRequest)
+	if err != nil {
+		if errors.Is(err, dto.ErrorPreconditionFailed) {
+			tflog.Debug(ctx, err.Error())
+			response.Diagnostics.AddError(
+				trendmicro.ConcurrentModificationSummary,
+				trendmicro.ConcurrentModificationDetail("Container Security policy", data.ID.ValueString()))
+			return
+		} else if errors.Is(err, dto.ErrorNotFound) {
 			tflog.Debug(ctx, err.Error())
 			response.Diagnostics.AddError(
 				"Unable to found policy id "+data.ID.ValueString(),
@@ -483,6 +565,7 @@ func (p *PolicyResource) Update(ctx context.Context, request resource.UpdateRequ
 
 	data.UpdatedDateTime = types.StringValue(apiResponse.UpdatedDateTime)
 	data.RulesetsUpdatedDateTime = types.StringValue(apiResponse.RulesetsUpdatedDateTime)
+	response.Diagnostics.Append(trendmicro.SetETag(ctx, response.Private, apiResponse.ETag)...)
 
 	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
 	if response.Diagnostics.HasError() {
@@ -516,13 +599,7 @@ func generateUpdatePolicyRequest(data *dto.PolicyResourceModel) (dto.UpdatePolic
 		result.PolicyDefault = nil
 	}
 
-	// Convert the policy namespaced list
-	if data.PolicyNamespacedList != nil {
-		result.PolicyNamespacedList =
-			generatePolicyRequestForPolicyNamespacedList(data.PolicyNamespacedList)
-	} else {
-		result.PolicyNamespacedList = nil
-	}
+	// The namespaced list is merged with the current definitions by Update
 
 	// Convert the policy runtime object
 	if data.PolicyRuntime != nil && data.PolicyRuntime.PolicyRulesetList != nil {
@@ -572,11 +649,13 @@ func (p *PolicyResource) Delete(ctx context.Context, request resource.DeleteRequ
 	// Read Terraform prior state data into the model
 	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
 
+	client := p.client.WithCredentials(data.Credentials)
+
 	if response.Diagnostics.HasError() {
 		return
 	}
 
-	err := p.client.DeletePolicy(data.ID.ValueString())
+	err := client.DeletePolicy(data.ID.ValueString())
 	if err != nil {
 		if errors.Is(err, dto.ErrorNotFound) {
 			tflog.Debug(ctx, err.Error())
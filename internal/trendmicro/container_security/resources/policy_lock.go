@@ -0,0 +1,15 @@
+package resources
+
+import "sync"
+
+// policyLocks holds one mutex per policy ID. The policy API only replaces whole policies, so
+// resources editing part of a policy read, modify and write it back while holding its lock, keeping
+// concurrent resources in one apply from overwriting each other.
+var policyLocks sync.Map
+
+// lockPolicy locks the policy with the given ID and returns the function that unlocks it.
+func lockPolicy(id string) func() {
+	mu, _ := policyLocks.LoadOrStore(id, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	return mu.(*sync.Mutex).Unlock
+}
@@ -0,0 +1,332 @@
+package resources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	PolicyIDSchemaName = "policy_id"
+
+	NamespacedPolicyIDSchemaMarkdownDescription       = "The ID of the definition, in the format `<policy_id>/<name>`."
+	NamespacedPolicyPolicyIDSchemaMarkdownDescription = "The ID of the `" + config.RESOURCE_TYPE_POLICY + "` the definition is attached to."
+)
+
+var (
+	_ resource.Resource                   = &NamespacedPolicyResource{}
+	_ resource.ResourceWithConfigure      = &NamespacedPolicyResource{}
+	_ resource.ResourceWithImportState    = &NamespacedPolicyResource{}
+	_ resource.ResourceWithValidateConfig = &NamespacedPolicyResource{}
+)
+
+var ErrNamespacedPolicyExists = errors.New("the policy already has a namespaced definition with this name")
+
+func NewNamespacedPolicyResource() resource.Resource {
+	return &NamespacedPolicyResource{
+		client: &api.CsClient{},
+	}
+}
+
+// NamespacedPolicyResource manages one namespaced definition of a policy. The policy API has no
+// endpoint for a single definition, so every change reads the policy, edits its namespaced list and
+// writes it back while holding the policy lock.
+type NamespacedPolicyResource struct {
+	client *api.CsClient
+}
+
+func (p *NamespacedPolicyResource) Configure(ctx context.Context, request resource.ConfigureRequest, response *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if request.ProviderData == nil {
+		return
+	}
+
+	client, ok := request.ProviderData.(*trendmicro.Client)
+	if !ok {
+		response.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trendmicro.Client, got: %T. Please report this issue to the provider developers.", request.ProviderData),
+		)
+		return
+	}
+
+	p.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
+}
+
+func (p *NamespacedPolicyResource) Metadata(ctx context.Context, request resource.MetadataRequest, response *resource.MetadataResponse) {
+	response.TypeName = request.ProviderTypeName + "_" + config.RESOURCE_TYPE_POLICY_NAMESPACED
+}
+
+func (p *NamespacedPolicyResource) Schema(ctx context.Context, request resource.SchemaRequest, response *resource.SchemaResponse) {
+	response.Schema = schema.Schema{
+		Description: config.RESOURCE_TYPE_POLICY_NAMESPACED_DESCRIPTION,
+		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
+			IDSchemaName: schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: NamespacedPolicyIDSchemaMarkdownDescription,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			PolicyIDSchemaName: schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: NamespacedPolicyPolicyIDSchemaMarkdownDescription,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			NamespacedNameSchemaName: schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: NamespacedNameSchemaMarkdownDescription,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			NamespacedNamespacesSchemaName: schema.ListAttribute{
+				Required:            true,
+				MarkdownDescription: NamespacedNamespacesSchemaMarkdownDescription,
+				ElementType:         types.StringType,
+			},
+			RulesSchemaName:      generatePolicyRuleListSchema(),
+			ExceptionsSchemaName: generatePolicyExceptionListSchema(),
+		},
+	}
+}
+
+func (p *NamespacedPolicyResource) ValidateConfig(ctx context.Context, request resource.ValidateConfigRequest, response *resource.ValidateConfigResponse) {
+	var data dto.NamespacedPolicyResourceModel
+	// Lists that are not known yet cannot be read into the model, they are validated on a later call
+	if diags := request.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+
+	validatePolicyRules(path.Root(RulesSchemaName), data.PolicyRuleList, &response.Diagnostics)
+	validatePolicyRules(path.Root(ExceptionsSchemaName), data.PolicyExceptionList, &response.Diagnostics)
+}
+
+func (p *NamespacedPolicyResource) Create(ctx context.Context, request resource.CreateRequest, response *resource.CreateResponse) {
+	var data dto.NamespacedPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := p.modifyPolicy(&data, func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+		if namespacedIndex(namespacedList, data.Name.ValueString()) >= 0 {
+			return nil, ErrNamespacedPolicyExists
+		}
+		return append(namespacedList, generateNamespacedPolicyRequest(&data)), nil
+	})
+	if err != nil {
+		p.addModifyError(ctx, &response.Diagnostics, &data, "create", err)
+		return
+	}
+
+	data.ID = types.StringValue(data.PolicyID.ValueString() + "/" + data.Name.ValueString())
+	tflog.Trace(ctx, "created a namespaced policy resource")
+
+	// Save data into Terraform state
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (p *NamespacedPolicyResource) Read(ctx context.Context, request resource.ReadRequest, response *resource.ReadResponse) {
+	var data dto.NamespacedPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	apiResponse, err := p.client.WithCredentials(data.Credentials).GetPolicy(data.PolicyID.ValueString())
+	if err != nil {
+		tflog.Debug(ctx, err.Error())
+		if errors.Is(err, dto.ErrorNotFound) {
+			response.State.RemoveResource(ctx)
+			return
+		}
+		response.Diagnostics.AddError(
+			"Unable to Get a policy id "+data.PolicyID.ValueString(),
+			"An unexpected error occurred when getting the Container Security policy. "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+		return
+	}
+
+	i := namespacedIndex(apiResponse.Namespaced, data.Name.ValueString())
+	if i < 0 {
+		tflog.Debug(ctx, "namespaced definition "+data.ID.ValueString()+" was removed from the policy")
+		response.State.RemoveResource(ctx)
+		return
+	}
+
+	prior := dto.PolicyNamespacedResourceModel{
+		PolicyRuleList:      data.PolicyRuleList,
+		PolicyExceptionList: data.PolicyExceptionList,
+	}
+	namespaced := saveStatePolicyNamespacedList(apiResponse.Namespaced[i:i+1], []dto.PolicyNamespacedResourceModel{prior})[0]
+	data.ID = types.StringValue(data.PolicyID.ValueString() + "/" + data.Name.ValueString())
+	data.Namespaces = namespaced.Namespaces
+	data.PolicyRuleList = namespaced.PolicyRuleList
+	data.PolicyExceptionList = namespaced.PolicyExceptionList
+	if len(data.PolicyExceptionList) == 0 && prior.PolicyExceptionList == nil {
+		data.PolicyExceptionList = nil
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (p *NamespacedPolicyResource) Update(ctx context.Context, request resource.UpdateRequest, response *resource.UpdateResponse) {
+	var data dto.NamespacedPolicyResourceModel
+
+	// Read Terraform plan data into the model
+	response.Diagnostics.Append(request.Plan.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := p.modifyPolicy(&data, func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+		if i := namespacedIndex(namespacedList, data.Name.ValueString()); i >= 0 {
+			namespacedList[i] = generateNamespacedPolicyRequest(&data)
+			return namespacedList, nil
+		}
+		// Removed outside Terraform since it was read, add it back
+		return append(namespacedList, generateNamespacedPolicyRequest(&data)), nil
+	})
+	if err != nil {
+		p.addModifyError(ctx, &response.Diagnostics, &data, "update", err)
+		return
+	}
+
+	response.Diagnostics.Append(response.State.Set(ctx, &data)...)
+}
+
+func (p *NamespacedPolicyResource) Delete(ctx context.Context, request resource.DeleteRequest, response *resource.DeleteResponse) {
+	var data dto.NamespacedPolicyResourceModel
+
+	// Read Terraform prior state data into the model
+	response.Diagnostics.Append(request.State.Get(ctx, &data)...)
+	if response.Diagnostics.HasError() {
+		return
+	}
+
+	err := p.modifyPolicy(&data, func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+		if i := namespacedIndex(namespacedList, data.Name.ValueString()); i >= 0 {
+			return append(namespacedList[:i], namespacedList[i+1:]...), nil
+		}
+		return nil, nil
+	})
+	if err != nil && !errors.Is(err, dto.ErrorNotFound) {
+		p.addModifyError(ctx, &response.Diagnostics, &data, "delete", err)
+	}
+}
+
+func (p *NamespacedPolicyResource) ImportState(ctx context.Context, request resource.ImportStateRequest, response *resource.ImportStateResponse) {
+	policyID, name, ok := strings.Cut(request.ID, "/")
+	if !ok || policyID == "" || name == "" {
+		response.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected an import ID in the format <policy_id>/<name>, got %q.", request.ID))
+		return
+	}
+
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root(IDSchemaName), request.ID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root(PolicyIDSchemaName), policyID)...)
+	response.Diagnostics.Append(response.State.SetAttribute(ctx, path.Root(NamespacedNameSchemaName), name)...)
+}
+
+// modifyPolicy applies modify to the namespaced list of the policy of data and writes the policy
+// back. A nil list from modify leaves the policy unchanged. The update is conditional on the ETag
+// of the read, so changes made outside Terraform in between are not overwritten.
+func (p *NamespacedPolicyResource) modifyPolicy(data *dto.NamespacedPolicyResourceModel, modify func([]dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error)) error {
+	client := p.client.WithCredentials(data.Credentials)
+	policyID := data.PolicyID.ValueString()
+
+	unlock := lockPolicy(policyID)
+	defer unlock()
+
+	policy, err := client.GetPolicy(policyID)
+	if err != nil {
+		return err
+	}
+	namespacedList, err := modify(policy.Namespaced)
+	if err != nil || namespacedList == nil {
+		return err
+	}
+
+	apiRequest := generateUpdatePolicyRequestFromResponse(policy)
+	apiRequest.PolicyNamespacedList = &namespacedList
+	_, err = client.UpdatePolicy(policyID, policy.ETag, &apiRequest)
+	return err
+}
+
+func (p *NamespacedPolicyResource) addModifyError(ctx context.Context, diags *diag.Diagnostics, data *dto.NamespacedPolicyResourceModel, action string, err error) {
+	tflog.Debug(ctx, err.Error())
+	switch {
+	case errors.Is(err, dto.ErrorPreconditionFailed):
+		diags.AddError(
+			trendmicro.ConcurrentModificationSummary,
+			trendmicro.ConcurrentModificationDetail("Container Security policy", data.PolicyID.ValueString()))
+	case errors.Is(err, ErrNamespacedPolicyExists):
+		diags.AddError(
+			"Unable to "+action+" the namespaced policy "+data.Name.ValueString(),
+			fmt.Sprintf("The policy %s already has a namespaced definition named %q. "+
+				"Import it with terraform import using the ID %s/%s, or remove it from the namespaced list of the policy.",
+				data.PolicyID.ValueString(), data.Name.ValueString(), data.PolicyID.ValueString(), data.Name.ValueString()))
+	default:
+		diags.AddError(
+			"Unable to "+action+" the namespaced policy "+data.Name.ValueString(),
+			"An unexpected error occurred when updating the Container Security policy "+data.PolicyID.ValueString()+". "+
+				"If the error is not clear, please contact the provider developers.\n\n"+
+				"TrendMicro Client Error: "+err.Error())
+	}
+}
+
+func generateNamespacedPolicyRequest(data *dto.NamespacedPolicyResourceModel) dto.PolicyNamespaced {
+	return generatePolicyRequestForPolicyNamespacedList([]dto.PolicyNamespacedResourceModel{{
+		Name:                data.Name,
+		Namespaces:          data.Namespaces,
+		PolicyRuleList:      data.PolicyRuleList,
+		PolicyExceptionList: data.PolicyExceptionList,
+	}})[0]
+}
+
+// generateUpdatePolicyRequestFromResponse returns an update request that keeps the policy as read.
+func generateUpdatePolicyRequestFromResponse(policy *dto.PolicyResponse) dto.UpdatePolicyRequest {
+	return dto.UpdatePolicyRequest{
+		Description:   policy.Description,
+		PolicyDefault: policy.Default,
+		PolicyRuntime: policy.Runtime,
+		XdrEnabled:    policy.XdrEnabled,
+		MalwareScan:   policy.MalwareScan,
+		SecretScan:    policy.SecretScan,
+	}
+}
+
+func namespacedIndex(namespacedList []dto.PolicyNamespaced, name string) int {
+	for i, namespaced := range namespacedList {
+		if namespaced.Name == name {
+			return i
+		}
+	}
+	return -1
+}
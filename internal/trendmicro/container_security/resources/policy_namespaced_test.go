@@ -0,0 +1,144 @@
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func newNamespacedTestResource(t *testing.T) (*NamespacedPolicyResource, *mockserver.Server) {
+	server := mockserver.NewTestServer(t)
+	server.Seed(mockserver.PoliciesPath, mockserver.Object{
+		"id":         "policy-1",
+		"name":       "central",
+		"default":    mockserver.Object{"rules": []any{mockserver.Object{"type": "podexec", "enabled": true}}},
+		"namespaced": []any{mockserver.Object{"name": "platform", "namespaces": []any{"kube-system"}}},
+	})
+	return &NamespacedPolicyResource{client: &api.CsClient{Client: &trendmicro.Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		BearerToken: server.APIKey(),
+	}}}, server
+}
+
+func namespacedTestModel(name string) *dto.NamespacedPolicyResourceModel {
+	return &dto.NamespacedPolicyResourceModel{
+		PolicyID:   types.StringValue("policy-1"),
+		Name:       types.StringValue(name),
+		Namespaces: []types.String{types.StringValue(name)},
+		PolicyRuleList: []dto.PolicyRuleResourceModel{
+			{Type: types.StringNull(), Enabled: types.BoolValue(true), Action: types.StringValue("block"), PodExec: types.BoolValue(true)},
+		},
+	}
+}
+
+func TestNamespacedPolicyConcurrentCreates(t *testing.T) {
+	r, server := newNamespacedTestResource(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data := namespacedTestModel(fmt.Sprintf("team-%d", i))
+			errs <- r.modifyPolicy(data, func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+				return append(namespacedList, generateNamespacedPolicyRequest(data)), nil
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("modifyPolicy: %v", err)
+		}
+	}
+
+	policy, err := r.client.GetPolicy("policy-1")
+	if err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	if len(policy.Namespaced) != 6 {
+		t.Fatalf("expected the existing and 5 new definitions, got %+v", policy.Namespaced)
+	}
+	if policy.Default == nil || len(policy.Default.PolicyRuleList) != 1 {
+		t.Errorf("default rules were not kept: %+v", policy.Default)
+	}
+	if i := namespacedIndex(policy.Namespaced, "team-3"); i < 0 || policy.Namespaced[i].PolicyRuleList[0].Type != RuleTypePodExec {
+		t.Errorf("unexpected definition team-3: %+v", policy.Namespaced)
+	}
+
+	for _, req := range server.Requests() {
+		if req.Method == http.MethodPatch && req.Header.Get("If-Match") == "" {
+			t.Error("namespaced update sent without If-Match")
+		}
+	}
+}
+
+func TestNamespacedPolicyDeleteLastDefinition(t *testing.T) {
+	r, _ := newNamespacedTestResource(t)
+
+	err := r.modifyPolicy(namespacedTestModel("platform"), func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+		return namespacedList[:0], nil
+	})
+	if err != nil {
+		t.Fatalf("modifyPolicy: %v", err)
+	}
+	policy, err := r.client.GetPolicy("policy-1")
+	if err != nil {
+		t.Fatalf("GetPolicy: %v", err)
+	}
+	if len(policy.Namespaced) != 0 {
+		t.Errorf("expected the last definition to be removed, got %+v", policy.Namespaced)
+	}
+
+	err = r.modifyPolicy(namespacedTestModel("team"), func(namespacedList []dto.PolicyNamespaced) ([]dto.PolicyNamespaced, error) {
+		return nil, ErrNamespacedPolicyExists
+	})
+	if !errors.Is(err, ErrNamespacedPolicyExists) {
+		t.Errorf("expected the modify error, got %v", err)
+	}
+}
+
+func TestPolicyKeepsForeignNamespaced(t *testing.T) {
+	remote := []dto.PolicyNamespaced{{Name: "platform"}, {Name: "team-a"}, {Name: "removed"}}
+	prior := []dto.PolicyNamespacedResourceModel{{Name: types.StringValue("platform")}, {Name: types.StringValue("removed")}}
+
+	owned := ownedPolicyNamespaced(remote, prior)
+	if len(owned) != 2 || owned[0].Name != "platform" || owned[1].Name != "removed" {
+		t.Errorf("unexpected owned definitions %+v", owned)
+	}
+
+	merged := mergePolicyNamespaced([]dto.PolicyNamespaced{{Name: "platform", Namespaces: []string{"kube-system"}}}, remote, prior)
+	if len(merged) != 2 || merged[0].Namespaces == nil || merged[1].Name != "team-a" {
+		t.Errorf("expected the planned definition and team-a, got %+v", merged)
+	}
+	if merged := mergePolicyNamespaced(nil, nil, nil); merged == nil {
+		t.Error("the merged list must not be nil so an empty list is sent")
+	}
+}
+
+func TestImportedPolicyKeepsForeignNamespaced(t *testing.T) {
+	remote := []dto.PolicyNamespaced{{Name: "platform"}, {Name: "team-a"}}
+
+	// Reading an imported policy adopts no definitions, team-a may belong to a container_policy_namespaced resource
+	if owned := ownedPolicyNamespaced(remote, nil); len(owned) != 0 {
+		t.Errorf("an imported policy should own no definition, got %+v", owned)
+	}
+
+	// The first update only claims the configured definitions
+	merged := mergePolicyNamespaced([]dto.PolicyNamespaced{{Name: "platform", Namespaces: []string{"kube-system"}}}, remote, nil)
+	if len(merged) != 2 || merged[0].Namespaces == nil || merged[1].Name != "team-a" {
+		t.Errorf("expected the planned definition and team-a, got %+v", merged)
+	}
+}
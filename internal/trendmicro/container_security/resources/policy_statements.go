@@ -0,0 +1,608 @@
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Policy rule types, as sent in the type of a rule.
+const (
+	RuleTypePodSecurityContext       = "podSecurityContext"
+	RuleTypeContainerSecurityContext = "containerSecurityContext"
+	RuleTypeRegistry                 = "registry"
+	RuleTypeImage                    = "image"
+	RuleTypeTag                      = "tag"
+	RuleTypeImagePath                = "imagePath"
+	RuleTypeVulnerabilities          = "vulnerabilities"
+	RuleTypeCvssAttackVector         = "cvssAttackVector"
+	RuleTypeCvssAttackComplexity     = "cvssAttackComplexity"
+	RuleTypeCvssAvailability         = "cvssAvailability"
+	RuleTypeChecklists               = "checklists"
+	RuleTypeChecklistProfile         = "checklistProfile"
+	RuleTypeMalware                  = "malware"
+	RuleTypeSecrets                  = "secrets"
+	RuleTypeUnscannedImageMalware    = "unscannedImageMalware"
+	RuleTypeUnscannedImageSecret     = "unscannedImageSecret"
+	RuleTypePodExec                  = "podexec"
+	RuleTypePortForward              = "portforward"
+)
+
+// Statement property keys.
+const (
+	propertyHostNetwork              = "hostNetwork"
+	propertyHostIPC                  = "hostIPC"
+	propertyHostPID                  = "hostPID"
+	propertyRunAsNonRoot             = "runAsNonRoot"
+	propertyPrivileged               = "privileged"
+	propertyAllowPrivilegeEscalation = "allowPrivilegeEscalation"
+	propertyReadOnlyRootFilesystem   = "readOnlyRootFilesystem"
+	propertyCapabilitiesRule         = "capabilities-rule"
+	propertyMaxSeverity              = "max-severity"
+	propertyCvssAttackVector         = "cvss-attack-vector"
+	propertyCvssAttackComplexity     = "cvss-attack-complexity"
+	propertyCvssAvailability         = "cvss-availability"
+	propertyChecklistProfile         = "checklist-profile"
+	propertyCount                    = "count"
+	propertyDays                     = "days"
+	propertyPodExec                  = "podExec"
+	propertyPodPortForward           = "podPortForward"
+
+	ImageMatchOperatorDefault = "equals"
+)
+
+var (
+	imageMatchOperators = []string{"equals", "notEquals", "contains", "notContains", "startsWith", "notStartsWith", "endsWith", "notEndsWith"}
+	severities          = []string{"any", "low", "medium", "high", "critical"}
+	capabilitiesRules   = []string{"restrict-nondefaults", "restrict-all", "baseline", "restricted"}
+	checklistProfiles   = []string{"hipaa", "nist800-190", "pci-dss"}
+)
+
+// statementKeys lists the property keys Vision One accepts per rule type. A generic statement with
+// another key only draws a warning, so new keys can be used before the provider knows them.
+var statementKeys = map[string][]string{
+	RuleTypePodSecurityContext:       {propertyHostNetwork, propertyHostIPC, propertyHostPID},
+	RuleTypeContainerSecurityContext: {propertyRunAsNonRoot, propertyPrivileged, propertyAllowPrivilegeEscalation, propertyReadOnlyRootFilesystem, propertyCapabilitiesRule},
+	RuleTypeRegistry:                 imageMatchOperators,
+	RuleTypeImage:                    imageMatchOperators,
+	RuleTypeTag:                      imageMatchOperators,
+	RuleTypeImagePath:                imageMatchOperators,
+	RuleTypeVulnerabilities:          {propertyMaxSeverity},
+	RuleTypeCvssAttackVector:         {propertyCvssAttackVector, propertyMaxSeverity},
+	RuleTypeCvssAttackComplexity:     {propertyCvssAttackComplexity, propertyMaxSeverity},
+	RuleTypeCvssAvailability:         {propertyCvssAvailability, propertyMaxSeverity},
+	RuleTypeChecklists:               {propertyMaxSeverity},
+	RuleTypeChecklistProfile:         {propertyChecklistProfile, propertyMaxSeverity},
+	RuleTypeMalware:                  {propertyCount},
+	RuleTypeSecrets:                  {propertyCount},
+	RuleTypeUnscannedImageMalware:    {propertyDays},
+	RuleTypeUnscannedImageSecret:     {propertyDays},
+	RuleTypePodExec:                  {propertyPodExec},
+	RuleTypePortForward:              {propertyPodPortForward},
+}
+
+// ruleStatement is a typed statement attribute of a policy rule and its mapping to the key/value
+// properties of the API.
+type ruleStatement struct {
+	name       string
+	ruleType   string
+	attribute  schema.Attribute
+	isSet      func(rule *dto.PolicyRuleResourceModel) bool
+	properties func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty
+	load       func(rule *dto.PolicyRuleResourceModel, properties map[string]string)
+}
+
+var ruleStatements = []ruleStatement{
+	{
+		name:     "pod_security_context",
+		ruleType: RuleTypePodSecurityContext,
+		attribute: statementAttribute(RuleTypePodSecurityContext, map[string]schema.Attribute{
+			"host_network": boolStatementAttribute("Match pods that use the host network."),
+			"host_ipc":     boolStatementAttribute("Match pods that share the host IPC namespace."),
+			"host_pid":     boolStatementAttribute("Match pods that share the host PID namespace."),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.PodSecurityContext != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			s := rule.PodSecurityContext
+			return slices.Concat(
+				boolProperty(propertyHostNetwork, s.HostNetwork),
+				boolProperty(propertyHostIPC, s.HostIPC),
+				boolProperty(propertyHostPID, s.HostPID))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.PodSecurityContext = &dto.PodSecurityContextStatementModel{
+				HostNetwork: boolFromProperty(p, propertyHostNetwork),
+				HostIPC:     boolFromProperty(p, propertyHostIPC),
+				HostPID:     boolFromProperty(p, propertyHostPID),
+			}
+		},
+	},
+	{
+		name:     "container_security_context",
+		ruleType: RuleTypeContainerSecurityContext,
+		attribute: statementAttribute(RuleTypeContainerSecurityContext, map[string]schema.Attribute{
+			"run_as_non_root":            boolStatementAttribute("Match containers by whether they must run as a non-root user."),
+			"privileged":                 boolStatementAttribute("Match privileged containers."),
+			"allow_privilege_escalation": boolStatementAttribute("Match containers that allow privilege escalation."),
+			"read_only_root_filesystem":  boolStatementAttribute("Match containers by whether their root filesystem is read-only."),
+			"capabilities_rule":          enumStatementAttribute("Match containers by their Linux capabilities.", false, capabilitiesRules),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.ContainerSecurityContext != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			s := rule.ContainerSecurityContext
+			return slices.Concat(
+				boolProperty(propertyRunAsNonRoot, s.RunAsNonRoot),
+				boolProperty(propertyPrivileged, s.Privileged),
+				boolProperty(propertyAllowPrivilegeEscalation, s.AllowPrivilegeEscalation),
+				boolProperty(propertyReadOnlyRootFilesystem, s.ReadOnlyRootFilesystem),
+				stringProperty(propertyCapabilitiesRule, s.CapabilitiesRule))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.ContainerSecurityContext = &dto.ContainerSecurityContextStatementModel{
+				RunAsNonRoot:             boolFromProperty(p, propertyRunAsNonRoot),
+				Privileged:               boolFromProperty(p, propertyPrivileged),
+				AllowPrivilegeEscalation: boolFromProperty(p, propertyAllowPrivilegeEscalation),
+				ReadOnlyRootFilesystem:   boolFromProperty(p, propertyReadOnlyRootFilesystem),
+				CapabilitiesRule:         stringFromProperty(p, propertyCapabilitiesRule),
+			}
+		},
+	},
+	imageMatchStatement("registry", RuleTypeRegistry, "image registry",
+		func(rule *dto.PolicyRuleResourceModel) **dto.ImageMatchStatementModel { return &rule.Registry }),
+	imageMatchStatement("image", RuleTypeImage, "image name",
+		func(rule *dto.PolicyRuleResourceModel) **dto.ImageMatchStatementModel { return &rule.Image }),
+	imageMatchStatement("tag", RuleTypeTag, "image tag",
+		func(rule *dto.PolicyRuleResourceModel) **dto.ImageMatchStatementModel { return &rule.Tag }),
+	imageMatchStatement("image_path", RuleTypeImagePath, "full image path",
+		func(rule *dto.PolicyRuleResourceModel) **dto.ImageMatchStatementModel { return &rule.ImagePath }),
+	severityStatement("vulnerabilities", RuleTypeVulnerabilities, "vulnerabilities",
+		func(rule *dto.PolicyRuleResourceModel) **dto.SeverityStatementModel { return &rule.Vulnerabilities }),
+	{
+		name:     "cvss_attack_vector",
+		ruleType: RuleTypeCvssAttackVector,
+		attribute: statementAttribute(RuleTypeCvssAttackVector, map[string]schema.Attribute{
+			"attack_vector": enumStatementAttribute("The CVSS attack vector of the vulnerabilities to match.", true, []string{"network", "adjacent", "local", "physical"}),
+			"max_severity":  maxSeverityAttribute(),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.CvssAttackVector != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return slices.Concat(
+				stringProperty(propertyCvssAttackVector, rule.CvssAttackVector.AttackVector),
+				stringProperty(propertyMaxSeverity, rule.CvssAttackVector.MaxSeverity))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.CvssAttackVector = &dto.CvssAttackVectorStatementModel{
+				AttackVector: stringFromProperty(p, propertyCvssAttackVector),
+				MaxSeverity:  stringFromProperty(p, propertyMaxSeverity),
+			}
+		},
+	},
+	{
+		name:     "cvss_attack_complexity",
+		ruleType: RuleTypeCvssAttackComplexity,
+		attribute: statementAttribute(RuleTypeCvssAttackComplexity, map[string]schema.Attribute{
+			"attack_complexity": enumStatementAttribute("The CVSS attack complexity of the vulnerabilities to match.", true, []string{"low", "high"}),
+			"max_severity":      maxSeverityAttribute(),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.CvssAttackComplexity != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return slices.Concat(
+				stringProperty(propertyCvssAttackComplexity, rule.CvssAttackComplexity.AttackComplexity),
+				stringProperty(propertyMaxSeverity, rule.CvssAttackComplexity.MaxSeverity))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.CvssAttackComplexity = &dto.CvssAttackComplexityStatementModel{
+				AttackComplexity: stringFromProperty(p, propertyCvssAttackComplexity),
+				MaxSeverity:      stringFromProperty(p, propertyMaxSeverity),
+			}
+		},
+	},
+	{
+		name:     "cvss_availability",
+		ruleType: RuleTypeCvssAvailability,
+		attribute: statementAttribute(RuleTypeCvssAvailability, map[string]schema.Attribute{
+			"availability": enumStatementAttribute("The CVSS availability impact of the vulnerabilities to match.", true, []string{"none", "low", "high"}),
+			"max_severity": maxSeverityAttribute(),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.CvssAvailability != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return slices.Concat(
+				stringProperty(propertyCvssAvailability, rule.CvssAvailability.Availability),
+				stringProperty(propertyMaxSeverity, rule.CvssAvailability.MaxSeverity))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.CvssAvailability = &dto.CvssAvailabilityStatementModel{
+				Availability: stringFromProperty(p, propertyCvssAvailability),
+				MaxSeverity:  stringFromProperty(p, propertyMaxSeverity),
+			}
+		},
+	},
+	severityStatement("checklists", RuleTypeChecklists, "failed compliance checklist items",
+		func(rule *dto.PolicyRuleResourceModel) **dto.SeverityStatementModel { return &rule.Checklists }),
+	{
+		name:     "checklist_profile",
+		ruleType: RuleTypeChecklistProfile,
+		attribute: statementAttribute(RuleTypeChecklistProfile, map[string]schema.Attribute{
+			"profile":      enumStatementAttribute("The compliance checklist profile.", true, checklistProfiles),
+			"max_severity": maxSeverityAttribute(),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return rule.ChecklistProfile != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return slices.Concat(
+				stringProperty(propertyChecklistProfile, rule.ChecklistProfile.Profile),
+				stringProperty(propertyMaxSeverity, rule.ChecklistProfile.MaxSeverity))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			rule.ChecklistProfile = &dto.ChecklistProfileStatementModel{
+				Profile:     stringFromProperty(p, propertyChecklistProfile),
+				MaxSeverity: stringFromProperty(p, propertyMaxSeverity),
+			}
+		},
+	},
+	countStatement("malware", RuleTypeMalware, "malware findings",
+		func(rule *dto.PolicyRuleResourceModel) **dto.CountStatementModel { return &rule.Malware }),
+	countStatement("secrets", RuleTypeSecrets, "secret findings",
+		func(rule *dto.PolicyRuleResourceModel) **dto.CountStatementModel { return &rule.Secrets }),
+	daysStatement("unscanned_image_malware", RuleTypeUnscannedImageMalware, "malware",
+		func(rule *dto.PolicyRuleResourceModel) **dto.DaysStatementModel { return &rule.UnscannedImageMalware }),
+	daysStatement("unscanned_image_secret", RuleTypeUnscannedImageSecret, "secrets",
+		func(rule *dto.PolicyRuleResourceModel) **dto.DaysStatementModel { return &rule.UnscannedImageSecret }),
+	boolStatement("pod_exec", RuleTypePodExec, propertyPodExec, "Match `kubectl exec` into pods.",
+		func(rule *dto.PolicyRuleResourceModel) *types.Bool { return &rule.PodExec }),
+	boolStatement("port_forward", RuleTypePortForward, propertyPodPortForward, "Match `kubectl port-forward` to pods.",
+		func(rule *dto.PolicyRuleResourceModel) *types.Bool { return &rule.PortForward }),
+}
+
+func statementAttribute(ruleType string, attributes map[string]schema.Attribute) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Optional:            true,
+		MarkdownDescription: fmt.Sprintf("Typed statement for `%s` rules. Sets `type` and replaces `statement`.", ruleType),
+		Attributes:          attributes,
+	}
+}
+
+func boolStatementAttribute(description string) schema.BoolAttribute {
+	return schema.BoolAttribute{Optional: true, MarkdownDescription: description}
+}
+
+func enumStatementAttribute(description string, required bool, values []string) schema.StringAttribute {
+	return schema.StringAttribute{
+		Required:            required,
+		Optional:            !required,
+		MarkdownDescription: fmt.Sprintf("%s Accepted values: `%s`.", description, strings.Join(values, "`, `")),
+		Validators:          []validator.String{stringvalidator.OneOf(values...)},
+	}
+}
+
+func maxSeverityAttribute() schema.StringAttribute {
+	return enumStatementAttribute("The highest severity allowed; findings above it match the rule.", true, severities)
+}
+
+func imageMatchStatement(name, ruleType, subject string, field func(*dto.PolicyRuleResourceModel) **dto.ImageMatchStatementModel) ruleStatement {
+	return ruleStatement{
+		name:     name,
+		ruleType: ruleType,
+		attribute: statementAttribute(ruleType, map[string]schema.Attribute{
+			"operator": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(ImageMatchOperatorDefault),
+				MarkdownDescription: fmt.Sprintf("How `value` is compared with the %s. Default is \"%s\". Accepted values: `%s`.", subject, ImageMatchOperatorDefault, strings.Join(imageMatchOperators, "`, `")),
+				Validators:          []validator.String{stringvalidator.OneOf(imageMatchOperators...)},
+			},
+			"value": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The %s to compare with.", subject),
+			},
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return *field(rule) != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			s := *field(rule)
+			operator := s.Operator.ValueString()
+			if operator == "" {
+				operator = ImageMatchOperatorDefault
+			}
+			return stringProperty(operator, s.Value)
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			s := &dto.ImageMatchStatementModel{Operator: types.StringValue(ImageMatchOperatorDefault), Value: types.StringNull()}
+			for _, operator := range imageMatchOperators {
+				if value, ok := p[operator]; ok {
+					s.Operator = types.StringValue(operator)
+					s.Value = types.StringValue(value)
+					break
+				}
+			}
+			*field(rule) = s
+		},
+	}
+}
+
+func severityStatement(name, ruleType, subject string, field func(*dto.PolicyRuleResourceModel) **dto.SeverityStatementModel) ruleStatement {
+	return ruleStatement{
+		name:     name,
+		ruleType: ruleType,
+		attribute: statementAttribute(ruleType, map[string]schema.Attribute{
+			"max_severity": enumStatementAttribute(fmt.Sprintf("The highest severity of %s allowed; images with more severe ones match the rule.", subject), true, severities),
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return *field(rule) != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return stringProperty(propertyMaxSeverity, (*field(rule)).MaxSeverity)
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			*field(rule) = &dto.SeverityStatementModel{MaxSeverity: stringFromProperty(p, propertyMaxSeverity)}
+		},
+	}
+}
+
+func countStatement(name, ruleType, subject string, field func(*dto.PolicyRuleResourceModel) **dto.CountStatementModel) ruleStatement {
+	return ruleStatement{
+		name:     name,
+		ruleType: ruleType,
+		attribute: statementAttribute(ruleType, map[string]schema.Attribute{
+			"count": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("The number of %s allowed; images with more match the rule.", subject),
+				Validators:          []validator.Int64{int64validator.AtLeast(0)},
+			},
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return *field(rule) != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return int64Property(propertyCount, (*field(rule)).Count)
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			*field(rule) = &dto.CountStatementModel{Count: int64FromProperty(p, propertyCount)}
+		},
+	}
+}
+
+func daysStatement(name, ruleType, subject string, field func(*dto.PolicyRuleResourceModel) **dto.DaysStatementModel) ruleStatement {
+	return ruleStatement{
+		name:     name,
+		ruleType: ruleType,
+		attribute: statementAttribute(ruleType, map[string]schema.Attribute{
+			"days": schema.Int64Attribute{
+				Required:            true,
+				MarkdownDescription: fmt.Sprintf("Match images not scanned for %s within this number of days.", subject),
+				Validators:          []validator.Int64{int64validator.AtLeast(1)},
+			},
+		}),
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return *field(rule) != nil },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return int64Property(propertyDays, (*field(rule)).Days)
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			*field(rule) = &dto.DaysStatementModel{Days: int64FromProperty(p, propertyDays)}
+		},
+	}
+}
+
+func boolStatement(name, ruleType, key, description string, field func(*dto.PolicyRuleResourceModel) *types.Bool) ruleStatement {
+	return ruleStatement{
+		name:     name,
+		ruleType: ruleType,
+		attribute: schema.BoolAttribute{
+			Optional:            true,
+			MarkdownDescription: fmt.Sprintf("%s Sets `type` to `%s` and replaces `statement`.", description, ruleType),
+		},
+		isSet: func(rule *dto.PolicyRuleResourceModel) bool { return !field(rule).IsNull() },
+		properties: func(rule *dto.PolicyRuleResourceModel) []dto.PolicyRuleProperty {
+			return boolProperty(key, *field(rule))
+		},
+		load: func(rule *dto.PolicyRuleResourceModel, p map[string]string) {
+			*field(rule) = boolFromProperty(p, key)
+		},
+	}
+}
+
+func boolProperty(key string, value types.Bool) []dto.PolicyRuleProperty {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return []dto.PolicyRuleProperty{{Key: key, Value: strconv.FormatBool(value.ValueBool())}}
+}
+
+func stringProperty(key string, value types.String) []dto.PolicyRuleProperty {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return []dto.PolicyRuleProperty{{Key: key, Value: value.ValueString()}}
+}
+
+func int64Property(key string, value types.Int64) []dto.PolicyRuleProperty {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return []dto.PolicyRuleProperty{{Key: key, Value: strconv.FormatInt(value.ValueInt64(), 10)}}
+}
+
+func boolFromProperty(properties map[string]string, key string) types.Bool {
+	value, ok := properties[key]
+	if !ok {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value == "true")
+}
+
+func stringFromProperty(properties map[string]string, key string) types.String {
+	value, ok := properties[key]
+	if !ok {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+func int64FromProperty(properties map[string]string, key string) types.Int64 {
+	value, err := strconv.ParseInt(properties[key], 10, 64)
+	if err != nil {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
+
+// typedStatementAttributes returns the typed statement attributes of a policy rule.
+func typedStatementAttributes() map[string]schema.Attribute {
+	attributes := make(map[string]schema.Attribute, len(ruleStatements))
+	for _, s := range ruleStatements {
+		attributes[s.name] = s.attribute
+	}
+	return attributes
+}
+
+// typedStatementsOf returns the typed statements set on rule.
+func typedStatementsOf(rule *dto.PolicyRuleResourceModel) []*ruleStatement {
+	var set []*ruleStatement
+	for i := range ruleStatements {
+		if ruleStatements[i].isSet(rule) {
+			set = append(set, &ruleStatements[i])
+		}
+	}
+	return set
+}
+
+// ruleTypeAndStatement returns the wire type and statement of rule, converting a typed statement
+// into key/value properties.
+func ruleTypeAndStatement(rule *dto.PolicyRuleResourceModel) (string, *dto.PolicyRuleStatement) {
+	if typed := typedStatementsOf(rule); len(typed) > 0 {
+		return typed[0].ruleType, &dto.PolicyRuleStatement{PolicyRulePropertyList: typed[0].properties(rule)}
+	}
+
+	if rule.PolicyRuleStatement == nil || rule.PolicyRuleStatement.PolicyRulePropertyList == nil {
+		return rule.Type.ValueString(), nil
+	}
+	statement := &dto.PolicyRuleStatement{}
+	for _, property := range rule.PolicyRuleStatement.PolicyRulePropertyList {
+		statement.PolicyRulePropertyList = append(statement.PolicyRulePropertyList, dto.PolicyRuleProperty{
+			Key:   property.Key.ValueString(),
+			Value: property.Value.ValueString(),
+		})
+	}
+	return rule.Type.ValueString(), statement
+}
+
+// loadRuleStatement sets the statement of rule from the API. The rule keeps the typed attribute its
+// prior state used, so configurations written either way do not drift; imported rules use the
+// generic statement.
+func loadRuleStatement(rule *dto.PolicyRuleResourceModel, statement *dto.PolicyRuleStatement, prior *dto.PolicyRuleResourceModel) {
+	if prior != nil {
+		if typed := typedStatementsOf(prior); len(typed) == 1 && typed[0].ruleType == rule.Type.ValueString() {
+			properties := map[string]string{}
+			if statement != nil {
+				for _, property := range statement.PolicyRulePropertyList {
+					properties[property.Key] = property.Value
+				}
+			}
+			typed[0].load(rule, properties)
+			return
+		}
+	}
+
+	if statement == nil || statement.PolicyRulePropertyList == nil {
+		rule.PolicyRuleStatement = nil
+		return
+	}
+	rule.PolicyRuleStatement = &dto.PolicyRuleStatementResourceModel{
+		PolicyRulePropertyList: make([]dto.PolicyRulePropertyResourceModel, 0, len(statement.PolicyRulePropertyList)),
+	}
+	for _, property := range statement.PolicyRulePropertyList {
+		rule.PolicyRuleStatement.PolicyRulePropertyList = append(rule.PolicyRuleStatement.PolicyRulePropertyList, dto.PolicyRulePropertyResourceModel{
+			Key:   types.StringValue(property.Key),
+			Value: types.StringValue(property.Value),
+		})
+	}
+}
+
+// validatePolicyRules checks that every rule has exactly one statement form and a type matching it.
+func validatePolicyRules(rulesPath path.Path, rules []dto.PolicyRuleResourceModel, diags *diag.Diagnostics) {
+	for i := range rules {
+		rule := &rules[i]
+		rulePath := rulesPath.AtListIndex(i)
+		typed := typedStatementsOf(rule)
+
+		switch {
+		case len(typed) > 1:
+			diags.AddAttributeError(rulePath, "Conflicting Rule Statements",
+				fmt.Sprintf("A rule takes one typed statement, got %q and %q. Split them into separate rules.", typed[0].name, typed[1].name))
+			continue
+		case len(typed) == 1 && rule.PolicyRuleStatement != nil:
+			diags.AddAttributeError(rulePath.AtName(RuleStatementSchemaName), "Conflicting Rule Statements",
+				fmt.Sprintf("%q already sets the statement of this rule, remove %q.", typed[0].name, RuleStatementSchemaName))
+			continue
+		case len(typed) == 1:
+			if !rule.Type.IsNull() && !rule.Type.IsUnknown() && rule.Type.ValueString() != typed[0].ruleType {
+				diags.AddAttributeError(rulePath.AtName(RuleTypeSchemaName), "Mismatched Rule Type",
+					fmt.Sprintf("%q is a statement of %q rules, but type is %q. Remove type or make it match.", typed[0].name, typed[0].ruleType, rule.Type.ValueString()))
+			}
+			if len(typed[0].properties(rule)) == 0 {
+				diags.AddAttributeError(rulePath.AtName(typed[0].name), "Empty Rule Statement",
+					fmt.Sprintf("Set at least one attribute of %q.", typed[0].name))
+			}
+			continue
+		case rule.Type.IsNull():
+			diags.AddAttributeError(rulePath.AtName(RuleTypeSchemaName), "Missing Rule Type",
+				"Set type, or use one of the typed statements such as \"vulnerabilities\" or \"registry\".")
+			continue
+		}
+
+		known, ok := statementKeys[rule.Type.ValueString()]
+		if !ok || rule.PolicyRuleStatement == nil {
+			continue
+		}
+		for j, property := range rule.PolicyRuleStatement.PolicyRulePropertyList {
+			if property.Key.IsUnknown() || slices.Contains(known, property.Key.ValueString()) {
+				continue
+			}
+			diags.AddAttributeWarning(
+				rulePath.AtName(RuleStatementSchemaName).AtName(RuleStatementPropertiesSchemaName).AtListIndex(j).AtName(RuleStatementPropertyKeySchemaName),
+				"Unknown Statement Property",
+				fmt.Sprintf("%q is not a known property of %q rules. Known properties: %s. Check for typos, Vision One rejects unknown properties on apply.",
+					property.Key.ValueString(), rule.Type.ValueString(), strings.Join(known, ", ")))
+		}
+	}
+}
+
+// ruleTypeFromStatement plans the type of a rule left unset from its typed statement.
+type ruleTypeFromStatement struct{}
+
+var _ planmodifier.String = ruleTypeFromStatement{}
+
+func (m ruleTypeFromStatement) Description(_ context.Context) string {
+	return "Derives the rule type from the typed statement of the rule."
+}
+
+func (m ruleTypeFromStatement) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m ruleTypeFromStatement) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if !req.ConfigValue.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var rule types.Object
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, req.Path.ParentPath(), &rule)...)
+	if resp.Diagnostics.HasError() || rule.IsNull() || rule.IsUnknown() {
+		return
+	}
+	attributes := rule.Attributes()
+	for _, s := range ruleStatements {
+		if value, ok := attributes[s.name]; ok && !value.IsNull() {
+			resp.PlanValue = types.StringValue(s.ruleType)
+			return
+		}
+	}
+}
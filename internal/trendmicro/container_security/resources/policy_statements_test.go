@@ -0,0 +1,112 @@
+package resources
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"terraform-provider-vision-one/pkg/dto"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestTypedStatementRoundTrip(t *testing.T) {
+	rules := []dto.PolicyRuleResourceModel{
+		{
+			Enabled:          types.BoolValue(true),
+			Action:           types.StringValue("block"),
+			Mitigation:       types.StringValue("none"),
+			CvssAttackVector: &dto.CvssAttackVectorStatementModel{AttackVector: types.StringValue("network"), MaxSeverity: types.StringValue("high")},
+		},
+		{
+			Type:     types.StringNull(),
+			Registry: &dto.ImageMatchStatementModel{Operator: types.StringValue("notEquals"), Value: types.StringValue("docker.io")},
+		},
+		{
+			PodSecurityContext: &dto.PodSecurityContextStatementModel{HostNetwork: types.BoolValue(true), HostIPC: types.BoolNull(), HostPID: types.BoolValue(false)},
+		},
+		{
+			Malware: &dto.CountStatementModel{Count: types.Int64Value(0)},
+		},
+	}
+
+	request := generatePolicyRequestForPolicyRuleList(rules)
+	want := []dto.PolicyRule{
+		{Type: RuleTypeCvssAttackVector, Enabled: true, Action: "block", Mitigation: "none", PolicyRuleStatement: &dto.PolicyRuleStatement{
+			PolicyRulePropertyList: []dto.PolicyRuleProperty{{Key: "cvss-attack-vector", Value: "network"}, {Key: "max-severity", Value: "high"}},
+		}},
+		{Type: RuleTypeRegistry, PolicyRuleStatement: &dto.PolicyRuleStatement{
+			PolicyRulePropertyList: []dto.PolicyRuleProperty{{Key: "notEquals", Value: "docker.io"}},
+		}},
+		{Type: RuleTypePodSecurityContext, PolicyRuleStatement: &dto.PolicyRuleStatement{
+			PolicyRulePropertyList: []dto.PolicyRuleProperty{{Key: "hostNetwork", Value: "true"}, {Key: "hostPID", Value: "false"}},
+		}},
+		{Type: RuleTypeMalware, PolicyRuleStatement: &dto.PolicyRuleStatement{
+			PolicyRulePropertyList: []dto.PolicyRuleProperty{{Key: "count", Value: "0"}},
+		}},
+	}
+	for i := range want {
+		if request[i].Type != want[i].Type || !slices.Equal(request[i].PolicyRuleStatement.PolicyRulePropertyList, want[i].PolicyRuleStatement.PolicyRulePropertyList) {
+			t.Errorf("rule %d: got %+v %+v, want %+v", i, request[i], request[i].PolicyRuleStatement, want[i].PolicyRuleStatement)
+		}
+	}
+
+	state := saveStatePolicyRuleList(request, rules)
+	if s := state[0].CvssAttackVector; s == nil || s.AttackVector.ValueString() != "network" || s.MaxSeverity.ValueString() != "high" || state[0].PolicyRuleStatement != nil {
+		t.Errorf("typed statement not kept: %+v", state[0])
+	}
+	if s := state[1].Registry; s == nil || s.Operator.ValueString() != "notEquals" || s.Value.ValueString() != "docker.io" {
+		t.Errorf("registry statement not kept: %+v", state[1].Registry)
+	}
+	if s := state[2].PodSecurityContext; s == nil || !s.HostNetwork.ValueBool() || !s.HostIPC.IsNull() || s.HostPID.ValueBool() {
+		t.Errorf("pod security context not kept: %+v", state[2].PodSecurityContext)
+	}
+
+	// Imported rules have no prior state and use the generic statement
+	imported := saveStatePolicyRuleList(request, nil)
+	if imported[3].Malware != nil || imported[3].PolicyRuleStatement == nil ||
+		imported[3].PolicyRuleStatement.PolicyRulePropertyList[0].Key.ValueString() != "count" {
+		t.Errorf("expected a generic statement on import, got %+v", imported[3])
+	}
+}
+
+func TestValidatePolicyRules(t *testing.T) {
+	genericStatement := &dto.PolicyRuleStatementResourceModel{PolicyRulePropertyList: []dto.PolicyRulePropertyResourceModel{
+		{Key: types.StringValue("max-severty"), Value: types.StringValue("high")},
+	}}
+	tests := []struct {
+		name     string
+		rule     dto.PolicyRuleResourceModel
+		errors   int
+		warnings int
+	}{
+		{"typed", dto.PolicyRuleResourceModel{Type: types.StringNull(), PodExec: types.BoolValue(true)}, 0, 0},
+		{"generic", dto.PolicyRuleResourceModel{Type: types.StringValue("contents"), PolicyRuleStatement: genericStatement}, 0, 0},
+		{"two typed", dto.PolicyRuleResourceModel{Type: types.StringNull(), PodExec: types.BoolValue(true), PortForward: types.BoolValue(true)}, 1, 0},
+		{"typed and generic", dto.PolicyRuleResourceModel{Type: types.StringNull(), PodExec: types.BoolValue(true), PolicyRuleStatement: genericStatement}, 1, 0},
+		{"mismatched type", dto.PolicyRuleResourceModel{Type: types.StringValue(RuleTypeMalware), PodExec: types.BoolValue(true)}, 1, 0},
+		{"missing type", dto.PolicyRuleResourceModel{Type: types.StringNull()}, 1, 0},
+		{"empty typed", dto.PolicyRuleResourceModel{Type: types.StringNull(), PodSecurityContext: &dto.PodSecurityContextStatementModel{}}, 1, 0},
+		{"unknown key", dto.PolicyRuleResourceModel{Type: types.StringValue(RuleTypeVulnerabilities), PolicyRuleStatement: genericStatement}, 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			validatePolicyRules(path.Root(RulesSchemaName), []dto.PolicyRuleResourceModel{tt.rule}, &diags)
+			if diags.ErrorsCount() != tt.errors || diags.WarningsCount() != tt.warnings {
+				t.Errorf("got %d errors and %d warnings, want %d and %d: %v", diags.ErrorsCount(), diags.WarningsCount(), tt.errors, tt.warnings, diags)
+			}
+		})
+	}
+}
+
+func TestPolicySchemaIsValid(t *testing.T) {
+	response := &resource.SchemaResponse{}
+	NewPolicyResource().Schema(context.Background(), resource.SchemaRequest{}, response)
+	if diags := response.Schema.ValidateImplementation(context.Background()); diags.HasError() {
+		t.Fatalf("invalid schema: %v", diags)
+	}
+}
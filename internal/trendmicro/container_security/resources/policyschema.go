@@ -1,7 +1,10 @@
 package resources
 
 import (
+	"maps"
+
 	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -62,7 +65,7 @@ const (
 	NameSchemaMarkdownDescription        = "A descriptive name for the policy."
 	DescriptionSchemaMarkdownDescription = "A description of the policy."
 	RulesSchemaMarkdownDescription       = "The set of policy rules. The rules are OR together."
-	RuleTypeSchemaMarkdownDescription    = "The type of the policy rule." +
+	RuleTypeSchemaMarkdownDescription    = "The type of the policy rule. Required with `statement`, derived from the typed statement otherwise. " +
 		"Enum: [podSecurityContext, containerSecurityContext, registry, image, tag, imagePath, vulnerabilities, cvssAttackVector, cvssAttackComplexity, cvssAvailability, checklists, checklistProfile, contents, malware, secret, unscannedImage, podexec, portforward, capabilities]."
 	RuleEnabledSchemaMarkdownDescription = "Enable the rule. " +
 		"Default is \"true\"."
@@ -96,6 +99,7 @@ func generatePolicySchema() schema.Schema {
 	return schema.Schema{
 		Description: config.RESOURCE_TYPE_POLICY_DESCRIPTION,
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			IDSchemaName: schema.StringAttribute{
 				Computed:            true,
 				MarkdownDescription: IDSchemaMarkdownDescription,
@@ -241,10 +245,14 @@ func generatePolicyExceptionListSchema() schema.ListNestedAttribute {
 		Optional:            true,
 		MarkdownDescription: RulesSchemaMarkdownDescription,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
+			Attributes: withTypedStatementAttributes(map[string]schema.Attribute{
 				RuleTypeSchemaName: schema.StringAttribute{
-					Required:            true,
+					Optional:            true,
+					Computed:            true,
 					MarkdownDescription: RuleTypeSchemaMarkdownDescription,
+					PlanModifiers: []planmodifier.String{
+						ruleTypeFromStatement{},
+					},
 				},
 				RuleEnabledSchemaName: schema.BoolAttribute{
 					Optional:            true,
@@ -273,7 +281,7 @@ func generatePolicyExceptionListSchema() schema.ListNestedAttribute {
 						RuleStatementPropertiesSchemaName: generatePolicyRuleStatementPropertyListSchema(),
 					},
 				},
-			},
+			}),
 		},
 	}
 }
@@ -283,10 +291,14 @@ func generatePolicyRuleListSchema() schema.ListNestedAttribute {
 		Required:            true,
 		MarkdownDescription: RulesSchemaMarkdownDescription,
 		NestedObject: schema.NestedAttributeObject{
-			Attributes: map[string]schema.Attribute{
+			Attributes: withTypedStatementAttributes(map[string]schema.Attribute{
 				RuleTypeSchemaName: schema.StringAttribute{
-					Required:            true,
+					Optional:            true,
+					Computed:            true,
 					MarkdownDescription: RuleTypeSchemaMarkdownDescription,
+					PlanModifiers: []planmodifier.String{
+						ruleTypeFromStatement{},
+					},
 				},
 				RuleEnabledSchemaName: schema.BoolAttribute{
 					Optional:            true,
@@ -312,7 +324,7 @@ func generatePolicyRuleListSchema() schema.ListNestedAttribute {
 						RuleStatementPropertiesSchemaName: generatePolicyRuleStatementPropertyListSchema(),
 					},
 				},
-			},
+			}),
 		},
 	}
 }
@@ -333,3 +345,9 @@ func generatePolicyRuleStatementPropertyListSchema() schema.ListNestedAttribute
 		},
 	}
 }
+
+// withTypedStatementAttributes adds the typed statements to the attributes of a policy rule.
+func withTypedStatementAttributes(attributes map[string]schema.Attribute) map[string]schema.Attribute {
+	maps.Copy(attributes, typedStatementAttributes())
+	return attributes
+}
@@ -4,26 +4,32 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"terraform-provider-vision-one/internal/trendmicro/container_security/resources/config"
 
 	"terraform-provider-vision-one/internal/trendmicro"
 	"terraform-provider-vision-one/internal/trendmicro/container_security/api"
+	"terraform-provider-vision-one/internal/trendmicro/resources/credentials"
 	"terraform-provider-vision-one/pkg/dto"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &RulesetResource{}
-	_ resource.ResourceWithConfigure   = &RulesetResource{}
-	_ resource.ResourceWithImportState = &RulesetResource{}
+	_ resource.Resource                   = &RulesetResource{}
+	_ resource.ResourceWithConfigure      = &RulesetResource{}
+	_ resource.ResourceWithImportState    = &RulesetResource{}
+	_ resource.ResourceWithValidateConfig = &RulesetResource{}
 )
 
 func NewRulesetResource() resource.Resource {
@@ -38,13 +44,16 @@ type RulesetResource struct {
 }
 
 type RulesetResourceModel struct {
-	Id              types.String `tfsdk:"id"`
-	Name            types.String `tfsdk:"name"`
-	Description     types.String `tfsdk:"description"`
-	Labels          []labelModel `tfsdk:"labels"`
-	Rules           []ruleModel  `tfsdk:"rules"`
-	CreatedDateTime types.String `tfsdk:"createdtime"`
-	UpdatedDateTime types.String `tfsdk:"updatedtime"`
+	Id              types.String      `tfsdk:"id"`
+	Name            types.String      `tfsdk:"name"`
+	Description     types.String      `tfsdk:"description"`
+	Labels          []labelModel      `tfsdk:"labels"`
+	Rules           []ruleModel       `tfsdk:"rules"`
+	CustomRules     []customRuleModel `tfsdk:"custom_rules"`
+	CreatedDateTime types.String      `tfsdk:"createdtime"`
+	UpdatedDateTime types.String      `tfsdk:"updatedtime"`
+
+	Credentials *dto.CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type labelModel struct {
@@ -57,6 +66,22 @@ type ruleModel struct {
 	Mitigation types.String `tfsdk:"mitigation"`
 }
 
+type customRuleModel struct {
+	Name        types.String   `tfsdk:"name"`
+	Description types.String   `tfsdk:"description"`
+	Condition   types.String   `tfsdk:"condition"`
+	Output      types.String   `tfsdk:"output"`
+	Priority    types.String   `tfsdk:"priority"`
+	Tags        []types.String `tfsdk:"tags"`
+	Mitigation  types.String   `tfsdk:"mitigation"`
+}
+
+// Priorities of custom runtime rules, as in Falco.
+var customRulePriorities = []string{"emergency", "alert", "critical", "error", "warning", "notice", "informational", "debug"}
+
+// defaultCustomRuleMitigation is the mitigation the API applies to custom rules that do not set one.
+const defaultCustomRuleMitigation = "log"
+
 func (r *RulesetResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_" + config.RESOURCE_TYPE_RULESET
 }
@@ -65,6 +90,7 @@ func (r *RulesetResource) Schema(ctx context.Context, req resource.SchemaRequest
 	resp.Schema = schema.Schema{
 		Description: config.RESOURCE_TYPE_RULESET_DESCRIPTION,
 		Attributes: map[string]schema.Attribute{
+			"credentials": credentials.OverrideSchema(),
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The unique ID assigned to this ruleset.",
 				Computed:            true,
@@ -110,6 +136,49 @@ func (r *RulesetResource) Schema(ctx context.Context, req resource.SchemaRequest
 					},
 				},
 			},
+			"custom_rules": schema.ListNestedAttribute{
+				MarkdownDescription: "Runtime rules authored for this ruleset, evaluated alongside the managed rules in `rules`.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the rule, unique in the ruleset.",
+							Required:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "Description of the rule.",
+							Optional:            true,
+						},
+						"condition": schema.StringAttribute{
+							MarkdownDescription: "The [Falco condition](https://falco.org/docs/concepts/rules/conditions/) matching the events the rule detects, for example `spawned_process and container and proc.name = nc`.",
+							Required:            true,
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+						"output": schema.StringAttribute{
+							MarkdownDescription: "The message logged when the rule matches. Falco fields such as `%container.id` are replaced with the values of the event.",
+							Required:            true,
+							Validators:          []validator.String{stringvalidator.LengthAtLeast(1)},
+						},
+						"priority": schema.StringAttribute{
+							MarkdownDescription: "Severity of the events of the rule. Enum: [" + strings.Join(customRulePriorities, ", ") + "].",
+							Required:            true,
+							Validators:          []validator.String{stringvalidator.OneOf(customRulePriorities...)},
+						},
+						"tags": schema.ListAttribute{
+							MarkdownDescription: "Tags of the rule, for example MITRE ATT&CK technique IDs such as `T1059`.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"mitigation": schema.StringAttribute{
+							MarkdownDescription: "Enum:[ log, isolate, terminate ] Default to log.",
+							Optional:            true,
+							Computed:            true,
+							Default:             stringdefault.StaticString(defaultCustomRuleMitigation),
+							Validators:          []validator.String{stringvalidator.OneOf("log", "isolate", "terminate")},
+						},
+					},
+				},
+			},
 			"createdtime": schema.StringAttribute{
 				MarkdownDescription: "The time when the ruleset was created.",
 				Computed:            true,
@@ -125,6 +194,27 @@ func (r *RulesetResource) Schema(ctx context.Context, req resource.SchemaRequest
 	}
 }
 
+func (r *RulesetResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data RulesetResourceModel
+	if diags := req.Config.Get(ctx, &data); diags.HasError() {
+		return
+	}
+
+	names := map[string]bool{}
+	for i, rule := range data.CustomRules {
+		if rule.Name.IsUnknown() || rule.Name.IsNull() {
+			continue
+		}
+		if names[rule.Name.ValueString()] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("custom_rules").AtListIndex(i).AtName("name"),
+				"Duplicate Custom Rule Name",
+				fmt.Sprintf("The custom rule name %q is used more than once in this ruleset.", rule.Name.ValueString()))
+		}
+		names[rule.Name.ValueString()] = true
+	}
+}
+
 func (r *RulesetResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
@@ -142,7 +232,7 @@ func (r *RulesetResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 
-	r.client.Client = client
+	r.client.Client = client.ForService(trendmicro.ServiceContainerSecurity)
 }
 
 func (r *RulesetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -153,27 +243,11 @@ func (r *RulesetResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	var rulesetRequest dto.CreateRulesetRequest
-	rulesetRequest.Name = plan.Name.ValueString()
-	if !plan.Description.IsNull() {
-		rulesetRequest.Description = plan.Description.ValueString()
-	}
-	rulesetRequest.Labels = make([]dto.Label, 0)
-	for _, label := range plan.Labels {
-		rulesetRequest.Labels = append(rulesetRequest.Labels, dto.Label{
-			Key:   label.Key.ValueString(),
-			Value: label.Value.ValueString(),
-		})
-	}
-	rulesetRequest.Rules = make([]dto.Rule, 0)
-	for _, rule := range plan.Rules {
-		rulesetRequest.Rules = append(rulesetRequest.Rules, dto.Rule{
-			Id:         rule.Id.ValueString(),
-			Mitigation: rule.Mitigation.ValueString(),
-		})
-	}
+	client := r.client.WithCredentials(plan.Credentials)
+
+	rulesetRequest := generateRulesetRequest(&plan)
 
-	createdRuleset, err := r.client.CreateRuleset(&rulesetRequest)
+	createdRuleset, err := client.CreateRuleset(&rulesetRequest)
 	if err != nil {
 		tflog.Debug(ctx, err.Error())
 		resp.Diagnostics.AddError(
@@ -196,6 +270,45 @@ func (r *RulesetResource) Create(ctx context.Context, req resource.CreateRequest
 	}
 }
 
+func generateRulesetRequest(plan *RulesetResourceModel) dto.CreateRulesetRequest {
+	var rulesetRequest dto.CreateRulesetRequest
+	rulesetRequest.Name = plan.Name.ValueString()
+	if !plan.Description.IsNull() {
+		rulesetRequest.Description = plan.Description.ValueString()
+	}
+	rulesetRequest.Labels = make([]dto.Label, 0)
+	for _, label := range plan.Labels {
+		rulesetRequest.Labels = append(rulesetRequest.Labels, dto.Label{
+			Key:   label.Key.ValueString(),
+			Value: label.Value.ValueString(),
+		})
+	}
+	rulesetRequest.Rules = make([]dto.Rule, 0)
+	for _, rule := range plan.Rules {
+		rulesetRequest.Rules = append(rulesetRequest.Rules, dto.Rule{
+			Id:         rule.Id.ValueString(),
+			Mitigation: rule.Mitigation.ValueString(),
+		})
+	}
+	rulesetRequest.CustomRules = make([]dto.CustomRule, 0)
+	for _, rule := range plan.CustomRules {
+		customRule := dto.CustomRule{
+			Name:        rule.Name.ValueString(),
+			Description: rule.Description.ValueString(),
+			Condition:   rule.Condition.ValueString(),
+			Output:      rule.Output.ValueString(),
+			Priority:    rule.Priority.ValueString(),
+			Mitigation:  rule.Mitigation.ValueString(),
+		}
+		for _, tag := range rule.Tags {
+			customRule.Tags = append(customRule.Tags, tag.ValueString())
+		}
+		rulesetRequest.CustomRules = append(rulesetRequest.CustomRules, customRule)
+	}
+
+	return rulesetRequest
+}
+
 func (r *RulesetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	var state RulesetResourceModel
 	diags := req.State.Get(ctx, &state)
@@ -204,7 +317,9 @@ func (r *RulesetResource) Read(ctx context.Context, req resource.ReadRequest, re
 		return
 	}
 
-	ruleset, err := r.client.GetRuleset(state.Id.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	ruleset, err := client.GetRuleset(state.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, dto.ErrorNotFound) {
 			resp.State.RemoveResource(ctx)
@@ -243,6 +358,36 @@ func (r *RulesetResource) Read(ctx context.Context, req resource.ReadRequest, re
 		}
 	}
 
+	if ruleset.CustomRules != nil && (len(ruleset.CustomRules) > 0 || state.CustomRules != nil) {
+		state.CustomRules = make([]customRuleModel, 0)
+		for i, rule := range ruleset.CustomRules {
+			customRule := customRuleModel{
+				Name:        types.StringValue(rule.Name),
+				Description: types.StringNull(),
+				Condition:   types.StringValue(rule.Condition),
+				Output:      types.StringValue(rule.Output),
+				Priority:    types.StringValue(rule.Priority),
+				Mitigation:  types.StringValue(defaultCustomRuleMitigation),
+			}
+			if rule.Description != "" {
+				customRule.Description = types.StringValue(rule.Description)
+			}
+			if rule.Mitigation != "" {
+				customRule.Mitigation = types.StringValue(rule.Mitigation)
+			}
+			// Keep an unset tags list unset when the API returns none
+			if len(rule.Tags) > 0 || (i < len(state.CustomRules) && state.CustomRules[i].Tags != nil) {
+				customRule.Tags = make([]types.String, 0, len(rule.Tags))
+				for _, tag := range rule.Tags {
+					customRule.Tags = append(customRule.Tags, types.StringValue(tag))
+				}
+			}
+			state.CustomRules = append(state.CustomRules, customRule)
+		}
+	} else {
+		state.CustomRules = nil
+	}
+
 	state.CreatedDateTime = types.StringValue(ruleset.CreatedDateTime)
 	state.UpdatedDateTime = types.StringValue(ruleset.UpdatedDateTime)
 
@@ -261,27 +406,11 @@ func (r *RulesetResource) Update(ctx context.Context, req resource.UpdateRequest
 		return
 	}
 
-	var rulesetRequest dto.CreateRulesetRequest
-	rulesetRequest.Name = plan.Name.ValueString()
-	if !plan.Description.IsNull() {
-		rulesetRequest.Description = plan.Description.ValueString()
-	}
-	rulesetRequest.Labels = make([]dto.Label, 0)
-	for _, label := range plan.Labels {
-		rulesetRequest.Labels = append(rulesetRequest.Labels, dto.Label{
-			Key:   label.Key.ValueString(),
-			Value: label.Value.ValueString(),
-		})
-	}
-	rulesetRequest.Rules = make([]dto.Rule, 0)
-	for _, rule := range plan.Rules {
-		rulesetRequest.Rules = append(rulesetRequest.Rules, dto.Rule{
-			Id:         rule.Id.ValueString(),
-			Mitigation: rule.Mitigation.ValueString(),
-		})
-	}
+	client := r.client.WithCredentials(plan.Credentials)
+
+	rulesetRequest := generateRulesetRequest(&plan)
 
-	ruleset, err := r.client.UpdateRuleset(plan.Id.ValueString(), &rulesetRequest)
+	ruleset, err := client.UpdateRuleset(plan.Id.ValueString(), &rulesetRequest)
 	if err != nil {
 		if errors.Is(err, dto.ErrorNotFound) {
 			resp.Diagnostics.AddError(
@@ -316,7 +445,9 @@ func (r *RulesetResource) Delete(ctx context.Context, req resource.DeleteRequest
 		return
 	}
 
-	err := r.client.DeleteRuleset(state.Id.ValueString())
+	client := r.client.WithCredentials(state.Credentials)
+
+	err := client.DeleteRuleset(state.Id.ValueString())
 	if err != nil {
 		if errors.Is(err, dto.ErrorNotFound) {
 			return
@@ -0,0 +1,236 @@
+package trendmicro
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCredentialsProfile is the profile read from the shared credentials file when none is named.
+	DefaultCredentialsProfile = "default"
+
+	credentialCommandTimeout = 30 * time.Second
+)
+
+// ErrProfileNotFound is returned by LoadSharedProfile when the credentials file has no section for the profile.
+var ErrProfileNotFound = errors.New("profile not found")
+
+// CredentialSource supplies the API key used as bearer token. The client asks it for a token
+// before every request and invalidates it when Vision One rejects the key with 401, so a rotated
+// key is picked up without restarting the provider.
+type CredentialSource interface {
+	// Token returns the current API key, fetching a new one when none is cached or it has expired.
+	Token() (string, error)
+	// Invalidate drops the cached key so that the next Token call fetches it again.
+	Invalidate()
+}
+
+// WithCredentials makes the client resolve its bearer token from src instead of the static key.
+func WithCredentials(src CredentialSource) ClientOption {
+	return func(c *Client) {
+		c.Credentials = src
+	}
+}
+
+// credential is a key fetched from a source together with the time it stops being valid.
+// A zero expiry means the key is used until Vision One rejects it.
+type credential struct {
+	token  string
+	expiry time.Time
+}
+
+type cachingCredentialSource struct {
+	mu     sync.Mutex
+	fetch  func() (credential, error)
+	cached *credential
+}
+
+func (s *cachingCredentialSource) Token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.cached != nil && (s.cached.expiry.IsZero() || time.Now().Before(s.cached.expiry)) {
+		return s.cached.token, nil
+	}
+
+	cred, err := s.fetch()
+	if err != nil {
+		return "", err
+	}
+	if cred.token == "" {
+		return "", fmt.Errorf("credential source returned an empty API key")
+	}
+	s.cached = &cred
+	return cred.token, nil
+}
+
+func (s *cachingCredentialSource) Invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cached = nil
+}
+
+// NewFileCredentialSource reads the API key from a file. The file is read again whenever the key
+// is rejected, so a secrets manager can rotate it in place.
+func NewFileCredentialSource(path string) CredentialSource {
+	return &cachingCredentialSource{
+		fetch: func() (credential, error) {
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return credential{}, fmt.Errorf("unable to read API key file %s: %w", path, err)
+			}
+			return credential{token: strings.TrimSpace(string(content))}, nil
+		},
+	}
+}
+
+// NewCommandCredentialSource runs a credential helper and uses what it prints to stdout as API key.
+// The output is either the bare key, or a JSON object {"api_key": "...", "expires_at": "<RFC3339>"}
+// in which case the helper is run again once the key has expired.
+func NewCommandCredentialSource(command []string) CredentialSource {
+	return &cachingCredentialSource{
+		fetch: func() (credential, error) {
+			return runCredentialCommand(command)
+		},
+	}
+}
+
+func runCredentialCommand(command []string) (credential, error) {
+	if len(command) == 0 || command[0] == "" {
+		return credential{}, fmt.Errorf("credential helper command is empty")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), credentialCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return credential{}, fmt.Errorf("credential helper %s failed: %w: %s", command[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseCredentialOutput(stdout.Bytes())
+}
+
+func parseCredentialOutput(out []byte) (credential, error) {
+	trimmed := bytes.TrimSpace(out)
+	if !bytes.HasPrefix(trimmed, []byte("{")) {
+		return credential{token: string(trimmed)}, nil
+	}
+
+	var payload struct {
+		APIKey    string `json:"api_key"`
+		ExpiresAt string `json:"expires_at"`
+	}
+	if err := json.Unmarshal(trimmed, &payload); err != nil {
+		return credential{}, fmt.Errorf("unable to parse credential helper output: %w", err)
+	}
+
+	cred := credential{token: payload.APIKey}
+	if payload.ExpiresAt != "" {
+		expiry, err := time.Parse(time.RFC3339, payload.ExpiresAt)
+		if err != nil {
+			return credential{}, fmt.Errorf("invalid expires_at in credential helper output: %w", err)
+		}
+		cred.expiry = expiry
+	}
+	return cred, nil
+}
+
+// SharedProfile is one named section of the shared credentials file.
+type SharedProfile struct {
+	APIKey       string
+	RegionalFQDN string
+}
+
+// DefaultSharedCredentialsFile returns ~/.visionone/credentials.
+func DefaultSharedCredentialsFile() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".visionone", "credentials"), nil
+}
+
+// LoadSharedProfile reads a profile from an INI style credentials file:
+//
+//	[default]
+//	api_key       = ...
+//	regional_fqdn = https://api.xdr.trendmicro.com
+func LoadSharedProfile(path, profile string) (*SharedProfile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open shared credentials file %s: %w", path, err)
+	}
+	defer file.Close()
+
+	var (
+		current string
+		found   bool
+		result  SharedProfile
+	)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = strings.TrimSpace(line[1 : len(line)-1])
+			if current == profile {
+				found = true
+			}
+			continue
+		}
+		if current != profile {
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		switch strings.TrimSpace(key) {
+		case "api_key":
+			result.APIKey = strings.TrimSpace(value)
+		case "regional_fqdn":
+			result.RegionalFQDN = strings.TrimSpace(value)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read shared credentials file %s: %w", path, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%w: %q in shared credentials file %s", ErrProfileNotFound, profile, path)
+	}
+
+	return &result, nil
+}
+
+// NewProfileCredentialSource reads the API key of a profile in the shared credentials file,
+// re-reading the file whenever the key is rejected.
+func NewProfileCredentialSource(path, profile string) CredentialSource {
+	return &cachingCredentialSource{
+		fetch: func() (credential, error) {
+			p, err := LoadSharedProfile(path, profile)
+			if err != nil {
+				return credential{}, err
+			}
+			if p.APIKey == "" {
+				return credential{}, fmt.Errorf("profile %q in %s has no api_key", profile, path)
+			}
+			return credential{token: p.APIKey}, nil
+		},
+	}
+}
@@ -0,0 +1,17 @@
+package trendmicro
+
+// WithCredentialsOverride returns a copy of the client that authenticates with apiKey against
+// regionalFQDN. An empty apiKey returns the client unchanged.
+func (c *Client) WithCredentialsOverride(apiKey, regionalFQDN string) *Client {
+	if apiKey == "" {
+		return c
+	}
+
+	clientCopy := *c
+	clientCopy.BearerToken = apiKey
+	clientCopy.Credentials = nil
+	if host, err := NormalizeRegionalFQDN(regionalFQDN); err == nil {
+		clientCopy.HostURL = host
+	}
+	return &clientCopy
+}
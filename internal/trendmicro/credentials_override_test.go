@@ -0,0 +1,29 @@
+package trendmicro
+
+import "testing"
+
+func TestWithCredentialsOverride(t *testing.T) {
+	base := &Client{
+		HostURL:     "https://api.xdr.trendmicro.com",
+		BearerToken: "provider-key",
+		Credentials: NewFileCredentialSource("/nonexistent"),
+	}
+
+	if got := base.WithCredentialsOverride("", ""); got != base {
+		t.Error("an unset override should return the client unchanged")
+	}
+
+	got := base.WithCredentialsOverride("tenant-key", "https://api.eu.xdr.trendmicro.com/")
+	if got == base {
+		t.Fatal("override should return a copy of the client")
+	}
+	if got.BearerToken != "tenant-key" || got.Credentials != nil {
+		t.Errorf("override should authenticate with the tenant key only, got token %q, source %v", got.BearerToken, got.Credentials)
+	}
+	if got.HostURL != "https://api.eu.xdr.trendmicro.com" {
+		t.Errorf("unexpected host %q", got.HostURL)
+	}
+	if base.BearerToken != "provider-key" || base.HostURL != "https://api.xdr.trendmicro.com" {
+		t.Error("override must not modify the provider client")
+	}
+}
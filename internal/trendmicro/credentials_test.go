@@ -0,0 +1,89 @@
+package trendmicro
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadSharedProfile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "credentials")
+	content := `# Vision One credentials
+[default]
+api_key = default-key
+
+[eu-tenant]
+api_key       = eu-key
+regional_fqdn = https://api.eu.xdr.trendmicro.com
+`
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := LoadSharedProfile(file, "eu-tenant")
+	if err != nil {
+		t.Fatalf("LoadSharedProfile returned error: %v", err)
+	}
+	if p.APIKey != "eu-key" || p.RegionalFQDN != "https://api.eu.xdr.trendmicro.com" {
+		t.Errorf("unexpected profile %+v", p)
+	}
+
+	if _, err := LoadSharedProfile(file, "missing"); !errors.Is(err, ErrProfileNotFound) {
+		t.Errorf("expected ErrProfileNotFound for a missing profile, got %v", err)
+	}
+}
+
+func TestParseCredentialOutput(t *testing.T) {
+	cred, err := parseCredentialOutput([]byte("plain-key\n"))
+	if err != nil || cred.token != "plain-key" || !cred.expiry.IsZero() {
+		t.Errorf("unexpected plain credential %+v, %v", cred, err)
+	}
+
+	cred, err = parseCredentialOutput([]byte(`{"api_key":"json-key","expires_at":"2026-01-01T00:00:00Z"}`))
+	if err != nil || cred.token != "json-key" || !cred.expiry.Equal(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected JSON credential %+v, %v", cred, err)
+	}
+
+	if _, err := parseCredentialOutput([]byte(`{"api_key":"k","expires_at":"tomorrow"}`)); err == nil {
+		t.Error("expected an error for an invalid expires_at")
+	}
+}
+
+func TestClientRefreshesRejectedKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "api_key")
+	if err := os.WriteFile(file, []byte("old-key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer new-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &Client{
+		HostURL:     server.URL,
+		HTTPClient:  &http.Client{},
+		Credentials: NewFileCredentialSource(file),
+	}
+
+	req, _ := http.NewRequest("GET", server.URL, http.NoBody)
+	if _, err := client.DoRequest(req); err == nil {
+		t.Fatal("expected 401 while the old key is in place")
+	}
+
+	if err := os.WriteFile(file, []byte("new-key\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	req, _ = http.NewRequest("GET", server.URL, http.NoBody)
+	if _, err := client.DoRequest(req); err != nil {
+		t.Fatalf("expected the rotated key to be picked up, got %v", err)
+	}
+}
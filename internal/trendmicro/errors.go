@@ -0,0 +1,129 @@
+package trendmicro
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+// APIError is a non-success response from the Vision One API. It matches the dto error sentinels
+// with errors.Is, so callers can branch on "not found" or "forbidden" without inspecting the message.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Code is the Vision One error code, e.g. "NotFound" or "InvalidParameter".
+	Code string
+	// Message is the human readable error message returned by the API.
+	Message string
+	// InnerErrors holds the nested innererror/details entries, when present.
+	InnerErrors []InnerError
+	// TraceID is the x-trace-id header, which Trend Micro support uses to find the request.
+	TraceID string
+	// Body is the raw response body.
+	Body []byte
+}
+
+// InnerError is a nested error entry in a Vision One error response.
+type InnerError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Service string `json:"service,omitempty"`
+	Target  string `json:"target,omitempty"`
+}
+
+type errorEnvelope struct {
+	Code       string       `json:"code"`
+	Message    string       `json:"message"`
+	InnerError *InnerError  `json:"innererror,omitempty"`
+	Details    []InnerError `json:"details,omitempty"`
+}
+
+// NewAPIError builds an APIError from a response status, headers and the already read body.
+// Bodies in both the {"error": {...}} envelope and the flat {"code", "message"} form are understood;
+// anything else is kept verbatim in Body.
+func NewAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		TraceID:    header.Get("x-trace-id"),
+		Body:       body,
+	}
+
+	var wrapped struct {
+		Error *errorEnvelope `json:"error"`
+	}
+	var envelope *errorEnvelope
+	if err := json.Unmarshal(body, &wrapped); err == nil && wrapped.Error != nil {
+		envelope = wrapped.Error
+	} else {
+		var flat errorEnvelope
+		if err := json.Unmarshal(body, &flat); err == nil {
+			envelope = &flat
+		}
+	}
+
+	if envelope != nil {
+		apiErr.Code = envelope.Code
+		apiErr.Message = envelope.Message
+		if envelope.InnerError != nil {
+			apiErr.InnerErrors = append(apiErr.InnerErrors, *envelope.InnerError)
+		}
+		apiErr.InnerErrors = append(apiErr.InnerErrors, envelope.Details...)
+	}
+
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	var out bytes.Buffer
+	if err := json.Indent(&out, e.Body, "", "  "); err == nil {
+		return fmt.Sprintf("\n%s \nTrace id: %s", out.String(), e.TraceID)
+	}
+	if len(e.Body) > 0 {
+		return fmt.Sprintf("unexpected status %d: %s \nTrace id: %s", e.StatusCode, e.Body, e.TraceID)
+	}
+	return fmt.Sprintf("unexpected status %d \nTrace id: %s", e.StatusCode, e.TraceID)
+}
+
+// Is reports whether the error corresponds to one of the dto error sentinels.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case dto.ErrorNotFound:
+		return e.StatusCode == http.StatusNotFound || e.Code == "NotFound"
+	case dto.ErrorForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case dto.Unauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case dto.ErrorBadRequest:
+		return e.StatusCode == http.StatusBadRequest
+	case dto.ErrorTooManyRequests:
+		return e.StatusCode == http.StatusTooManyRequests
+	case dto.ErrorPreconditionFailed:
+		return e.StatusCode == http.StatusPreconditionFailed
+	case dto.ErrorInternal:
+		return e.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
+
+// HasErrorCode reports whether err is an APIError with one of codes as its code or the code of one of
+// its inner errors.
+func HasErrorCode(err error, codes ...string) bool {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if slices.Contains(codes, apiErr.Code) {
+		return true
+	}
+	for _, inner := range apiErr.InnerErrors {
+		if slices.Contains(codes, inner.Code) {
+			return true
+		}
+	}
+	return false
+}
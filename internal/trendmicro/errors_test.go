@@ -0,0 +1,78 @@
+package trendmicro
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"terraform-provider-vision-one/pkg/dto"
+)
+
+func TestNewAPIErrorParsesEnvelope(t *testing.T) {
+	header := http.Header{}
+	header.Set("x-trace-id", "trace-123")
+	body := []byte(`{"error":{"code":"NotFound","message":"Profile does not exist","innererror":{"code":"ProfileNotFound","service":"crm"}}}`)
+
+	apiErr := NewAPIError(http.StatusNotFound, header, body)
+
+	if apiErr.Code != "NotFound" || apiErr.Message != "Profile does not exist" {
+		t.Errorf("unexpected code/message: %q / %q", apiErr.Code, apiErr.Message)
+	}
+	if apiErr.TraceID != "trace-123" {
+		t.Errorf("unexpected trace id %q", apiErr.TraceID)
+	}
+	if len(apiErr.InnerErrors) != 1 || apiErr.InnerErrors[0].Code != "ProfileNotFound" {
+		t.Errorf("unexpected inner errors %+v", apiErr.InnerErrors)
+	}
+	if !strings.Contains(apiErr.Error(), "Trace id: trace-123") {
+		t.Errorf("error message should carry the trace id: %s", apiErr.Error())
+	}
+}
+
+func TestAPIErrorMatchesSentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		body   string
+		target error
+		want   bool
+	}{
+		{http.StatusNotFound, `{"error":{"code":"NotFound","message":"gone"}}`, dto.ErrorNotFound, true},
+		{StatusVisionOneInnerError, `{"code":"NotFound","message":"gone"}`, dto.ErrorNotFound, true},
+		{http.StatusBadRequest, `{"error":{"code":"InvalidParameter","message":"NotFound in text only"}}`, dto.ErrorNotFound, false},
+		{http.StatusForbidden, `{}`, dto.ErrorForbidden, true},
+		{http.StatusUnauthorized, `{}`, dto.Unauthorized, true},
+		{http.StatusBadGateway, `<html>bad gateway</html>`, dto.ErrorInternal, true},
+		{http.StatusTooManyRequests, ``, dto.ErrorTooManyRequests, true},
+		{http.StatusPreconditionFailed, `{"error":{"code":"PreconditionFailed"}}`, dto.ErrorPreconditionFailed, true},
+		{http.StatusNotFound, ``, dto.ErrorForbidden, false},
+	}
+	for _, tc := range cases {
+		err := fmt.Errorf("wrapped: %w", NewAPIError(tc.status, http.Header{}, []byte(tc.body)))
+		if got := errors.Is(err, tc.target); got != tc.want {
+			t.Errorf("errors.Is(%d %s, %v) = %v, want %v", tc.status, tc.body, tc.target, got, tc.want)
+		}
+	}
+}
+
+func TestHasErrorCode(t *testing.T) {
+	cases := []struct {
+		body string
+		want bool
+	}{
+		{`{"error":{"code":"assume-identity-failed","message":"failed"}}`, true},
+		{`{"error":{"code":"BadRequest","message":"failed","innererror":{"code":"assume-identity-failed"}}}`, true},
+		{`{"error":{"code":"BadRequest","message":"assume-identity-failed in text only"}}`, false},
+		{`not json`, false},
+	}
+	for _, tc := range cases {
+		err := fmt.Errorf("wrapped: %w", NewAPIError(http.StatusBadRequest, http.Header{}, []byte(tc.body)))
+		if got := HasErrorCode(err, "assume-identity-failed"); got != tc.want {
+			t.Errorf("HasErrorCode(%s) = %v, want %v", tc.body, got, tc.want)
+		}
+	}
+	if HasErrorCode(errors.New("assume-identity-failed"), "assume-identity-failed") {
+		t.Error("only API errors have codes")
+	}
+}
@@ -0,0 +1,67 @@
+package trendmicro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ETagPrivateKey is the private state key under which resources keep the ETag of their last read.
+const ETagPrivateKey = "etag"
+
+// ConcurrentModificationSummary is the diagnostic summary reported when an update is rejected
+// with 412 Precondition Failed.
+const ConcurrentModificationSummary = "Resource Modified Outside Terraform"
+
+// PrivateState is the private state of a resource request or response.
+type PrivateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// GetETag returns the ETag kept in private, or "" when there is none.
+func GetETag(ctx context.Context, private PrivateState) (string, diag.Diagnostics) {
+	if private == nil {
+		return "", nil
+	}
+	raw, diags := private.GetKey(ctx, ETagPrivateKey)
+	if diags.HasError() || len(raw) == 0 {
+		return "", diags
+	}
+	var etag string
+	if err := json.Unmarshal(raw, &etag); err != nil {
+		// A corrupt value only costs the concurrency check, do not fail the operation.
+		return "", diags
+	}
+	return etag, diags
+}
+
+// SetETag keeps etag in private, or removes the key when etag is empty.
+func SetETag(ctx context.Context, private PrivateState, etag string) diag.Diagnostics {
+	if private == nil {
+		return nil
+	}
+	if etag == "" {
+		return private.SetKey(ctx, ETagPrivateKey, nil)
+	}
+	raw, _ := json.Marshal(etag)
+	return private.SetKey(ctx, ETagPrivateKey, raw)
+}
+
+// SetIfMatch makes req conditional on etag. Nothing is set for an empty etag, so objects read before
+// ETags were kept are still updated unconditionally.
+func SetIfMatch(req *http.Request, etag string) {
+	if etag != "" {
+		req.Header.Set("If-Match", etag)
+	}
+}
+
+// ConcurrentModificationDetail explains a 412 Precondition Failed on update.
+func ConcurrentModificationDetail(kind, id string) string {
+	return fmt.Sprintf("The %s %s was changed in Vision One after Terraform last read it, for example by another "+
+		"pipeline or in the console. Terraform did not apply its changes to avoid overwriting them. "+
+		"Run terraform refresh (or terraform plan -refresh-only) to pick up the remote changes, then re-plan and apply.", kind, id)
+}
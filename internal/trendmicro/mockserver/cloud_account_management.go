@@ -0,0 +1,26 @@
+package mockserver
+
+// Cloud Account Management collections. Azure subscriptions and GCP projects are keyed by the
+// subscription ID and project number sent on creation, AWS accounts get a generated ID.
+const (
+	CAMAWSAccountsPath        = "/beta/cam/awsAccounts"
+	CAMAzureSubscriptionsPath = "/beta/cam/azureSubscriptions"
+	CAMGCPProjectsPath        = "/beta/cam/gcpProjects"
+)
+
+func (s *Server) registerCloudAccountManagement() {
+	s.handleCollection(collection{
+		path:     CAMAWSAccountsPath,
+		defaults: Object{"state": "managed"},
+	})
+	s.handleCollection(collection{
+		path:     CAMAzureSubscriptionsPath,
+		idField:  "subscriptionId",
+		defaults: Object{"state": "managed"},
+	})
+	s.handleCollection(collection{
+		path:     CAMGCPProjectsPath,
+		idField:  "projectNumber",
+		defaults: Object{"state": "managed"},
+	})
+}
@@ -0,0 +1,196 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Cloud Risk Management collections. Accounts, checks, rules and compliance standards cannot be
+// created through the API; add them with Seed.
+const (
+	CRMAccountsPath                    = "/beta/cloudPosture/accounts"
+	CRMChecksPath                      = "/beta/cloudPosture/checks"
+	CRMComplianceStandardsPath         = "/beta/cloudPosture/complianceStandards"
+	CRMCommunicationConfigurationsPath = "/beta/cloudPosture/communicationConfigurations"
+	CRMCustomRulesPath                 = "/beta/cloudPosture/customRules"
+	CRMGroupsPath                      = "/beta/cloudPosture/groups"
+	CRMProfilesPath                    = "/beta/cloudPosture/profiles"
+	CRMReportConfigurationsPath        = "/beta/cloudPosture/reportConfigurations"
+	CRMRulesPath                       = "/beta/cloudPosture/rules"
+
+	crmAccountsListPath = "/v3.0/cloudRiskManagement/accounts"
+)
+
+// CRMScanRulesPath is the collection holding the customized scan rule settings of an account.
+func CRMScanRulesPath(accountID string) string {
+	return CRMAccountsPath + "/" + accountID + "/scanRules"
+}
+
+// crmScanSettings is the store key of the account scan settings, which have no collection URL.
+const crmScanSettings = "crm:scanSettings"
+
+var defaultScanSetting = Object{"enabled": true, "interval": 1, "disabledRegions": []any{}}
+
+func (s *Server) registerCloudRiskManagement() {
+	s.handleCollection(collection{path: CRMCustomRulesPath})
+	s.handleCollection(collection{path: CRMGroupsPath})
+	s.handleCollection(collection{path: CRMProfilesPath})
+	s.handleCollection(collection{path: CRMReportConfigurationsPath})
+	s.handleCollection(collection{path: CRMCommunicationConfigurationsPath, multiStatus: true})
+
+	s.mux.HandleFunc("GET "+crmAccountsListPath, func(w http.ResponseWriter, r *http.Request) {
+		s.writeList(w, r, CRMAccountsPath)
+	})
+	s.mux.HandleFunc("GET "+CRMAccountsPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.writeObject(w, CRMAccountsPath, r.PathValue("id"))
+	})
+
+	s.mux.HandleFunc("GET "+CRMChecksPath, func(w http.ResponseWriter, r *http.Request) {
+		s.writeList(w, r, CRMChecksPath)
+	})
+	s.mux.HandleFunc("GET "+CRMChecksPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.writeObject(w, CRMChecksPath, r.PathValue("id"))
+	})
+	s.mux.HandleFunc("PATCH "+CRMChecksPath+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.patchObject(w, r, CRMChecksPath, r.PathValue("id"))
+	})
+
+	for _, path := range []string{CRMRulesPath, CRMComplianceStandardsPath} {
+		s.mux.HandleFunc("GET "+path, func(w http.ResponseWriter, r *http.Request) {
+			s.writeList(w, r, path)
+		})
+	}
+
+	s.mux.HandleFunc("POST "+CRMProfilesPath+"/{id}/apply", s.applyProfile)
+
+	s.mux.HandleFunc("GET "+CRMAccountsPath+"/{id}/scanRules", func(w http.ResponseWriter, r *http.Request) {
+		if !s.hasAccount(w, r) {
+			return
+		}
+		s.writeList(w, r, CRMScanRulesPath(r.PathValue("id")))
+	})
+	s.mux.HandleFunc("POST "+CRMAccountsPath+"/{id}/scanRules/update", s.updateScanRules)
+	s.mux.HandleFunc("POST "+CRMAccountsPath+"/{id}/scanRules/delete", s.deleteScanRules)
+
+	s.mux.HandleFunc("GET "+CRMAccountsPath+"/{id}/scanSetting", func(w http.ResponseWriter, r *http.Request) {
+		if !s.hasAccount(w, r) {
+			return
+		}
+		setting, ok := s.Get(crmScanSettings, r.PathValue("id"))
+		if !ok {
+			setting = copyObject(defaultScanSetting)
+		}
+		delete(setting, "id")
+		writeJSON(w, http.StatusOK, setting)
+	})
+	s.mux.HandleFunc("PATCH "+CRMAccountsPath+"/{id}/scanSetting", func(w http.ResponseWriter, r *http.Request) {
+		if !s.hasAccount(w, r) {
+			return
+		}
+		var patch Object
+		if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+
+		id := r.PathValue("id")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		setting, ok := s.store[crmScanSettings][id]
+		if !ok {
+			setting = copyObject(defaultScanSetting)
+			setting["id"] = id
+			s.put(crmScanSettings, id, setting)
+		}
+		merge(setting, patch)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (s *Server) hasAccount(w http.ResponseWriter, r *http.Request) bool {
+	if _, ok := s.Get(CRMAccountsPath, r.PathValue("id")); !ok {
+		writeNotFound(w)
+		return false
+	}
+	return true
+}
+
+func (s *Server) applyProfile(w http.ResponseWriter, r *http.Request) {
+	if _, ok := s.Get(CRMProfilesPath, r.PathValue("id")); !ok {
+		writeNotFound(w)
+		return
+	}
+	var req struct {
+		AccountIDs []string `json:"accountIds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+	for _, id := range req.AccountIDs {
+		if _, ok := s.Get(CRMAccountsPath, id); !ok {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", "account "+id+" does not exist")
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, Object{"meta": Object{"status": "success", "message": "Profile applied"}})
+}
+
+// updateScanRules answers the multi-status update of account rule settings. Updated rules are
+// stored with isCustomized set, so they show up in the customized rule list.
+func (s *Server) updateScanRules(w http.ResponseWriter, r *http.Request) {
+	if !s.hasAccount(w, r) {
+		return
+	}
+	var rules []Object
+	if err := json.NewDecoder(r.Body).Decode(&rules); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	path := CRMScanRulesPath(r.PathValue("id"))
+	results := make([]Object, 0, len(rules))
+	s.mu.Lock()
+	for _, rule := range rules {
+		id, _ := rule["id"].(string)
+		if id == "" {
+			results = append(results, Object{"status": http.StatusBadRequest, "body": Object{"error": Object{"code": "InvalidParameter", "message": "rule id is required"}}})
+			continue
+		}
+		delete(rule, "note")
+		rule["isCustomized"] = true
+		s.put(path, id, rule)
+		results = append(results, Object{"status": http.StatusNoContent})
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusMultiStatus, results)
+}
+
+// deleteScanRules answers the multi-status reset of account rule settings.
+func (s *Server) deleteScanRules(w http.ResponseWriter, r *http.Request) {
+	if !s.hasAccount(w, r) {
+		return
+	}
+	var items []struct {
+		ID string `json:"id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&items); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	path := CRMScanRulesPath(r.PathValue("id"))
+	results := make([]Object, 0, len(items))
+	s.mu.Lock()
+	for _, item := range items {
+		status := http.StatusNoContent
+		if !s.remove(path, item.ID) {
+			status = http.StatusNotFound
+		}
+		results = append(results, Object{"status": status})
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusMultiStatus, results)
+}
@@ -0,0 +1,52 @@
+package mockserver
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// Container Security collections.
+const (
+	KubernetesClustersPath      = "/v3.0/containerSecurity/kubernetesClusters"
+	KubernetesClusterGroupsPath = "/v3.0/containerSecurity/kubernetesClusterGroups"
+	AmazonEcsClustersPath       = "/v3.0/containerSecurity/amazonEcsClusters"
+	PoliciesPath                = "/v3.0/containerSecurity/policies"
+	RulesetsPath                = "/v3.0/containerSecurity/rulesets"
+	ManagedRulesPath            = "/v3.0/containerSecurity/managedRules"
+	// CustomTagsPath holds the Attack Surface Risk Management custom tags clusters are tagged with.
+	CustomTagsPath = "/v3.0/asrm/attackSurfaceCustomTags"
+)
+
+func (s *Server) registerContainerSecurity() {
+	s.handleCollection(collection{
+		path:     KubernetesClustersPath,
+		defaults: Object{"orchestrator": "Kubernetes"},
+		createResponse: func(obj Object) any {
+			return Object{
+				"apiKey":      "mock-cluster-key-" + obj["id"].(string),
+				"endpointUrl": s.URL + "/container-security",
+			}
+		},
+	})
+	s.mux.HandleFunc("POST "+KubernetesClustersPath+"/{id}/regenerateApiKey", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		cluster, ok := s.store[KubernetesClustersPath][id]
+		if !ok {
+			writeNotFound(w)
+			return
+		}
+		// Keys are not stored on the cluster, count the rotations to hand out a new one each time
+		version, _ := cluster["apiKeyVersion"].(int)
+		cluster["apiKeyVersion"] = version + 1
+		writeJSON(w, http.StatusOK, Object{"apiKey": fmt.Sprintf("mock-cluster-key-%s-%d", id, version+1)})
+	})
+	s.handleCollection(collection{path: PoliciesPath})
+	s.handleCollection(collection{path: RulesetsPath})
+	s.handleCollection(collection{path: ManagedRulesPath})
+	s.handleCollection(collection{path: KubernetesClusterGroupsPath})
+	// ECS clusters are discovered, tests Seed them instead of creating them
+	s.handleCollection(collection{path: AmazonEcsClustersPath})
+	s.handleCollection(collection{path: CustomTagsPath})
+}
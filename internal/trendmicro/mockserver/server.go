@@ -0,0 +1,485 @@
+// Package mockserver is an in-memory fake of the Vision One API. It serves the healthcheck and the
+// Container Security, Cloud Risk Management and Cloud Account Management endpoints called by the
+// provider, so client and acceptance tests can run without network access or a live tenant.
+package mockserver
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// APIKey is the bearer token accepted by a new server.
+const APIKey = "mock-api-key"
+
+// Object is a stored API object, kept as decoded JSON.
+type Object = map[string]any
+
+// Request is a request received by the server, recorded for assertions.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is a fake Vision One backend listening on a local httptest server. Every response carries
+// an x-trace-id header, creations answer with a Location header, single objects are served with an
+// ETag that PATCH checks against If-Match, and objects live in memory until the server is closed.
+type Server struct {
+	*httptest.Server
+
+	mux *http.ServeMux
+
+	mu       sync.Mutex
+	apiKey   string
+	store    map[string]map[string]Object
+	order    map[string][]string
+	faults   []*fault
+	requests []Request
+}
+
+type fault struct {
+	method string
+	path   string
+	status int
+	times  int
+}
+
+// New starts a fake Vision One backend. Call Close when done.
+func New() *Server {
+	s := &Server{
+		mux:    http.NewServeMux(),
+		apiKey: APIKey,
+		store:  map[string]map[string]Object{},
+		order:  map[string][]string{},
+	}
+
+	s.mux.HandleFunc("GET /v3.0/healthcheck/connectivity", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Object{"status": "available"})
+	})
+	s.registerContainerSecurity()
+	s.registerCloudRiskManagement()
+	s.registerCloudAccountManagement()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// NewTestServer starts a fake Vision One backend that is closed when the test ends.
+func NewTestServer(t testing.TB) *Server {
+	t.Helper()
+	s := New()
+	t.Cleanup(s.Close)
+	return s
+}
+
+// SetProviderEnv points the provider at the server through the VISIONONE_API_KEY and
+// VISIONONE_REGIONAL_FQDN environment variables, for acceptance tests driving the provider binary.
+func (s *Server) SetProviderEnv(t testing.TB) {
+	t.Helper()
+	t.Setenv("VISIONONE_API_KEY", s.APIKey())
+	t.Setenv("VISIONONE_REGIONAL_FQDN", s.URL)
+}
+
+// APIKey returns the bearer token currently accepted by the server.
+func (s *Server) APIKey() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.apiKey
+}
+
+// SetAPIKey changes the accepted bearer token, e.g. to simulate a rotated key.
+func (s *Server) SetAPIKey(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.apiKey = key
+}
+
+// FailNext makes the next times requests with the given method whose path starts with pathPrefix
+// fail with status, before any other processing.
+func (s *Server) FailNext(method, pathPrefix string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault{method: method, path: pathPrefix, status: status, times: times})
+}
+
+// Requests returns the requests received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// Seed stores obj in the collection at path, for objects the API cannot create such as CRM
+// accounts or checks. obj must have a string "id".
+func (s *Server) Seed(path string, obj Object) {
+	id, _ := obj["id"].(string)
+	if id == "" {
+		panic("mockserver: seeded object has no id")
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.put(path, id, copyObject(obj))
+}
+
+// Get returns a copy of the object with the given id in the collection at path.
+func (s *Server) Get(path, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.store[path][id]
+	if !ok {
+		return nil, false
+	}
+	return copyObject(obj), true
+}
+
+// List returns copies of the objects in the collection at path, in creation order.
+func (s *Server) List(path string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	items := make([]Object, 0, len(s.order[path]))
+	for _, id := range s.order[path] {
+		items = append(items, copyObject(s.store[path][id]))
+	}
+	return items
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("x-trace-id", uuid.NewString())
+
+	body, _ := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	apiKey := s.apiKey
+	status := s.takeFault(r)
+	s.mu.Unlock()
+
+	if r.Header.Get("Authorization") != "Bearer "+apiKey {
+		writeError(w, http.StatusUnauthorized, "InvalidCredentials", "the API key is invalid or expired")
+		return
+	}
+	if status != 0 {
+		writeError(w, status, http.StatusText(status), "injected failure")
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) takeFault(r *http.Request) int {
+	for i, f := range s.faults {
+		if f.method == r.Method && strings.HasPrefix(r.URL.Path, f.path) {
+			f.times--
+			if f.times <= 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+			return f.status
+		}
+	}
+	return 0
+}
+
+// collection describes a REST collection served with the usual list, create, get, patch and
+// delete operations.
+type collection struct {
+	path string
+	// idField names the request field holding the ID of a new object. The server generates an ID
+	// when it is empty.
+	idField string
+	// defaults are set on new objects that do not carry the field.
+	defaults Object
+	// createResponse returns the body of a successful creation; the body is empty when nil.
+	createResponse func(obj Object) any
+	// multiStatus collections take an array of objects on POST and answer 207 with one
+	// {"status", "headers"} entry per object.
+	multiStatus bool
+}
+
+func (s *Server) handleCollection(c collection) {
+	s.mux.HandleFunc("GET "+c.path, func(w http.ResponseWriter, r *http.Request) {
+		s.writeList(w, r, c.path)
+	})
+	s.mux.HandleFunc("POST "+c.path, func(w http.ResponseWriter, r *http.Request) {
+		if c.multiStatus {
+			s.createMultiStatus(w, r, c)
+			return
+		}
+		var obj Object
+		if err := json.NewDecoder(r.Body).Decode(&obj); err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+			return
+		}
+		created, status := s.create(c, obj)
+		if status != http.StatusCreated {
+			writeError(w, status, "Conflict", "the object already exists")
+			return
+		}
+		w.Header().Set("Location", s.location(c.path, created["id"].(string)))
+		if c.createResponse == nil {
+			w.WriteHeader(http.StatusCreated)
+			return
+		}
+		writeJSON(w, http.StatusCreated, c.createResponse(created))
+	})
+	s.mux.HandleFunc("GET "+c.path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.writeObject(w, c.path, r.PathValue("id"))
+	})
+	s.mux.HandleFunc("PATCH "+c.path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.patchObject(w, r, c.path, r.PathValue("id"))
+	})
+	s.mux.HandleFunc("DELETE "+c.path+"/{id}", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if !s.remove(c.path, r.PathValue("id")) {
+			writeNotFound(w)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+// create stores a new object and returns a copy of it with 201, or 409 when an object with the
+// same ID exists.
+func (s *Server) create(c collection, obj Object) (Object, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id, _ := obj[c.idField].(string)
+	if id == "" {
+		id = uuid.NewString()
+	}
+	if _, exists := s.store[c.path][id]; exists {
+		return nil, http.StatusConflict
+	}
+
+	now := timestamp()
+	obj["id"] = id
+	obj["createdDateTime"] = now
+	obj["updatedDateTime"] = now
+	for k, v := range c.defaults {
+		if _, ok := obj[k]; !ok {
+			obj[k] = v
+		}
+	}
+	s.put(c.path, id, obj)
+	return copyObject(obj), http.StatusCreated
+}
+
+func (s *Server) createMultiStatus(w http.ResponseWriter, r *http.Request, c collection) {
+	var objs []Object
+	if err := json.NewDecoder(r.Body).Decode(&objs); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	results := make([]Object, 0, len(objs))
+	for _, obj := range objs {
+		created, status := s.create(c, obj)
+		result := Object{"status": status}
+		if status == http.StatusCreated {
+			result["headers"] = []Object{{"name": "Location", "value": s.location(c.path, created["id"].(string))}}
+		} else {
+			result["body"] = Object{"error": Object{"code": "Conflict", "message": "the object already exists"}}
+		}
+		results = append(results, result)
+	}
+	writeJSON(w, http.StatusMultiStatus, results)
+}
+
+func (s *Server) writeObject(w http.ResponseWriter, path, id string) {
+	obj, ok := s.Get(path, id)
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	w.Header().Set("ETag", etag(obj))
+	writeJSON(w, http.StatusOK, obj)
+}
+
+func (s *Server) patchObject(w http.ResponseWriter, r *http.Request, path, id string) {
+	var patch Object
+	if err := json.NewDecoder(r.Body).Decode(&patch); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidParameter", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	obj, ok := s.store[path][id]
+	if !ok {
+		writeNotFound(w)
+		return
+	}
+	if ifMatch := r.Header.Get("If-Match"); ifMatch != "" && ifMatch != etag(obj) {
+		writeError(w, http.StatusPreconditionFailed, "PreconditionFailed", "the object was modified since it was read")
+		return
+	}
+	merge(obj, patch)
+	obj["updatedDateTime"] = timestamp()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeList answers a collection GET with {"items", "count", "totalCount"}. The TMV1-Filter header
+// is honoured for "field eq 'value'" clauses joined with "and", each optionally a parenthesized
+// group of clauses joined with "or", and the top query parameter pages the result through nextLink.
+func (s *Server) writeList(w http.ResponseWriter, r *http.Request, path string) {
+	items := filterObjects(s.List(path), r.Header.Get("TMV1-Filter"))
+	total := len(items)
+
+	query := r.URL.Query()
+	skip, _ := strconv.Atoi(query.Get("skipToken"))
+	if skip > len(items) {
+		skip = len(items)
+	}
+	items = items[skip:]
+
+	resp := Object{"totalCount": total}
+	if top, err := strconv.Atoi(query.Get("top")); err == nil && top > 0 && top < len(items) {
+		items = items[:top]
+		query.Set("skipToken", strconv.Itoa(skip+top))
+		resp["nextLink"] = s.URL + r.URL.Path + "?" + query.Encode()
+	}
+	resp["items"] = items
+	resp["count"] = len(items)
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+var filterClause = regexp.MustCompile(`^\s*(\w+)\s+eq\s+'((?:[^']|'')*)'\s*$`)
+
+func filterObjects(items []Object, filter string) []Object {
+	if strings.TrimSpace(filter) == "" {
+		return items
+	}
+	clauses := strings.Split(filter, " and ")
+
+	var matched []Object
+	for _, item := range items {
+		ok := true
+		for _, clause := range clauses {
+			if !matchesAny(item, clause) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			matched = append(matched, item)
+		}
+	}
+	return matched
+}
+
+// matchesAny reports whether item matches one of the "or" alternatives of a clause.
+func matchesAny(item Object, clause string) bool {
+	clause = strings.TrimSpace(clause)
+	if strings.HasPrefix(clause, "(") && strings.HasSuffix(clause, ")") {
+		clause = clause[1 : len(clause)-1]
+	}
+	for _, alternative := range strings.Split(clause, " or ") {
+		m := filterClause.FindStringSubmatch(alternative)
+		if m != nil && fmt.Sprint(item[m[1]]) == strings.ReplaceAll(m[2], "''", "'") {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) location(path, id string) string {
+	return s.URL + path + "/" + url.PathEscape(id)
+}
+
+// put and remove expect s.mu to be held.
+func (s *Server) put(path, id string, obj Object) {
+	if s.store[path] == nil {
+		s.store[path] = map[string]Object{}
+	}
+	if _, exists := s.store[path][id]; !exists {
+		s.order[path] = append(s.order[path], id)
+	}
+	s.store[path][id] = obj
+}
+
+func (s *Server) remove(path, id string) bool {
+	if _, ok := s.store[path][id]; !ok {
+		return false
+	}
+	delete(s.store[path], id)
+	for i, existing := range s.order[path] {
+		if existing == id {
+			s.order[path] = append(s.order[path][:i], s.order[path][i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// merge applies a JSON merge patch: null removes a field, objects are merged into objects and anything
+// else replaces the field.
+func merge(obj, patch Object) {
+	for k, v := range patch {
+		if v == nil {
+			delete(obj, k)
+			continue
+		}
+		nested, ok := v.(map[string]any)
+		if current, isObject := obj[k].(map[string]any); ok && isObject {
+			merge(current, nested)
+			continue
+		}
+		obj[k] = v
+	}
+}
+
+// etag derives a strong ETag from the content of obj, so every change yields a new one.
+func etag(obj Object) string {
+	raw, _ := json.Marshal(obj)
+	return fmt.Sprintf("\"%x\"", sha256.Sum256(raw))
+}
+
+func copyObject(obj Object) Object {
+	raw, _ := json.Marshal(obj)
+	var out Object
+	_ = json.Unmarshal(raw, &out)
+	return out
+}
+
+func timestamp() string {
+	return time.Now().UTC().Format(time.RFC3339)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, Object{"error": Object{"code": code, "message": message}})
+}
+
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "NotFound", "the requested object does not exist")
+}
@@ -0,0 +1,75 @@
+package mockserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func get(t *testing.T, s *Server, url string, header http.Header) (*http.Response, Object) {
+	t.Helper()
+	req, _ := http.NewRequest("GET", url, http.NoBody)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Authorization", "Bearer "+s.APIKey())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	defer resp.Body.Close()
+	var body Object
+	_ = json.NewDecoder(resp.Body).Decode(&body)
+	return resp, body
+}
+
+func TestListFiltersAndPages(t *testing.T) {
+	s := NewTestServer(t)
+	for _, id := range []string{"a", "b", "c"} {
+		s.Seed(CRMAccountsPath, Object{"id": id, "provider": "aws"})
+	}
+	s.Seed(CRMAccountsPath, Object{"id": "d", "provider": "azure"})
+
+	header := http.Header{"Tmv1-Filter": []string{"provider eq 'aws'"}}
+	url := s.URL + crmAccountsListPath + "?top=2"
+	var ids []string
+	for pages := 0; url != ""; pages++ {
+		if pages > 3 {
+			t.Fatal("nextLink does not terminate")
+		}
+		resp, body := get(t, s, url, header)
+		if resp.Header.Get("x-trace-id") == "" {
+			t.Error("response without x-trace-id")
+		}
+		for _, item := range body["items"].([]any) {
+			ids = append(ids, item.(Object)["id"].(string))
+		}
+		url, _ = body["nextLink"].(string)
+	}
+	if len(ids) != 3 || ids[0] != "a" || ids[2] != "c" {
+		t.Errorf("unexpected ids %v", ids)
+	}
+}
+
+func TestRejectsUnknownKeyAndInjectsFaults(t *testing.T) {
+	s := NewTestServer(t)
+
+	req, _ := http.NewRequest("GET", s.URL+"/v3.0/healthcheck/connectivity", http.NoBody)
+	req.Header.Set("Authorization", "Bearer wrong")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected 401 for a wrong key, got %d", resp.StatusCode)
+	}
+
+	s.FailNext("GET", "/v3.0/healthcheck", http.StatusServiceUnavailable, 1)
+	if resp, _ := get(t, s, s.URL+"/v3.0/healthcheck/connectivity", nil); resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("expected the injected 503, got %d", resp.StatusCode)
+	}
+	if resp, body := get(t, s, s.URL+"/v3.0/healthcheck/connectivity", nil); resp.StatusCode != http.StatusOK || body["status"] != "available" {
+		t.Errorf("expected the fault to be consumed, got %d %v", resp.StatusCode, body)
+	}
+}
@@ -0,0 +1,128 @@
+package trendmicro
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// Page is one page of a Vision One list response.
+type Page[T any] struct {
+	Items      []T    `json:"items"`
+	TotalCount int    `json:"totalCount"`
+	Count      int    `json:"count"`
+	NextLink   string `json:"nextLink"`
+}
+
+// ListOptions controls how a list endpoint is paged.
+type ListOptions struct {
+	// Top is the page size sent as the top query parameter of the first request. Zero leaves the
+	// server default. Later pages keep whatever page size the server encoded in nextLink.
+	Top int
+	// Limit stops ListAll once this many items were collected. Zero reads every page.
+	Limit int
+	// Header is sent with every page request, for example a TMV1-Filter.
+	Header http.Header
+}
+
+// Pages returns an iterator over the pages of the list at rawURL. It follows nextLink until the
+// server stops sending one, the consumer stops iterating or ctx is done. A failed page is yielded
+// as the last element with its error; API errors are passed through unwrapped so callers can
+// still match them with errors.Is.
+func Pages[T any](ctx context.Context, c *Client, rawURL string, opts ListOptions) iter.Seq2[*Page[T], error] {
+	return func(yield func(*Page[T], error) bool) {
+		next, err := url.Parse(rawURL)
+		if err != nil {
+			yield(nil, fmt.Errorf("invalid list URL %q: %w", rawURL, err))
+			return
+		}
+		if opts.Top > 0 {
+			query := next.Query()
+			query.Set("top", strconv.Itoa(opts.Top))
+			next.RawQuery = query.Encode()
+		}
+
+		seen := map[string]bool{}
+		for next != nil {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+				return
+			}
+			if seen[next.String()] {
+				yield(nil, fmt.Errorf("nextLink %s was already read, stopping to avoid an endless loop", next.Redacted()))
+				return
+			}
+			seen[next.String()] = true
+
+			page, err := getPage[T](ctx, c, next, opts.Header)
+			if err != nil {
+				yield(nil, err)
+				return
+			}
+			if !yield(page, nil) {
+				return
+			}
+
+			if next, err = resolveNextLink(next, page.NextLink); err != nil {
+				yield(nil, err)
+				return
+			}
+		}
+	}
+}
+
+// ListAll reads the pages of the list at rawURL and returns their items.
+func ListAll[T any](ctx context.Context, c *Client, rawURL string, opts ListOptions) ([]T, error) {
+	items := []T{}
+	for page, err := range Pages[T](ctx, c, rawURL, opts) {
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if opts.Limit > 0 && len(items) >= opts.Limit {
+			return items[:opts.Limit], nil
+		}
+	}
+	return items, nil
+}
+
+func getPage[T any](ctx context.Context, c *Client, pageURL *url.URL, header http.Header) (*Page[T], error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	body, err := c.DoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var page Page[T]
+	if err := json.Unmarshal(body, &page); err != nil {
+		return nil, fmt.Errorf("failed to decode list page %s: %w", pageURL.Path, err)
+	}
+	return &page, nil
+}
+
+// resolveNextLink returns the URL of the page after current, or nil on the last page. The bearer
+// token is sent with every page, so a nextLink pointing at another host is refused.
+func resolveNextLink(current *url.URL, nextLink string) (*url.URL, error) {
+	if nextLink == "" {
+		return nil, nil
+	}
+	next, err := current.Parse(nextLink)
+	if err != nil {
+		return nil, fmt.Errorf("invalid nextLink %q: %w", nextLink, err)
+	}
+	if next.Scheme != current.Scheme || next.Host != current.Host {
+		return nil, fmt.Errorf("nextLink points to %s://%s instead of %s://%s", next.Scheme, next.Host, current.Scheme, current.Host)
+	}
+	return next, nil
+}
@@ -0,0 +1,128 @@
+package trendmicro
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"terraform-provider-vision-one/internal/trendmicro/mockserver"
+)
+
+type pagedItem struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func newPagingTestClient(t *testing.T, count int) (*Client, *mockserver.Server) {
+	server := mockserver.NewTestServer(t)
+	for i := range count {
+		server.Seed(mockserver.KubernetesClustersPath, mockserver.Object{"id": fmt.Sprintf("cluster-%d", i), "name": fmt.Sprintf("name-%d", i%2)})
+	}
+	return &Client{HostURL: server.URL, HTTPClient: &http.Client{}, BearerToken: mockserver.APIKey}, server
+}
+
+func TestListAllFollowsNextLink(t *testing.T) {
+	client, server := newPagingTestClient(t, 5)
+
+	items, err := ListAll[pagedItem](context.Background(), client, server.URL+mockserver.KubernetesClustersPath, ListOptions{Top: 2})
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(items) != 5 || items[0].ID != "cluster-0" || items[4].ID != "cluster-4" {
+		t.Errorf("unexpected items %+v", items)
+	}
+
+	requests := server.Requests()
+	if len(requests) != 3 {
+		t.Fatalf("expected 3 page requests, got %d", len(requests))
+	}
+	if top := requests[0].Query.Get("top"); top != "2" {
+		t.Errorf("expected top=2 on the first page, got %q", top)
+	}
+}
+
+func TestListAllSendsHeaderAndStopsAtLimit(t *testing.T) {
+	client, server := newPagingTestClient(t, 9)
+
+	items, err := ListAll[pagedItem](context.Background(), client, server.URL+mockserver.KubernetesClustersPath, ListOptions{
+		Top:    2,
+		Limit:  3,
+		Header: http.Header{"TMV1-Filter": {"name eq 'name-0'"}},
+	})
+	if err != nil {
+		t.Fatalf("ListAll: %v", err)
+	}
+	if len(items) != 3 || items[2].ID != "cluster-4" {
+		t.Errorf("unexpected items %+v", items)
+	}
+	requests := server.Requests()
+	if len(requests) != 2 {
+		t.Errorf("expected reading to stop after 2 pages, got %d requests", len(requests))
+	}
+	for _, r := range requests {
+		if r.Header.Get("TMV1-Filter") != "name eq 'name-0'" {
+			t.Errorf("page request %s is missing the filter header", r.Query.Encode())
+		}
+	}
+}
+
+func TestPagesStopsWhenConsumerBreaks(t *testing.T) {
+	client, server := newPagingTestClient(t, 5)
+
+	for page, err := range Pages[pagedItem](context.Background(), client, server.URL+mockserver.KubernetesClustersPath, ListOptions{Top: 2}) {
+		if err != nil {
+			t.Fatalf("Pages: %v", err)
+		}
+		if page.TotalCount != 5 || page.NextLink == "" {
+			t.Errorf("unexpected first page %+v", page)
+		}
+		break
+	}
+	if n := len(server.Requests()); n != 1 {
+		t.Errorf("expected 1 request, got %d", n)
+	}
+}
+
+func TestPagesHonoursCancelledContext(t *testing.T) {
+	client, server := newPagingTestClient(t, 5)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := ListAll[pagedItem](ctx, client, server.URL+mockserver.KubernetesClustersPath, ListOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if n := len(server.Requests()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+}
+
+func TestPagesRefusesNextLinkToAnotherHost(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":"a"}],"nextLink":"https://attacker.example/v3.0/items?skipToken=1"}`))
+	}))
+	defer server.Close()
+
+	client := &Client{HostURL: server.URL, HTTPClient: &http.Client{}}
+	_, err := ListAll[pagedItem](context.Background(), client, server.URL+"/v3.0/items", ListOptions{})
+	if err == nil || !strings.Contains(err.Error(), "attacker.example") {
+		t.Errorf("expected the foreign nextLink to be refused, got %v", err)
+	}
+}
+
+func TestPagesStopsOnRepeatedNextLink(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"items":[],"nextLink":%q}`, server.URL+"/v3.0/items")
+	}))
+	defer server.Close()
+
+	client := &Client{HostURL: server.URL, HTTPClient: &http.Client{}}
+	if _, err := ListAll[pagedItem](context.Background(), client, server.URL+"/v3.0/items", ListOptions{}); err == nil {
+		t.Error("expected a repeated nextLink to stop paging")
+	}
+}
@@ -0,0 +1,153 @@
+package trendmicro
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimit is the sustained request rate and the burst allowed above it. A zero
+// RequestsPerSecond means unlimited.
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+const (
+	DefaultRequestsPerSecond = 10
+	DefaultBurst             = 20
+	// Cloud account connections fan out to the cloud provider behind Vision One, keep them slower.
+	DefaultCloudAccountManagementRequestsPerSecond = 2
+	DefaultCloudAccountManagementBurst             = 4
+)
+
+// RateLimits holds the rate limit of the client and of each service. A service without its own
+// limit shares the bucket of Default with every other such service.
+type RateLimits struct {
+	Default                RateLimit
+	ContainerSecurity      RateLimit
+	CloudRiskManagement    RateLimit
+	CloudAccountManagement RateLimit
+}
+
+// DefaultRateLimits returns the limits used when the provider configuration sets none.
+func DefaultRateLimits() RateLimits {
+	return RateLimits{
+		Default: RateLimit{RequestsPerSecond: DefaultRequestsPerSecond, Burst: DefaultBurst},
+		CloudAccountManagement: RateLimit{
+			RequestsPerSecond: DefaultCloudAccountManagementRequestsPerSecond,
+			Burst:             DefaultCloudAccountManagementBurst,
+		},
+	}
+}
+
+// WithRateLimits sets the default and per-service rate limits.
+func WithRateLimits(l RateLimits) ClientOption {
+	return func(c *Client) {
+		c.RateLimits = l
+	}
+}
+
+// rateLimiters are the token buckets of a client and the copies derived from it, so that every
+// resource of a provider draws from the same budget.
+type rateLimiters struct {
+	fallback *RateLimiter
+	services map[Service]*RateLimiter
+}
+
+func newRateLimiters(l RateLimits) *rateLimiters {
+	limiters := &rateLimiters{
+		fallback: NewRateLimiter(l.Default),
+		services: map[Service]*RateLimiter{},
+	}
+	for service, limit := range map[Service]RateLimit{
+		ServiceContainerSecurity:      l.ContainerSecurity,
+		ServiceCloudRiskManagement:    l.CloudRiskManagement,
+		ServiceCloudAccountManagement: l.CloudAccountManagement,
+	} {
+		if limit.RequestsPerSecond > 0 {
+			limiters.services[service] = NewRateLimiter(limit)
+		}
+	}
+	return limiters
+}
+
+func (l *rateLimiters) For(service Service) *RateLimiter {
+	if limiter, ok := l.services[service]; ok {
+		return limiter
+	}
+	return l.fallback
+}
+
+// RateLimiter is a token bucket. Each request takes a token; tokens are refilled at the configured
+// rate up to the burst size. Waiting requests are served in arrival order.
+type RateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+// NewRateLimiter returns a limiter for limit, or nil when limit is unlimited. A nil limiter never
+// waits. A burst below one is raised to one.
+func NewRateLimiter(limit RateLimit) *RateLimiter {
+	if limit.RequestsPerSecond <= 0 {
+		return nil
+	}
+	burst := math.Max(float64(limit.Burst), 1)
+	return &RateLimiter{
+		rate:   limit.RequestsPerSecond,
+		burst:  burst,
+		tokens: burst,
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		l.cancel()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// reserve takes a token, letting the bucket go negative, and returns how long the caller has to
+// wait for the token to exist.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	if !l.last.IsZero() {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+	}
+	l.last = now
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.rate * float64(time.Second))
+}
+
+// cancel returns the token of a request that gave up waiting.
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens = math.Min(l.burst, l.tokens+1)
+}
@@ -0,0 +1,66 @@
+package trendmicro
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	now := time.Unix(0, 0)
+	l := NewRateLimiter(RateLimit{RequestsPerSecond: 2, Burst: 2})
+	l.now = func() time.Time { return now }
+
+	if l.reserve() != 0 || l.reserve() != 0 {
+		t.Fatal("the burst should not wait")
+	}
+	if d := l.reserve(); d != 500*time.Millisecond {
+		t.Errorf("third request should wait for one refill, got %s", d)
+	}
+	if d := l.reserve(); d != time.Second {
+		t.Errorf("fourth request should queue behind the third, got %s", d)
+	}
+
+	now = now.Add(10 * time.Second)
+	if d := l.reserve(); d != 0 {
+		t.Errorf("bucket should have refilled, got %s", d)
+	}
+	if l.tokens != 1 {
+		t.Errorf("refill must stop at the burst size, have %v tokens", l.tokens)
+	}
+}
+
+func TestRateLimiterWaitHonorsContext(t *testing.T) {
+	l := NewRateLimiter(RateLimit{RequestsPerSecond: 0.001, Burst: 1})
+	l.now = func() time.Time { return time.Unix(0, 0) }
+	_ = l.Wait(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err == nil {
+		t.Fatal("expected the context deadline to end the wait")
+	}
+	if l.tokens != 0 {
+		t.Errorf("a cancelled wait must give its token back, have %v tokens", l.tokens)
+	}
+
+	var unlimited *RateLimiter
+	if err := unlimited.Wait(context.Background()); err != nil {
+		t.Errorf("a nil limiter must not wait: %v", err)
+	}
+}
+
+func TestForServiceSharesLimiters(t *testing.T) {
+	c := &Client{limiters: newRateLimiters(RateLimits{
+		Default:                RateLimit{RequestsPerSecond: 10, Burst: 10},
+		CloudAccountManagement: RateLimit{RequestsPerSecond: 1, Burst: 1},
+	})}
+
+	cam := c.ForService(ServiceCloudAccountManagement)
+	if cam.limiter == nil || cam.limiter != c.ForService(ServiceCloudAccountManagement).limiter {
+		t.Error("every CAM client must draw from the same bucket")
+	}
+	if cs := c.ForService(ServiceContainerSecurity); cs.limiter != c.limiters.fallback || cs.limiter == cam.limiter {
+		t.Error("a service without its own limit must share the default bucket")
+	}
+}
@@ -0,0 +1,108 @@
+package trendmicro
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RegionalFQDNs maps the region shorthand accepted by the provider to the documented regional
+// domain. Reference: https://automation.trendmicro.com/xdr/Guides/Regional-Domains
+var RegionalFQDNs = map[string]string{
+	"us":  "https://api.xdr.trendmicro.com",
+	"eu":  "https://api.eu.xdr.trendmicro.com",
+	"jp":  "https://api.xdr.trendmicro.co.jp",
+	"sg":  "https://api.sg.xdr.trendmicro.com",
+	"au":  "https://api.au.xdr.trendmicro.com",
+	"in":  "https://api.in.xdr.trendmicro.com",
+	"mea": "https://api.mea.xdr.trendmicro.com",
+	"uk":  "https://api.uk.xdr.trendmicro.com",
+	"ca":  "https://api.ca.xdr.trendmicro.com",
+}
+
+const regionProbeTimeout = 5 * time.Second
+
+// Regions returns the supported region shorthands in alphabetical order.
+func Regions() []string {
+	regions := make([]string, 0, len(RegionalFQDNs))
+	for region := range RegionalFQDNs {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// NormalizeRegionalFQDN checks that raw is an http(s) URL made of scheme and host only, and returns
+// it without a trailing slash.
+func NormalizeRegionalFQDN(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("%q is not a valid URL: %w", raw, err)
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("%q must start with https://, for example %s", raw, RegionalFQDNs["us"])
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host name", raw)
+	}
+	if (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not contain a path or query, only scheme and host such as %s", raw, RegionalFQDNs["us"])
+	}
+	return u.Scheme + "://" + u.Host, nil
+}
+
+// RegionForHost returns the region shorthand of a regional FQDN, or "" for a custom endpoint.
+func RegionForHost(host string) string {
+	normalized, err := NormalizeRegionalFQDN(host)
+	if err != nil {
+		return ""
+	}
+	for region, fqdn := range RegionalFQDNs {
+		if strings.EqualFold(fqdn, normalized) {
+			return region
+		}
+	}
+	return ""
+}
+
+// ProbeRegion checks the API key against the healthcheck of every region other than skipHost and
+// returns the first region accepting it, or "" when none does. It is meant to explain a 401 caused
+// by a key from another region, so failures are not reported. The key is sent to every regional
+// domain, so it must only be called when the user asked for it. The probes go through transport, or
+// http.DefaultTransport when it is nil.
+func ProbeRegion(token, version, skipHost string, transport http.RoundTripper) string {
+	skipRegion := RegionForHost(skipHost)
+
+	var wg sync.WaitGroup
+	result := make(chan string, len(RegionalFQDNs))
+	for region, fqdn := range RegionalFQDNs {
+		if region == skipRegion {
+			continue
+		}
+		wg.Add(1)
+		go func(region, fqdn string) {
+			defer wg.Done()
+			probe := &Client{
+				HostURL:         fqdn,
+				HTTPClient:      &http.Client{Timeout: regionProbeTimeout, Transport: transport},
+				BearerToken:     token,
+				TMUserAgent:     TMUserAgent,
+				ProviderVersion: version,
+			}
+			if _, err := probe.Auth(); err == nil {
+				result <- region
+			}
+		}(region, fqdn)
+	}
+	wg.Wait()
+	close(result)
+
+	if region, ok := <-result; ok {
+		return region
+	}
+	return ""
+}
@@ -0,0 +1,34 @@
+package trendmicro
+
+import "testing"
+
+func TestNormalizeRegionalFQDN(t *testing.T) {
+	cases := []struct {
+		raw     string
+		want    string
+		wantErr bool
+	}{
+		{"https://api.eu.xdr.trendmicro.com", "https://api.eu.xdr.trendmicro.com", false},
+		{"https://api.eu.xdr.trendmicro.com/", "https://api.eu.xdr.trendmicro.com", false},
+		{" https://api.xdr.trendmicro.co.jp ", "https://api.xdr.trendmicro.co.jp", false},
+		{"api.xdr.trendmicro.com", "", true},
+		{"ftp://api.xdr.trendmicro.com", "", true},
+		{"https://api.xdr.trendmicro.com/v3.0", "", true},
+		{"https://", "", true},
+	}
+	for _, tc := range cases {
+		got, err := NormalizeRegionalFQDN(tc.raw)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("NormalizeRegionalFQDN(%q) = %q, %v; want %q, error %v", tc.raw, got, err, tc.want, tc.wantErr)
+		}
+	}
+}
+
+func TestRegionForHost(t *testing.T) {
+	if got := RegionForHost("https://api.sg.xdr.trendmicro.com/"); got != "sg" {
+		t.Errorf("RegionForHost returned %q, want sg", got)
+	}
+	if got := RegionForHost("https://vision-one.example.com"); got != "" {
+		t.Errorf("RegionForHost returned %q for a custom endpoint", got)
+	}
+}
@@ -0,0 +1,79 @@
+// Package credentials holds the schema of the `credentials` attribute shared by the resources that
+// can target a tenant other than the provider's.
+package credentials
+
+import (
+	"context"
+	"regexp"
+
+	"terraform-provider-vision-one/internal/trendmicro"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+const regionalFQDNKey = "regional_fqdn"
+
+// OverrideSchema is the schema of the optional `credentials` attribute, read into a
+// dto.CredentialsOverrideModel.
+func OverrideSchema() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		MarkdownDescription: "Credentials of the Vision One tenant that manages this resource, overriding the provider configuration. " +
+			"Use it to manage several tenants from one provider configuration. Adding or removing it replaces the resource, as its ID belongs to the previous tenant. " +
+			"Resources imported with `terraform import` are read with the provider credentials.",
+		Optional: true,
+		PlanModifiers: []planmodifier.Object{
+			objectplanmodifier.RequiresReplaceIf(
+				tenantChanged,
+				"Adding or removing the credentials, or changing their regional domain, replaces the resource.",
+				"Adding or removing the credentials, or changing their regional domain, replaces the resource.",
+			),
+		},
+		Attributes: map[string]schema.Attribute{
+			"api_key": schema.StringAttribute{
+				MarkdownDescription: "API key of the tenant.",
+				Required:            true,
+				Sensitive:           true,
+			},
+			regionalFQDNKey: schema.StringAttribute{
+				MarkdownDescription: "Regional domain of the tenant, as scheme and host only (for example `https://api.eu.xdr.trendmicro.com`). Changing it replaces the resource, as its ID belongs to the previous tenant.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(`^https?://[^/?#]+/?$`), "must be scheme and host only, such as https://api.xdr.trendmicro.com"),
+				},
+			},
+		},
+	}
+}
+
+// tenantChanged compares the domain the resource is managed through before and after the plan.
+// The modifiers of regional_fqdn do not run when the whole attribute is added or removed, so the
+// comparison is made on the attribute itself.
+func tenantChanged(ctx context.Context, req planmodifier.ObjectRequest, resp *objectplanmodifier.RequiresReplaceIfFuncResponse) {
+	before, beforeKnown := effectiveFQDN(req.StateValue)
+	after, afterKnown := effectiveFQDN(req.PlanValue)
+	resp.RequiresReplace = !beforeKnown || !afterKnown || before != after
+}
+
+// effectiveFQDN returns the normalized regional_fqdn of the credentials, or "" for the provider's
+// domain when they are not set. It reports false while the domain is unknown.
+func effectiveFQDN(credentials types.Object) (string, bool) {
+	if credentials.IsNull() {
+		return "", true
+	}
+	if credentials.IsUnknown() {
+		return "", false
+	}
+	fqdn, ok := credentials.Attributes()[regionalFQDNKey].(types.String)
+	if !ok || fqdn.IsUnknown() {
+		return "", false
+	}
+	if normalized, err := trendmicro.NormalizeRegionalFQDN(fqdn.ValueString()); err == nil {
+		return normalized, true
+	}
+	return fqdn.ValueString(), true
+}
@@ -0,0 +1,59 @@
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestOverrideSchemaReplacesOnTenantChange(t *testing.T) {
+	attribute := OverrideSchema()
+	attributeTypes := attribute.GetType().(types.ObjectType).AttrTypes
+	credentials := func(apiKey, fqdn string) types.Object {
+		return types.ObjectValueMust(attributeTypes, map[string]attr.Value{
+			"api_key":       types.StringValue(apiKey),
+			"regional_fqdn": types.StringValue(fqdn),
+		})
+	}
+	unset := types.ObjectNull(attributeTypes)
+	// The resource exists before and after the plan, RequiresReplaceIf ignores creation and deletion
+	resource := tftypes.NewValue(tftypes.Object{}, map[string]tftypes.Value{})
+
+	tests := []struct {
+		name        string
+		state, plan types.Object
+		wantReplace bool
+	}{
+		{"added", unset, credentials("key", "https://api.eu.xdr.trendmicro.com"), true},
+		{"removed", credentials("key", "https://api.eu.xdr.trendmicro.com"), unset, true},
+		{"other region", credentials("key", "https://api.eu.xdr.trendmicro.com"), credentials("key", "https://api.xdr.trendmicro.com"), true},
+		{"rotated key", credentials("old", "https://api.eu.xdr.trendmicro.com"), credentials("new", "https://api.eu.xdr.trendmicro.com"), false},
+		{"trailing slash", credentials("key", "https://api.eu.xdr.trendmicro.com"), credentials("key", "https://api.eu.xdr.trendmicro.com/"), false},
+		{"unknown", credentials("key", "https://api.eu.xdr.trendmicro.com"), types.ObjectUnknown(attributeTypes), true},
+	}
+	for _, tt := range tests {
+		req := planmodifier.ObjectRequest{
+			State:      tfsdk.State{Raw: resource},
+			Plan:       tfsdk.Plan{Raw: resource},
+			StateValue: tt.state,
+			PlanValue:  tt.plan,
+		}
+		resp := &planmodifier.ObjectResponse{PlanValue: tt.plan}
+		for _, modifier := range attribute.PlanModifiers {
+			modifier.PlanModifyObject(context.Background(), req, resp)
+		}
+		if resp.RequiresReplace != tt.wantReplace {
+			t.Errorf("%s: requires replace is %t, want %t", tt.name, resp.RequiresReplace, tt.wantReplace)
+		}
+	}
+
+	if modifiers := attribute.Attributes["api_key"].(schema.StringAttribute).PlanModifiers; len(modifiers) != 0 {
+		t.Error("a rotated api_key should be updated in place")
+	}
+}
@@ -0,0 +1,161 @@
+package trendmicro
+
+import (
+	"context"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	DefaultMaxRetries      = 3
+	DefaultRetryMinBackoff = 1 * time.Second
+	DefaultRetryMaxBackoff = 30 * time.Second
+)
+
+// RetryConfig controls how the client retries throttled (429) and server side (5xx) failures.
+// The zero value disables retries.
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the initial attempt.
+	MaxRetries int
+	// MinBackoff is the delay before the first retry, doubled on every following attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the delay, including the one a Retry-After header sent by the server asks for.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryConfig returns the retry settings used when the provider configuration leaves them unset.
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: DefaultMaxRetries,
+		MinBackoff: DefaultRetryMinBackoff,
+		MaxBackoff: DefaultRetryMaxBackoff,
+	}
+}
+
+type retryNonIdempotentKey struct{}
+
+// AllowRetry marks a request with a non-idempotent verb (POST, PATCH) as safe to retry.
+// Requests with idempotent verbs are always eligible and do not need it.
+func AllowRetry(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), retryNonIdempotentKey{}, true))
+}
+
+func isRetryableMethod(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	allowed, _ := req.Context().Value(retryNonIdempotentKey{}).(bool)
+	return allowed
+}
+
+func isRetryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given either as delay seconds or as an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if d := at.Sub(now); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// backoff returns the delay before retry number attempt (0-based): exponential growth from
+// MinBackoff capped at MaxBackoff, with the upper half randomized to spread out parallel callers.
+func (rc RetryConfig) backoff(attempt int) time.Duration {
+	d := rc.MinBackoff
+	for i := 0; i < attempt && d < rc.MaxBackoff; i++ {
+		d *= 2
+	}
+	if rc.MaxBackoff > 0 && d > rc.MaxBackoff {
+		d = rc.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+// send executes req through the HTTP client, retrying 429/5xx responses and transport errors
+// for retryable requests. Every attempt waits for the rate limiter first. The returned response is
+// the last one received; the caller owns its body.
+func (c *Client) send(req *http.Request) (*http.Response, error) {
+	rc := c.Retry
+	if rc.MaxRetries <= 0 || !isRetryableMethod(req) {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		return c.HTTPClient.Do(req)
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+		res, err := c.HTTPClient.Do(req)
+
+		if attempt >= rc.MaxRetries || req.Context().Err() != nil {
+			return res, err
+		}
+		if err == nil && !isRetryableStatus(res.StatusCode) {
+			return res, nil
+		}
+		// The body of a request can only be replayed when net/http knows how to rebuild it.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return res, err
+		}
+
+		delay := rc.backoff(attempt)
+		if err == nil {
+			if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+				if rc.MaxBackoff > 0 {
+					delay = min(retryAfter, rc.MaxBackoff)
+				}
+			}
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return nil, bodyErr
+			}
+			req.Body = body
+		}
+	}
+}
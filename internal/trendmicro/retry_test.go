@@ -0,0 +1,156 @@
+package trendmicro
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newRetryTestClient(url string, maxRetries int) *Client {
+	return &Client{
+		HostURL:    url,
+		HTTPClient: &http.Client{},
+		Retry: RetryConfig{
+			MaxRetries: maxRetries,
+			MinBackoff: time.Millisecond,
+			MaxBackoff: 5 * time.Millisecond,
+		},
+	}
+}
+
+func TestDoRequestRetriesThrottledGet(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"available"}`))
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, http.NoBody)
+	body, err := newRetryTestClient(server.URL, 3).DoRequest(req)
+	if err != nil {
+		t.Fatalf("DoRequest returned error: %v", err)
+	}
+	if string(body) != `{"status":"available"}` {
+		t.Errorf("unexpected body %q", body)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoRequestReplaysBodyOnRetry(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != `{"a":1}` {
+			t.Errorf("attempt %d got body %q", atomic.LoadInt32(&calls)+1, body)
+		}
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("PUT", server.URL, bytes.NewBufferString(`{"a":1}`))
+	if _, err := newRetryTestClient(server.URL, 2).DoRequest(req); err != nil {
+		t.Fatalf("DoRequest returned error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestDoRequestDoesNotRetryPostByDefault(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := newRetryTestClient(server.URL, 3)
+
+	req, _ := http.NewRequest("POST", server.URL, bytes.NewBufferString(`{}`))
+	if _, err := client.DoRequest(req); err == nil {
+		t.Fatal("expected an error for 502")
+	}
+	if calls != 1 {
+		t.Errorf("POST should not be retried, got %d calls", calls)
+	}
+
+	atomic.StoreInt32(&calls, 0)
+	req, _ = http.NewRequest("POST", server.URL, bytes.NewBufferString(`{}`))
+	if _, err := client.DoRequest(AllowRetry(req)); err == nil {
+		t.Fatal("expected an error for 502")
+	}
+	if calls != 4 {
+		t.Errorf("POST marked with AllowRetry should be retried, got %d calls", calls)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"7", 7 * time.Second, true},
+		{"-1", 0, false},
+		{now.Add(30 * time.Second).Format(http.TimeFormat), 30 * time.Second, true},
+		{"soon", 0, false},
+	}
+	for _, tc := range cases {
+		got, ok := parseRetryAfter(tc.value, now)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tc.value, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestBackoffStaysWithinBounds(t *testing.T) {
+	rc := RetryConfig{MaxRetries: 5, MinBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+	for attempt := 0; attempt < 10; attempt++ {
+		d := rc.backoff(attempt)
+		if d < rc.MinBackoff/2 || d > rc.MaxBackoff {
+			t.Errorf("backoff(%d) = %v outside [%v, %v]", attempt, d, rc.MinBackoff/2, rc.MaxBackoff)
+		}
+	}
+}
+
+func TestDoRequestCapsRetryAfter(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	start := time.Now()
+	req, _ := http.NewRequest("GET", server.URL, http.NoBody)
+	if _, err := newRetryTestClient(server.URL, 1).DoRequest(req); err != nil {
+		t.Fatalf("DoRequest returned error: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Retry-After should be capped at MaxBackoff, waited %v", elapsed)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
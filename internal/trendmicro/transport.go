@@ -0,0 +1,150 @@
+package trendmicro
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Service is a Vision One API area that has its own request timeout.
+type Service int
+
+const (
+	ServiceContainerSecurity Service = iota
+	ServiceCloudRiskManagement
+	ServiceCloudAccountManagement
+)
+
+const (
+	DefaultRequestTimeout                = 10 * time.Second
+	DefaultCloudRiskManagementTimeout    = 60 * time.Second
+	DefaultCloudAccountManagementTimeout = 60 * time.Second
+	DefaultContainerSecurityTimeout      = DefaultRequestTimeout
+)
+
+// Timeouts holds the HTTP request timeout of the client and of each service. A zero service
+// timeout falls back to Default.
+type Timeouts struct {
+	Default                time.Duration
+	ContainerSecurity      time.Duration
+	CloudRiskManagement    time.Duration
+	CloudAccountManagement time.Duration
+}
+
+// DefaultTimeouts returns the timeouts used when the provider configuration sets none.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{
+		Default:                DefaultRequestTimeout,
+		ContainerSecurity:      DefaultContainerSecurityTimeout,
+		CloudRiskManagement:    DefaultCloudRiskManagementTimeout,
+		CloudAccountManagement: DefaultCloudAccountManagementTimeout,
+	}
+}
+
+// For returns the timeout of service.
+func (t Timeouts) For(service Service) time.Duration {
+	var d time.Duration
+	switch service {
+	case ServiceContainerSecurity:
+		d = t.ContainerSecurity
+	case ServiceCloudRiskManagement:
+		d = t.CloudRiskManagement
+	case ServiceCloudAccountManagement:
+		d = t.CloudAccountManagement
+	}
+	if d == 0 {
+		return t.Default
+	}
+	return d
+}
+
+// WithTimeouts sets the default and per-service request timeouts.
+func WithTimeouts(t Timeouts) ClientOption {
+	return func(c *Client) {
+		c.Timeouts = t
+	}
+}
+
+// WithTransport makes the client send its requests through rt, e.g. a transport built by NewTransport.
+func WithTransport(rt http.RoundTripper) ClientOption {
+	return func(c *Client) {
+		c.HTTPClient.Transport = rt
+	}
+}
+
+// ForService returns a copy of the client using the request timeout and the rate limiter
+// configured for service.
+func (c *Client) ForService(service Service) *Client {
+	serviceClient := c
+	if d := c.Timeouts.For(service); d != 0 {
+		serviceClient = c.WithTimeout(d)
+	}
+	if c.limiters != nil {
+		if serviceClient == c {
+			clientCopy := *c
+			serviceClient = &clientCopy
+		}
+		serviceClient.limiter = c.limiters.For(service)
+	}
+	return serviceClient
+}
+
+// TransportConfig describes how the client reaches Vision One: through which proxy, which
+// certificate authorities it trusts and which client certificate it presents.
+type TransportConfig struct {
+	// ProxyURL is the proxy for all requests. When empty the HTTPS_PROXY, HTTP_PROXY and NO_PROXY
+	// environment variables apply.
+	ProxyURL string
+	// CACertPEM holds PEM encoded certificate authorities trusted in addition to the system pool.
+	CACertPEM []byte
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded certificate and key presented for mTLS.
+	ClientCertPEM []byte
+	ClientKeyPEM  []byte
+	// InsecureSkipVerify disables server certificate verification.
+	InsecureSkipVerify bool
+}
+
+// NewTransport builds an HTTP transport from cfg, starting from the defaults of http.DefaultTransport.
+func NewTransport(cfg TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.ProxyURL != "" {
+		proxyURL, err := url.Parse(cfg.ProxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL %q: %w", cfg.ProxyURL, err)
+		}
+		if proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("invalid proxy URL %q: scheme and host are required, e.g. http://proxy.example.com:3128", cfg.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if len(cfg.CACertPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(cfg.CACertPEM) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in the CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if len(cfg.ClientCertPEM) > 0 || len(cfg.ClientKeyPEM) > 0 {
+		cert, err := tls.X509KeyPair(cfg.ClientCertPEM, cfg.ClientKeyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate or key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	tlsConfig.InsecureSkipVerify = cfg.InsecureSkipVerify // #nosec G402 -- explicitly requested by the user, who is warned
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
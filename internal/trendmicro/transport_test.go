@@ -0,0 +1,98 @@
+package trendmicro
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewTransportTrustsCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	plain, err := NewTransport(TransportConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := (&http.Client{Transport: plain}).Get(server.URL); err == nil {
+		t.Error("expected the self-signed certificate to be rejected without the CA bundle")
+	}
+
+	trusting, err := NewTransport(TransportConfig{CACertPEM: caPEM})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: trusting}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("request with the CA bundle failed: %v", err)
+	}
+	res.Body.Close()
+
+	if _, err := NewTransport(TransportConfig{CACertPEM: []byte("not a certificate")}); err == nil {
+		t.Error("expected an error for a CA bundle without certificates")
+	}
+}
+
+func TestNewTransportPresentsClientCertificate(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	// The test server certificate doubles as client certificate.
+	cert := server.TLS.Certificates[0]
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: cert.Certificate[0]})
+	keyDER, err := x509.MarshalPKCS8PrivateKey(cert.PrivateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	transport, err := NewTransport(TransportConfig{ClientCertPEM: certPEM, ClientKeyPEM: keyPEM, InsecureSkipVerify: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: transport}).Get(server.URL)
+	if err != nil {
+		t.Fatalf("mTLS request failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		t.Errorf("server did not receive the client certificate, got %d", res.StatusCode)
+	}
+}
+
+func TestNewTransportRejectsProxyWithoutScheme(t *testing.T) {
+	if _, err := NewTransport(TransportConfig{ProxyURL: "proxy.example.com:3128"}); err == nil {
+		t.Error("expected an error for a proxy URL without scheme")
+	}
+	if _, err := NewTransport(TransportConfig{ProxyURL: "http://proxy.example.com:3128"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestForServiceKeepsTransport(t *testing.T) {
+	transport := &http.Transport{}
+	c := &Client{HTTPClient: &http.Client{Transport: transport}}
+	WithTimeouts(Timeouts{Default: 5 * time.Second, CloudRiskManagement: time.Minute})(c)
+
+	crm := c.ForService(ServiceCloudRiskManagement)
+	if crm.HTTPClient.Timeout != time.Minute || crm.HTTPClient.Transport != transport {
+		t.Errorf("unexpected CRM client timeout %s, transport %v", crm.HTTPClient.Timeout, crm.HTTPClient.Transport)
+	}
+	if cs := c.ForService(ServiceContainerSecurity); cs.HTTPClient.Timeout != 5*time.Second {
+		t.Errorf("Container Security should fall back to the default timeout, got %s", cs.HTTPClient.Timeout)
+	}
+	if c.HTTPClient.Timeout != 0 {
+		t.Error("ForService modified the original client")
+	}
+}
@@ -0,0 +1,16 @@
+package cloud_risk_management_dto
+
+// CheckResource is a check in the list returned by GET /checks.
+type CheckResource struct {
+	ID                   string   `json:"id"`
+	AccountID            string   `json:"accountId"`
+	RuleID               string   `json:"ruleId"`
+	Service              string   `json:"service"`
+	Region               string   `json:"region"`
+	ResourceID           string   `json:"resource"`
+	Status               string   `json:"status"`
+	RiskLevel            string   `json:"riskLevel"`
+	Suppressed           bool     `json:"suppressed"`
+	Tags                 []string `json:"tags"`
+	LastModifiedDateTime string   `json:"lastModifiedDateTime"`
+}
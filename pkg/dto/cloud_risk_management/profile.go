@@ -6,6 +6,9 @@ type Profile struct {
 	Name        string     `json:"name"`
 	Description *string    `json:"description,omitempty"`
 	ScanRules   []ScanRule `json:"scanRules,omitempty"`
+
+	// ETag is the version of the profile returned in the ETag header.
+	ETag string `json:"-"`
 }
 
 // CreateProfileRequest represents the request to create a profile
@@ -0,0 +1,23 @@
+package cloud_risk_management_dto
+
+// Rule is a rule of the Cloud Risk Management rules catalog.
+type Rule struct {
+	ID                  string               `json:"id"`
+	Title               string               `json:"title"`
+	Description         string               `json:"description"`
+	Provider            string               `json:"provider"`
+	Service             string               `json:"service"`
+	RiskLevel           string               `json:"riskLevel"`
+	Categories          []string             `json:"categories"`
+	ComplianceStandards []ComplianceStandard `json:"complianceStandards"`
+	ExtraSettings       []RuleExtraSetting   `json:"extraSettings"`
+	Deprecated          bool                 `json:"deprecated"`
+}
+
+// ComplianceStandardDetail is a compliance standard rules can be mapped to.
+type ComplianceStandardDetail struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Providers   []string `json:"providers"`
+}
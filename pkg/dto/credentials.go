@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+// CredentialsOverrideModel is the optional `credentials` attribute of a resource. When set, the
+// resource talks to the Vision One tenant it names instead of the one configured on the provider.
+type CredentialsOverrideModel struct {
+	ApiKey  types.String `tfsdk:"api_key"`
+	RegFQDN types.String `tfsdk:"regional_fqdn"`
+}
@@ -14,6 +14,7 @@ type CreateRulesetRequest struct {
 	Description string `json:"description"`
 	Labels      []Label
 	Rules       []Rule
+	CustomRules []CustomRule `json:"customRules"`
 }
 
 type Label struct {
@@ -27,6 +28,17 @@ type Rule struct {
 	Mitigation string `json:"mitigation"`
 }
 
+// CustomRule is a runtime rule authored in a ruleset, with a Falco condition and output.
+type CustomRule struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Condition   string   `json:"condition"`
+	Output      string   `json:"output"`
+	Priority    string   `json:"priority"`
+	Tags        []string `json:"tags,omitempty"`
+	Mitigation  string   `json:"mitigation,omitempty"`
+}
+
 type DeleteClusterRequest struct {
 	ID string
 }
@@ -43,6 +55,27 @@ type UpdateClusterRequest struct {
 	CustomizableTagIDs []string `json:"customizableTagIds"`
 }
 
+// UpdateEcsClusterRequest assigns a policy to an Amazon ECS cluster, like UpdateClusterRequest does for
+// Kubernetes, and toggles the features that are configured from Vision One rather than an agent.
+type UpdateEcsClusterRequest struct {
+	PolicyId                 string `json:"policyId"`
+	RuntimeSecurityEnabled   bool   `json:"runtimeSecurityEnabled"`
+	VulnerabilityScanEnabled bool   `json:"vulnerabilityScanEnabled"`
+}
+
+// Container Security - Cluster Group and Customizable Tag Request
+
+type ClusterGroupRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// CustomizableTagRequest creates or updates a custom tag, a key and value pair assigned to assets such as clusters.
+type CustomizableTagRequest struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // Container Security - Policy Request
 
 type CreatePolicyRequest struct {
@@ -118,11 +151,13 @@ type PolicyRuleProperty struct {
 }
 
 type UpdatePolicyRequest struct {
-	Description          string             `json:"description,omitempty"`
-	PolicyDefault        *PolicyDefault     `json:"default,omitempty"`
-	PolicyNamespacedList []PolicyNamespaced `json:"namespaced,omitempty"`
-	PolicyRuntime        *PolicyRuntime     `json:"runtime,omitempty"`
-	XdrEnabled           bool               `json:"xdrEnabled"`
+	Description   string         `json:"description,omitempty"`
+	PolicyDefault *PolicyDefault `json:"default,omitempty"`
+	// PolicyNamespacedList is left out when nil, so partial updates keep the namespaced definitions. An
+	// empty list removes every namespaced definition.
+	PolicyNamespacedList *[]PolicyNamespaced `json:"namespaced,omitempty"`
+	PolicyRuntime        *PolicyRuntime      `json:"runtime,omitempty"`
+	XdrEnabled           bool                `json:"xdrEnabled"`
 
 	MalwareScan *MalwareScan `json:"malwareScan,omitempty"`
 	SecretScan  *SecretScan  `json:"secretScan,omitempty"`
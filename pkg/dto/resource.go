@@ -14,27 +14,85 @@ var (
 	MissingProxyAddressOrPort = errors.New("missing proxy address or port")
 )
 
+// ClusterAPIKeyRotationResourceModel is a regenerated API key of a cluster.
+type ClusterAPIKeyRotationResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	ClusterID       types.String `tfsdk:"cluster_id"`
+	RotationTrigger types.Map    `tfsdk:"rotation_trigger"`
+	RotateAfter     types.String `tfsdk:"rotate_after"`
+	ApiKey          types.String `tfsdk:"api_key"`
+	RotatedAt       types.String `tfsdk:"rotated_at"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
 type ClusterResourceModel struct {
-	ID                       types.String     `tfsdk:"id"`
-	Name                     types.String     `tfsdk:"name"`
-	Description              types.String     `tfsdk:"description"`
-	PolicyId                 types.String     `tfsdk:"policy_id"`
-	ResourceId               types.String     `tfsdk:"resource_id"`
-	ApiKey                   types.String     `tfsdk:"api_key"`
-	Endpoint                 types.String     `tfsdk:"endpoint"`
-	Orchestrator             types.String     `tfsdk:"orchestrator"`
-	CreatedDateTime          types.String     `tfsdk:"created_date_time"`
-	UpdatedDateTime          types.String     `tfsdk:"updated_date_time"`
-	LastEvaluatedDateTime    types.String     `tfsdk:"last_evaluated_date_time"`
-	GroupId                  types.String     `tfsdk:"group_id"`
-	Namespaces               types.Set        `tfsdk:"namespaces"`
-	RuntimeSecurityEnabled   types.Bool       `tfsdk:"runtime_security_enabled"`
-	VulnerabilityScanEnabled types.Bool       `tfsdk:"vulnerability_scan_enabled"`
-	MalwareScanEnabled       types.Bool       `tfsdk:"malware_scan_enabled"`
-	SecretScanEnabled        types.Bool       `tfsdk:"secret_scan_enabled"`
-	InventoryCollection      types.Bool       `tfsdk:"inventory_collection"`
-	Proxy                    ProxyDetailModel `tfsdk:"proxy"`
-	CustomizableTagIDs       types.Set        `tfsdk:"customizable_tags"`
+	ID                       types.String             `tfsdk:"id"`
+	Name                     types.String             `tfsdk:"name"`
+	Description              types.String             `tfsdk:"description"`
+	PolicyId                 types.String             `tfsdk:"policy_id"`
+	ResourceId               types.String             `tfsdk:"resource_id"`
+	ApiKey                   types.String             `tfsdk:"api_key"`
+	Endpoint                 types.String             `tfsdk:"endpoint"`
+	Orchestrator             types.String             `tfsdk:"orchestrator"`
+	CreatedDateTime          types.String             `tfsdk:"created_date_time"`
+	UpdatedDateTime          types.String             `tfsdk:"updated_date_time"`
+	LastEvaluatedDateTime    types.String             `tfsdk:"last_evaluated_date_time"`
+	GroupId                  types.String             `tfsdk:"group_id"`
+	Namespaces               types.Set                `tfsdk:"namespaces"`
+	RuntimeSecurityEnabled   types.Bool               `tfsdk:"runtime_security_enabled"`
+	VulnerabilityScanEnabled types.Bool               `tfsdk:"vulnerability_scan_enabled"`
+	MalwareScanEnabled       types.Bool               `tfsdk:"malware_scan_enabled"`
+	SecretScanEnabled        types.Bool               `tfsdk:"secret_scan_enabled"`
+	InventoryCollection      types.Bool               `tfsdk:"inventory_collection"`
+	Proxy                    ProxyDetailModel         `tfsdk:"proxy"`
+	CustomizableTagIDs       types.Set                `tfsdk:"customizable_tags"`
+	Platform                 types.String             `tfsdk:"platform"`
+	HelmValuesYaml           types.String             `tfsdk:"helm_values_yaml"`
+	DeletionProtection       *DeletionProtectionModel `tfsdk:"deletion_protection"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
+// EcsClusterResourceModel is the Container Security configuration of a discovered Amazon ECS cluster.
+type EcsClusterResourceModel struct {
+	ID                       types.String `tfsdk:"id"`
+	ClusterArn               types.String `tfsdk:"cluster_arn"`
+	Name                     types.String `tfsdk:"name"`
+	AwsAccountID             types.String `tfsdk:"aws_account_id"`
+	Region                   types.String `tfsdk:"region"`
+	PolicyId                 types.String `tfsdk:"policy_id"`
+	RuntimeSecurityEnabled   types.Bool   `tfsdk:"runtime_security_enabled"`
+	VulnerabilityScanEnabled types.Bool   `tfsdk:"vulnerability_scan_enabled"`
+	CreatedDateTime          types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime          types.String `tfsdk:"updated_date_time"`
+	LastEvaluatedDateTime    types.String `tfsdk:"last_evaluated_date_time"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
+type ClusterGroupResourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	CreatedDateTime types.String `tfsdk:"created_date_time"`
+	UpdatedDateTime types.String `tfsdk:"updated_date_time"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
+type CustomizableTagResourceModel struct {
+	ID    types.String `tfsdk:"id"`
+	Key   types.String `tfsdk:"key"`
+	Value types.String `tfsdk:"value"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
+// DeletionProtectionModel keeps a cluster from being deleted while its agents are still reporting.
+type DeletionProtectionModel struct {
+	AgentWindow       types.String `tfsdk:"agent_window"`
+	WaitForAgentsGone types.String `tfsdk:"wait_for_agents_gone"`
 }
 
 type ProxyDetailModel struct {
@@ -68,6 +126,8 @@ type PolicyResourceModel struct {
 	SecretScanMitigation          types.String   `tfsdk:"secret_scan_mitigation"`
 	SecretScanSkipIfRuleNotChange types.Bool     `tfsdk:"secret_scan_skip_if_rule_not_change"`
 	SecretScanExcludePaths        []types.String `tfsdk:"secret_scan_exclude_paths"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
 }
 
 type PolicyDefaultResourceModel struct {
@@ -90,12 +150,95 @@ type PolicyNamespacedResourceModel struct {
 	PolicyExceptionList []PolicyRuleResourceModel `tfsdk:"exceptions"`
 }
 
+// NamespacedPolicyResourceModel is one namespaced definition of a policy, managed on its own.
+type NamespacedPolicyResourceModel struct {
+	ID                  types.String              `tfsdk:"id"`
+	PolicyID            types.String              `tfsdk:"policy_id"`
+	Name                types.String              `tfsdk:"name"`
+	Namespaces          []types.String            `tfsdk:"namespaces"`
+	PolicyRuleList      []PolicyRuleResourceModel `tfsdk:"rules"`
+	PolicyExceptionList []PolicyRuleResourceModel `tfsdk:"exceptions"`
+
+	Credentials *CredentialsOverrideModel `tfsdk:"credentials"`
+}
+
 type PolicyRuleResourceModel struct {
 	Type                types.String                      `tfsdk:"type"`
 	Enabled             types.Bool                        `tfsdk:"enabled"`
 	Action              types.String                      `tfsdk:"action"`
 	Mitigation          types.String                      `tfsdk:"mitigation"`
 	PolicyRuleStatement *PolicyRuleStatementResourceModel `tfsdk:"statement"`
+
+	// Typed statements, each converted to the statement properties of one rule type.
+	PodSecurityContext       *PodSecurityContextStatementModel       `tfsdk:"pod_security_context"`
+	ContainerSecurityContext *ContainerSecurityContextStatementModel `tfsdk:"container_security_context"`
+	Registry                 *ImageMatchStatementModel               `tfsdk:"registry"`
+	Image                    *ImageMatchStatementModel               `tfsdk:"image"`
+	Tag                      *ImageMatchStatementModel               `tfsdk:"tag"`
+	ImagePath                *ImageMatchStatementModel               `tfsdk:"image_path"`
+	Vulnerabilities          *SeverityStatementModel                 `tfsdk:"vulnerabilities"`
+	CvssAttackVector         *CvssAttackVectorStatementModel         `tfsdk:"cvss_attack_vector"`
+	CvssAttackComplexity     *CvssAttackComplexityStatementModel     `tfsdk:"cvss_attack_complexity"`
+	CvssAvailability         *CvssAvailabilityStatementModel         `tfsdk:"cvss_availability"`
+	Checklists               *SeverityStatementModel                 `tfsdk:"checklists"`
+	ChecklistProfile         *ChecklistProfileStatementModel         `tfsdk:"checklist_profile"`
+	Malware                  *CountStatementModel                    `tfsdk:"malware"`
+	Secrets                  *CountStatementModel                    `tfsdk:"secrets"`
+	UnscannedImageMalware    *DaysStatementModel                     `tfsdk:"unscanned_image_malware"`
+	UnscannedImageSecret     *DaysStatementModel                     `tfsdk:"unscanned_image_secret"`
+	PodExec                  types.Bool                              `tfsdk:"pod_exec"`
+	PortForward              types.Bool                              `tfsdk:"port_forward"`
+}
+
+type PodSecurityContextStatementModel struct {
+	HostNetwork types.Bool `tfsdk:"host_network"`
+	HostIPC     types.Bool `tfsdk:"host_ipc"`
+	HostPID     types.Bool `tfsdk:"host_pid"`
+}
+
+type ContainerSecurityContextStatementModel struct {
+	RunAsNonRoot             types.Bool   `tfsdk:"run_as_non_root"`
+	Privileged               types.Bool   `tfsdk:"privileged"`
+	AllowPrivilegeEscalation types.Bool   `tfsdk:"allow_privilege_escalation"`
+	ReadOnlyRootFilesystem   types.Bool   `tfsdk:"read_only_root_filesystem"`
+	CapabilitiesRule         types.String `tfsdk:"capabilities_rule"`
+}
+
+type ImageMatchStatementModel struct {
+	Operator types.String `tfsdk:"operator"`
+	Value    types.String `tfsdk:"value"`
+}
+
+type SeverityStatementModel struct {
+	MaxSeverity types.String `tfsdk:"max_severity"`
+}
+
+type CvssAttackVectorStatementModel struct {
+	AttackVector types.String `tfsdk:"attack_vector"`
+	MaxSeverity  types.String `tfsdk:"max_severity"`
+}
+
+type CvssAttackComplexityStatementModel struct {
+	AttackComplexity types.String `tfsdk:"attack_complexity"`
+	MaxSeverity      types.String `tfsdk:"max_severity"`
+}
+
+type CvssAvailabilityStatementModel struct {
+	Availability types.String `tfsdk:"availability"`
+	MaxSeverity  types.String `tfsdk:"max_severity"`
+}
+
+type ChecklistProfileStatementModel struct {
+	Profile     types.String `tfsdk:"profile"`
+	MaxSeverity types.String `tfsdk:"max_severity"`
+}
+
+type CountStatementModel struct {
+	Count types.Int64 `tfsdk:"count"`
+}
+
+type DaysStatementModel struct {
+	Days types.Int64 `tfsdk:"days"`
 }
 
 type PolicyRuleStatementResourceModel struct {
@@ -5,11 +5,13 @@ import (
 )
 
 var (
-	ErrorBadRequest = errors.New("bad request")
-	ErrorNotFound   = errors.New("resource not found")
-	ErrorForbidden  = errors.New("forbidden")
-	ErrorInternal   = errors.New("internal error")
-	Unauthorized    = errors.New("unauthorized access or invalid api key")
+	ErrorBadRequest         = errors.New("bad request")
+	ErrorNotFound           = errors.New("resource not found")
+	ErrorForbidden          = errors.New("forbidden")
+	ErrorInternal           = errors.New("internal error")
+	ErrorTooManyRequests    = errors.New("too many requests, retries exhausted")
+	ErrorPreconditionFailed = errors.New("resource modified since it was read")
+	Unauthorized            = errors.New("unauthorized access or invalid api key")
 )
 
 type CreateClusterResponse struct {
@@ -18,6 +20,10 @@ type CreateClusterResponse struct {
 	Endpoint string `json:"endpointUrl"`
 }
 
+type RegenerateClusterAPIKeyResponse struct {
+	ApiKey string `json:"apiKey"`
+}
+
 type ListClusterResponse struct {
 	Items      []ClusterItem `json:"items"`
 	TotalCount int           `json:"totalCount"`
@@ -36,6 +42,7 @@ type ClusterItem struct {
 	VulnerabilityScanEnabled bool     `json:"vulnerabilityScanEnabled"`
 	MalwareScanEnabled       bool     `json:"malwareScanEnabled"`
 	PolicyId                 string   `json:"policyId"`
+	GroupId                  string   `json:"groupId"`
 	Orchestrator             string   `json:"orchestrator"`
 	Nodes                    []Node   `json:"nodes"`
 	ResourceId               string   `json:"resourceId"`
@@ -66,10 +73,51 @@ type RulesetResponse struct {
 	Description     string `json:"description"`
 	Labels          []Label
 	Rules           []Rule
+	CustomRules     []CustomRule `json:"customRules"`
+	CreatedDateTime string       `json:"createdDateTime"`
+	UpdatedDateTime string       `json:"updatedDateTime"`
+}
+
+// ManagedRule is a runtime rule provided by Trend Micro that rulesets enable by ID.
+type ManagedRule struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+	Mitigation  string   `json:"mitigation"`
+}
+
+// EcsClusterItem is an Amazon ECS cluster Vision One discovered in a connected AWS account.
+type EcsClusterItem struct {
+	ID                       string `json:"id"`
+	Name                     string `json:"name"`
+	AwsAccountID             string `json:"awsAccountId"`
+	Region                   string `json:"region"`
+	ClusterArn               string `json:"clusterArn"`
+	PolicyId                 string `json:"policyId"`
+	RuntimeSecurityEnabled   bool   `json:"runtimeSecurityEnabled"`
+	VulnerabilityScanEnabled bool   `json:"vulnerabilityScanEnabled"`
+	CreatedDateTime          string `json:"createdDateTime"`
+	UpdatedDateTime          string `json:"updatedDateTime"`
+	LastEvaluatedDateTime    string `json:"lastEvaluatedDateTime"`
+}
+
+// Container Security - Cluster Group and Customizable Tag
+
+type ClusterGroupResponse struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Description     string `json:"description"`
 	CreatedDateTime string `json:"createdDateTime"`
 	UpdatedDateTime string `json:"updatedDateTime"`
 }
 
+type CustomizableTagResponse struct {
+	ID    string `json:"id"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // Container Security - Policy
 
 type PolicyResponse struct {
@@ -87,6 +135,9 @@ type PolicyResponse struct {
 
 	MalwareScan *MalwareScan `json:"malwareScan,omitempty"`
 	SecretScan  *SecretScan  `json:"secretScan,omitempty"`
+
+	// ETag is the version of the policy returned in the ETag header.
+	ETag string `json:"-"`
 }
 
 type ListPolicyResponse struct {